@@ -2,12 +2,23 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/you/linkedinify/internal/config"
+	"github.com/you/linkedinify/internal/logging"
 	"github.com/you/linkedinify/internal/router"
+	"github.com/you/linkedinify/internal/telemetry"
 )
 
 func main() {
@@ -19,30 +30,85 @@ func main() {
 	}
 
 	// Try loading from each path until successful
-	var loaded bool
+	var loadedFrom string
 	for _, path := range envPaths {
 		if err := godotenv.Load(path); err == nil {
-			log.Printf("✓ Successfully loaded .env file from %s", path)
-			loaded = true
+			loadedFrom = path
 			break
 		}
 	}
 
-	if !loaded {
-		log.Println("⚠ Warning: Could not load .env file - using default configuration")
+	cfg, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if cfg.PrintConfig {
+		if perr := cfg.Print(os.Stdout); perr != nil {
+			log.Fatalf("FATAL: %v", perr)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration:\n%v\n", err)
+		os.Exit(2)
+	}
+	if cfg.PrintConfig {
+		return
+	}
+
+	logger, err := logging.New(os.Stdout, cfg.LogLevel, cfg.LogFormat)
+	if err != nil {
+		log.Fatalf("FATAL: %v", err)
+	}
+	// Route the standard library logger (used by dependencies) through slog too.
+	slog.SetDefault(logger)
+
+	if loadedFrom != "" {
+		logger.Info("loaded .env file", "path", loadedFrom)
+	} else {
+		logger.Warn("could not load .env file - using environment only")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	shutdownTracing, err := telemetry.Setup(ctx, cfg.TraceExporter, cfg.TraceSampleRatio)
+	if err != nil {
+		logger.Error("failed to set up tracing", "err", err)
+		os.Exit(1)
 	}
-	cfg := config.Load()
 
 	// Create the router, which now includes all middleware
-	appRouter := router.New(cfg)
+	appRouter := router.New(ctx, cfg, logger)
 
 	server := &http.Server{
-		Addr:    cfg.HTTPAddr,
-		Handler: appRouter, // Use the router from the router package directly
+		Addr:     cfg.HTTPAddr,
+		Handler:  appRouter, // Use the router from the router package directly
+		ErrorLog: slog.NewLogLogger(logger.Handler(), slog.LevelError),
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		logger.Info("server starting", "addr", cfg.HTTPAddr)
+		serverErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("server failed to start", "err", err)
+			os.Exit(1)
+		}
+	case <-ctx.Done():
+		logger.Info("shutting down")
 	}
 
-	log.Printf("⇢ Server starting on %s", cfg.HTTPAddr)
-	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		log.Fatalf("Server failed to start: %v", err)
+	// Drain in-flight requests, then flush any buffered spans.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Error("graceful shutdown failed", "err", err)
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Error("failed to flush traces", "err", err)
 	}
 }
@@ -18,9 +18,15 @@ var _ Client = &ClientMock{}
 //
 //		// make and configure a mocked Client
 //		mockedClient := &ClientMock{
-//			TransformFunc: func(ctx context.Context, text string) (string, error) {
+//			DescribeVoiceFunc: func(ctx context.Context, examples []string) (string, error) {
+//				panic("mock out the DescribeVoice method")
+//			},
+//			TransformFunc: func(ctx context.Context, text string, opts Options) (string, error) {
 //				panic("mock out the Transform method")
 //			},
+//			TranslateFunc: func(ctx context.Context, text string, language string) (string, error) {
+//				panic("mock out the Translate method")
+//			},
 //		}
 //
 //		// use mockedClient in code that requires Client
@@ -28,38 +34,102 @@ var _ Client = &ClientMock{}
 //
 //	}
 type ClientMock struct {
+	// DescribeVoiceFunc mocks the DescribeVoice method.
+	DescribeVoiceFunc func(ctx context.Context, examples []string) (string, error)
+
 	// TransformFunc mocks the Transform method.
-	TransformFunc func(ctx context.Context, text string) (string, error)
+	TransformFunc func(ctx context.Context, text string, opts Options) (string, error)
+
+	// TranslateFunc mocks the Translate method.
+	TranslateFunc func(ctx context.Context, text string, language string) (string, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// DescribeVoice holds details about calls to the DescribeVoice method.
+		DescribeVoice []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Examples is the examples argument value.
+			Examples []string
+		}
 		// Transform holds details about calls to the Transform method.
 		Transform []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Text is the text argument value.
 			Text string
+			// Opts is the opts argument value.
+			Opts Options
+		}
+		// Translate holds details about calls to the Translate method.
+		Translate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Text is the text argument value.
+			Text string
+			// Language is the language argument value.
+			Language string
 		}
 	}
-	lockTransform sync.RWMutex
+	lockDescribeVoice sync.RWMutex
+	lockTransform     sync.RWMutex
+	lockTranslate     sync.RWMutex
+}
+
+// DescribeVoice calls DescribeVoiceFunc.
+func (mock *ClientMock) DescribeVoice(ctx context.Context, examples []string) (string, error) {
+	if mock.DescribeVoiceFunc == nil {
+		panic("ClientMock.DescribeVoiceFunc: method is nil but Client.DescribeVoice was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Examples []string
+	}{
+		Ctx:      ctx,
+		Examples: examples,
+	}
+	mock.lockDescribeVoice.Lock()
+	mock.calls.DescribeVoice = append(mock.calls.DescribeVoice, callInfo)
+	mock.lockDescribeVoice.Unlock()
+	return mock.DescribeVoiceFunc(ctx, examples)
+}
+
+// DescribeVoiceCalls gets all the calls that were made to DescribeVoice.
+// Check the length with:
+//
+//	len(mockedClient.DescribeVoiceCalls())
+func (mock *ClientMock) DescribeVoiceCalls() []struct {
+	Ctx      context.Context
+	Examples []string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Examples []string
+	}
+	mock.lockDescribeVoice.RLock()
+	calls = mock.calls.DescribeVoice
+	mock.lockDescribeVoice.RUnlock()
+	return calls
 }
 
 // Transform calls TransformFunc.
-func (mock *ClientMock) Transform(ctx context.Context, text string) (string, error) {
+func (mock *ClientMock) Transform(ctx context.Context, text string, opts Options) (string, error) {
 	if mock.TransformFunc == nil {
 		panic("ClientMock.TransformFunc: method is nil but Client.Transform was just called")
 	}
 	callInfo := struct {
 		Ctx  context.Context
 		Text string
+		Opts Options
 	}{
 		Ctx:  ctx,
 		Text: text,
+		Opts: opts,
 	}
 	mock.lockTransform.Lock()
 	mock.calls.Transform = append(mock.calls.Transform, callInfo)
 	mock.lockTransform.Unlock()
-	return mock.TransformFunc(ctx, text)
+	return mock.TransformFunc(ctx, text, opts)
 }
 
 // TransformCalls gets all the calls that were made to Transform.
@@ -69,13 +139,55 @@ func (mock *ClientMock) Transform(ctx context.Context, text string) (string, err
 func (mock *ClientMock) TransformCalls() []struct {
 	Ctx  context.Context
 	Text string
+	Opts Options
 } {
 	var calls []struct {
 		Ctx  context.Context
 		Text string
+		Opts Options
 	}
 	mock.lockTransform.RLock()
 	calls = mock.calls.Transform
 	mock.lockTransform.RUnlock()
 	return calls
 }
+
+// Translate calls TranslateFunc.
+func (mock *ClientMock) Translate(ctx context.Context, text string, language string) (string, error) {
+	if mock.TranslateFunc == nil {
+		panic("ClientMock.TranslateFunc: method is nil but Client.Translate was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Text     string
+		Language string
+	}{
+		Ctx:      ctx,
+		Text:     text,
+		Language: language,
+	}
+	mock.lockTranslate.Lock()
+	mock.calls.Translate = append(mock.calls.Translate, callInfo)
+	mock.lockTranslate.Unlock()
+	return mock.TranslateFunc(ctx, text, language)
+}
+
+// TranslateCalls gets all the calls that were made to Translate.
+// Check the length with:
+//
+//	len(mockedClient.TranslateCalls())
+func (mock *ClientMock) TranslateCalls() []struct {
+	Ctx      context.Context
+	Text     string
+	Language string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Text     string
+		Language string
+	}
+	mock.lockTranslate.RLock()
+	calls = mock.calls.Translate
+	mock.lockTranslate.RUnlock()
+	return calls
+}
@@ -0,0 +1,54 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Kinds of provider failure callers can react to. Match them with errors.Is.
+var (
+	// ErrRateLimited means the provider throttled the request.
+	ErrRateLimited = errors.New("AI provider rate limit exceeded")
+	// ErrContentFiltered means the provider refused the input or output
+	// under its content policy. Retrying will not help.
+	ErrContentFiltered = errors.New("AI provider content filter triggered")
+	// ErrUnavailable means the provider failed, timed out or could not be
+	// reached, or its circuit breaker is open.
+	ErrUnavailable = errors.New("AI provider unavailable")
+)
+
+// ProviderError is a classified provider failure. It matches its Kind with
+// errors.Is and unwraps to the underlying error.
+type ProviderError struct {
+	Kind error
+	// RetryAfter is how long the provider asked callers to wait, if it did.
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *ProviderError) Error() string {
+	if e.Err == nil {
+		return e.Kind.Error()
+	}
+	return fmt.Sprintf("%v: %v", e.Kind, e.Err)
+}
+
+func (e *ProviderError) Is(target error) bool { return target == e.Kind }
+
+func (e *ProviderError) Unwrap() error { return e.Err }
+
+// RetryAfter returns how long err asks callers to wait before retrying, or
+// zero if it does not say.
+func RetryAfter(err error) time.Duration {
+	var pe *ProviderError
+	if errors.As(err, &pe) {
+		return pe.RetryAfter
+	}
+	return 0
+}
+
+// retryable reports whether another attempt could succeed.
+func retryable(err error) bool {
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrUnavailable)
+}
@@ -0,0 +1,58 @@
+package ai
+
+import (
+	"context"
+	"time"
+)
+
+// MetricsRecorder receives measurements of AI provider calls.
+type MetricsRecorder interface {
+	ObserveAICall(provider, model, operation string, duration time.Duration, err error)
+	AddAITokens(provider, model string, promptTokens, completionTokens int)
+}
+
+// measuredClient wraps a Client and reports latency and errors of every call.
+type measuredClient struct {
+	next     Client
+	provider string
+	model    string
+	rec      MetricsRecorder
+}
+
+// WithMetrics decorates c so each call's latency and outcome are reported
+// to rec under the given provider and model.
+func WithMetrics(c Client, provider, model string, rec MetricsRecorder) Client {
+	return &measuredClient{next: c, provider: provider, model: model, rec: rec}
+}
+
+func (m *measuredClient) observe(op string, start time.Time, err error) {
+	m.rec.ObserveAICall(m.provider, m.model, op, time.Since(start), err)
+}
+
+func (m *measuredClient) Transform(ctx context.Context, text string, opts Options) (string, error) {
+	start := time.Now()
+	out, err := m.next.Transform(ctx, text, opts)
+	m.observe("transform", start, err)
+	return out, err
+}
+
+func (m *measuredClient) DescribeVoice(ctx context.Context, examples []string) (string, error) {
+	start := time.Now()
+	out, err := m.next.DescribeVoice(ctx, examples)
+	m.observe("describe_voice", start, err)
+	return out, err
+}
+
+func (m *measuredClient) Classify(ctx context.Context, text string) ([]string, error) {
+	start := time.Now()
+	flagged, err := classify(ctx, m.next, text)
+	m.observe("classify", start, err)
+	return flagged, err
+}
+
+func (m *measuredClient) Translate(ctx context.Context, text, language string) (string, error) {
+	start := time.Now()
+	out, err := m.next.Translate(ctx, text, language)
+	m.observe("translate", start, err)
+	return out, err
+}
@@ -3,37 +3,299 @@ package ai
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	openai "github.com/sashabaranov/go-openai"
 )
 
 type Client interface {
-	Transform(ctx context.Context, text string) (string, error)
+	Transform(ctx context.Context, text string, opts Options) (string, error)
+	// DescribeVoice distills sample posts into style guidance for Voice.Guidance.
+	DescribeVoice(ctx context.Context, examples []string) (string, error)
+	// Translate localizes a finished post into the BCP-47 language.
+	Translate(ctx context.Context, text, language string) (string, error)
 }
 
+// OpenAIModel is the chat model used by default for OpenAI calls.
+const OpenAIModel = "gpt-4o-mini"
+
 type openaiClient struct {
-	cl *openai.Client
+	cl      *openai.Client
+	name    string // provider name reported with token usage
+	model   string
+	tokens  MetricsRecorder // optional, see WithTokenUsage
+	baseURL string          // optional, see WithBaseURL
 }
 
-func NewOpenAI(token string) Client {
-	return &openaiClient{cl: openai.NewClient(token)}
+// OpenAIOption configures the OpenAI client.
+type OpenAIOption func(*openaiClient)
+
+// WithTokenUsage reports the token usage of every completion to rec.
+func WithTokenUsage(rec MetricsRecorder) OpenAIOption {
+	return func(c *openaiClient) { c.tokens = rec }
 }
 
-func (c *openaiClient) Transform(ctx context.Context, text string) (string, error) {
-	msg := fmt.Sprintf(`Rewrite the following statement as an over-the-top inspirational LinkedIn post with emojis, buzzwords, and hashtags. Keep it under 240 characters.
+// WithBaseURL points the client at an OpenAI-compatible API other than
+// api.openai.com.
+func WithBaseURL(url string) OpenAIOption {
+	return func(c *openaiClient) { c.baseURL = url }
+}
 
-"%s"`, text)
-	resp, err := c.cl.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
-		Model: "gpt-4o-mini",
-		Messages: []openai.ChatCompletionMessage{
-			{Role: "system", Content: "You are a viral LinkedIn influencer."},
-			{Role: "user", Content: msg},
-		},
-		MaxTokens: 120,
-	})
+// WithModel sets the chat model used for every call instead of OpenAIModel.
+func WithModel(model string) OpenAIOption {
+	return func(c *openaiClient) { c.model = model }
+}
+
+// WithProviderName sets the provider name token usage is reported under,
+// for OpenAI-compatible APIs. It defaults to "openai".
+func WithProviderName(name string) OpenAIOption {
+	return func(c *openaiClient) { c.name = name }
+}
+
+func NewOpenAI(token string, opts ...OpenAIOption) Client {
+	c := &openaiClient{name: "openai", model: OpenAIModel}
+	for _, opt := range opts {
+		opt(c)
+	}
+	cfg := openai.DefaultConfig(token)
+	if c.baseURL != "" {
+		cfg.BaseURL = c.baseURL
+	}
+	cfg.HTTPClient = &http.Client{Transport: retryAfterTransport{next: http.DefaultTransport}}
+	c.cl = openai.NewClientWithConfig(cfg)
+	return c
+}
+
+// Pinger is implemented by clients that can cheaply check that their
+// provider is reachable and the credentials are valid.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Classifier is implemented by clients whose provider can check text
+// against its content policy. The decorators in this package implement it
+// by passing the call on, so a decorated client classifies with the same
+// routing, retries, metrics and tracing as its other calls.
+type Classifier interface {
+	// Classify returns the policy categories text is flagged for.
+	Classify(ctx context.Context, text string) ([]string, error)
+}
+
+// errNoClassifier is returned by decorators of clients that cannot
+// classify text. Routing falls back past such clients.
+var errNoClassifier = errors.New("AI client cannot classify text")
+
+// classify passes a Classify call on to c.
+func classify(ctx context.Context, c Client, text string) ([]string, error) {
+	cl, ok := c.(Classifier)
+	if !ok {
+		return nil, errNoClassifier
+	}
+	return cl.Classify(ctx, text)
+}
+
+// Classify runs text through the OpenAI moderation endpoint.
+func (c *openaiClient) Classify(ctx context.Context, text string) ([]string, error) {
+	hint := &retryAfterHint{}
+	resp, err := c.cl.Moderations(context.WithValue(ctx, retryAfterKey{}, hint), openai.ModerationRequest{Input: text})
 	if err != nil {
-		return "", err
+		return nil, classifyOpenAIError(ctx, err, hint.after)
+	}
+	var flagged []string
+	for _, r := range resp.Results {
+		cats := r.Categories
+		for name, on := range map[string]bool{
+			"hate":             cats.Hate,
+			"hate/threatening": cats.HateThreatening,
+			"self-harm":        cats.SelfHarm,
+			"sexual":           cats.Sexual,
+			"sexual/minors":    cats.SexualMinors,
+			"violence":         cats.Violence,
+			"violence/graphic": cats.ViolenceGraphic,
+		} {
+			if on && !slices.Contains(flagged, name) {
+				flagged = append(flagged, name)
+			}
+		}
+	}
+	slices.Sort(flagged)
+	return flagged, nil
+}
+
+// Ping looks up the configured model, which costs no tokens.
+func (c *openaiClient) Ping(ctx context.Context) error {
+	_, err := c.cl.GetModel(ctx, c.model)
+	return err
+}
+
+// complete runs a chat completion and returns the first choice's content.
+// Failures are classified as a *ProviderError where possible.
+func (c *openaiClient) complete(ctx context.Context, req openai.ChatCompletionRequest) (string, error) {
+	hint := &retryAfterHint{}
+	resp, err := c.cl.CreateChatCompletion(context.WithValue(ctx, retryAfterKey{}, hint), req)
+	if err != nil {
+		return "", classifyOpenAIError(ctx, err, hint.after)
+	}
+	if c.tokens != nil {
+		c.tokens.AddAITokens(c.name, req.Model, resp.Usage.PromptTokens, resp.Usage.CompletionTokens)
+	}
+	if len(resp.Choices) == 0 {
+		return "", &ProviderError{Kind: ErrUnavailable, Err: errors.New("response contained no choices")}
+	}
+	if resp.Choices[0].FinishReason == openai.FinishReasonContentFilter {
+		return "", &ProviderError{Kind: ErrContentFiltered, Err: errors.New("completion stopped by content filter")}
 	}
 	return resp.Choices[0].Message.Content, nil
 }
+
+// ClassifyOpenAIError classifies err from a go-openai call made outside
+// this package, such as image generation, as a *ProviderError where
+// possible, so that WithResilience-style retries and breakers apply. The
+// provider's Retry-After is not known there.
+func ClassifyOpenAIError(ctx context.Context, err error) error {
+	return classifyOpenAIError(ctx, err, 0)
+}
+
+// classifyOpenAIError maps go-openai errors onto the provider error kinds.
+// Errors it cannot classify, such as invalid credentials, are returned as is.
+func classifyOpenAIError(ctx context.Context, err error, retryAfter time.Duration) error {
+	if ctx.Err() != nil {
+		// The caller gave up; that says nothing about the provider.
+		return err
+	}
+
+	status := 0
+	var apiErr *openai.APIError
+	var reqErr *openai.RequestError
+	switch {
+	case errors.As(err, &apiErr):
+		status = apiErr.HTTPStatusCode
+		if apiErr.Code == "content_policy_violation" || apiErr.Code == "content_filter" {
+			return &ProviderError{Kind: ErrContentFiltered, Err: err}
+		}
+		if apiErr.Code == "insufficient_quota" {
+			// Waiting will not restore a spent quota.
+			return &ProviderError{Kind: ErrUnavailable, Err: err}
+		}
+	case errors.As(err, &reqErr):
+		status = reqErr.HTTPStatusCode
+	}
+
+	var netErr net.Error
+	switch {
+	case status == http.StatusTooManyRequests:
+		return &ProviderError{Kind: ErrRateLimited, RetryAfter: retryAfter, Err: err}
+	case status >= http.StatusInternalServerError:
+		return &ProviderError{Kind: ErrUnavailable, RetryAfter: retryAfter, Err: err}
+	case status == 0 && (errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded)):
+		return &ProviderError{Kind: ErrUnavailable, Err: err}
+	default:
+		return err
+	}
+}
+
+// go-openai does not expose response headers, so the Retry-After header is
+// captured by the transport into a hint carried by the request context.
+type retryAfterKey struct{}
+
+type retryAfterHint struct{ after time.Duration }
+
+type retryAfterTransport struct{ next http.RoundTripper }
+
+func (t retryAfterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	if hint, ok := req.Context().Value(retryAfterKey{}).(*retryAfterHint); ok {
+		hint.after = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	}
+	return resp, nil
+}
+
+// parseRetryAfter reads a Retry-After value given in seconds or as an HTTP
+// date. Unparseable values yield zero.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if at, err := http.ParseTime(v); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+func (c *openaiClient) Transform(ctx context.Context, text string, opts Options) (string, error) {
+	return c.complete(ctx, openai.ChatCompletionRequest{
+		Model:     c.model,
+		Messages:  transformMessages(text, opts),
+		MaxTokens: maxTokens(opts),
+	})
+}
+
+// transformMessages sends the instructions as system messages and
+// everything the author wrote, the profile and voice included, delimited in
+// one user message.
+func transformMessages(text string, opts Options) []openai.ChatCompletionMessage {
+	messages := []openai.ChatCompletionMessage{
+		{Role: "system", Content: opts.systemPrompt()},
+	}
+	var content []string
+	if profile := buildProfile(opts); profile != "" {
+		content = append(content, delimit(profileTag, profile))
+	}
+	if !opts.Voice.empty() {
+		messages = append(messages, openai.ChatCompletionMessage{Role: "system", Content: buildVoicePrompt(opts.Voice)})
+		content = append(content, voiceContent(opts.Voice)...)
+	}
+	content = append(content, delimit(statementTag, text))
+	return append(messages,
+		openai.ChatCompletionMessage{Role: "system", Content: buildPrompt(opts)},
+		openai.ChatCompletionMessage{Role: "user", Content: strings.Join(content, "\n\n")},
+	)
+}
+
+func (c *openaiClient) DescribeVoice(ctx context.Context, examples []string) (string, error) {
+	return c.complete(ctx, openai.ChatCompletionRequest{
+		Model:     c.model,
+		Messages:  describeVoiceMessages(examples),
+		MaxTokens: 300,
+	})
+}
+
+func describeVoiceMessages(examples []string) []openai.ChatCompletionMessage {
+	posts := make([]string, len(examples))
+	for i, ex := range examples {
+		posts[i] = delimit(exampleTag, ex)
+	}
+	return []openai.ChatCompletionMessage{
+		{Role: "system", Content: "You are an editor who analyses writing style."},
+		{Role: "system", Content: buildDescribeVoicePrompt()},
+		{Role: "user", Content: strings.Join(posts, "\n\n")},
+	}
+}
+
+func (c *openaiClient) Translate(ctx context.Context, text, language string) (string, error) {
+	return c.complete(ctx, openai.ChatCompletionRequest{
+		Model:     c.model,
+		Messages:  translateMessages(text, language),
+		MaxTokens: 1200,
+	})
+}
+
+func translateMessages(text, language string) []openai.ChatCompletionMessage {
+	return []openai.ChatCompletionMessage{
+		{Role: "system", Content: "You are a professional translator who localizes LinkedIn posts."},
+		{Role: "system", Content: buildTranslatePrompt(language)},
+		{Role: "user", Content: delimit(postTag, text)},
+	}
+}
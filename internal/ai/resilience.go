@@ -0,0 +1,236 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// ResilienceConfig tunes WithResilience. Zero fields disable the matching
+// behaviour: no per-attempt timeout, no retries, no circuit breaker.
+type ResilienceConfig struct {
+	// Timeout bounds each attempt, not the call as a whole.
+	Timeout time.Duration
+	// MaxRetries is how many times a rate-limited or unavailable call is
+	// retried after the first attempt.
+	MaxRetries int
+	// BaseBackoff and MaxBackoff bound the jittered exponential backoff
+	// between attempts. A provider's Retry-After takes precedence, but a
+	// call fails at once rather than wait longer than MaxBackoff.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// BreakerThreshold consecutive unavailable failures open the breaker,
+	// which then fails calls fast for BreakerCooldown before letting a
+	// single trial call through.
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+// Resilience retries, times out and circuit-breaks calls, as configured
+// by a ResilienceConfig. WithResilience applies it to a Client; other
+// provider calls, such as image generation, can use it directly.
+type Resilience struct {
+	cfg     ResilienceConfig
+	breaker *breaker
+	// sleep waits for d or until ctx is done; replaced in tests.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewResilience creates a Resilience with its own circuit breaker.
+func NewResilience(cfg ResilienceConfig) *Resilience {
+	return &Resilience{
+		cfg:     cfg,
+		breaker: &breaker{threshold: cfg.BreakerThreshold, cooldown: cfg.BreakerCooldown, now: time.Now},
+		sleep:   sleepContext,
+	}
+}
+
+// Do runs fn until it succeeds, fails for good or runs out of retries.
+// Only errors classified as rate limits or unavailability are retried.
+func (r *Resilience) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	_, err := r.call(ctx, func(ctx context.Context) (string, error) {
+		return "", fn(ctx)
+	})
+	return err
+}
+
+// resilientClient applies a Resilience to every call to next.
+type resilientClient struct {
+	next Client
+	*Resilience
+}
+
+// WithResilience decorates c with per-attempt timeouts, retries with
+// jittered backoff and a circuit breaker, as configured by cfg.
+func WithResilience(c Client, cfg ResilienceConfig) Client {
+	return &resilientClient{next: c, Resilience: NewResilience(cfg)}
+}
+
+func (r *resilientClient) Transform(ctx context.Context, text string, opts Options) (string, error) {
+	return r.call(ctx, func(ctx context.Context) (string, error) {
+		return r.next.Transform(ctx, text, opts)
+	})
+}
+
+func (r *resilientClient) DescribeVoice(ctx context.Context, examples []string) (string, error) {
+	return r.call(ctx, func(ctx context.Context) (string, error) {
+		return r.next.DescribeVoice(ctx, examples)
+	})
+}
+
+func (r *resilientClient) Translate(ctx context.Context, text, language string) (string, error) {
+	return r.call(ctx, func(ctx context.Context) (string, error) {
+		return r.next.Translate(ctx, text, language)
+	})
+}
+
+func (r *resilientClient) Classify(ctx context.Context, text string) ([]string, error) {
+	var flagged []string
+	_, err := r.call(ctx, func(ctx context.Context) (string, error) {
+		var err error
+		flagged, err = classify(ctx, r.next, text)
+		return "", err
+	})
+	return flagged, err
+}
+
+func (r *Resilience) call(ctx context.Context, fn func(context.Context) (string, error)) (string, error) {
+	for attempt := 0; ; attempt++ {
+		wait, probe, ok := r.breaker.allow()
+		if !ok {
+			return "", &ProviderError{Kind: ErrUnavailable, RetryAfter: wait, Err: errors.New("circuit breaker open")}
+		}
+		out, err := r.attempt(ctx, fn)
+		r.breaker.record(ctx, err, probe)
+		if err == nil || !retryable(err) || attempt >= r.cfg.MaxRetries || ctx.Err() != nil {
+			return out, err
+		}
+		wait, ok = r.backoff(ctx, attempt, RetryAfter(err))
+		if !ok {
+			return out, err
+		}
+		if err := r.sleep(ctx, wait); err != nil {
+			return "", err
+		}
+	}
+}
+
+// attempt runs fn once under the per-attempt timeout. A timeout of the
+// attempt itself is reported as the provider being unavailable; the
+// caller's own cancellation is passed through unchanged.
+func (r *Resilience) attempt(ctx context.Context, fn func(context.Context) (string, error)) (string, error) {
+	if r.cfg.Timeout <= 0 {
+		return fn(ctx)
+	}
+	actx, cancel := context.WithTimeout(ctx, r.cfg.Timeout)
+	defer cancel()
+	out, err := fn(actx)
+	if err != nil && ctx.Err() == nil && actx.Err() != nil && !errors.Is(err, ErrUnavailable) {
+		err = &ProviderError{Kind: ErrUnavailable, Err: err}
+	}
+	return out, err
+}
+
+// backoff returns the wait before the next attempt: the provider's
+// Retry-After when given, otherwise full jitter over an exponentially
+// growing window capped at MaxBackoff. It reports false when the provider
+// asks for a longer wait than MaxBackoff or the wait would outlast ctx's
+// deadline; retrying is then pointless and the caller is better off with
+// the provider's error.
+func (r *Resilience) backoff(ctx context.Context, attempt int, retryAfter time.Duration) (time.Duration, bool) {
+	wait := retryAfter
+	if wait > 0 {
+		if r.cfg.MaxBackoff > 0 && wait > r.cfg.MaxBackoff {
+			return 0, false
+		}
+	} else if r.cfg.BaseBackoff > 0 {
+		window := r.cfg.BaseBackoff << attempt
+		if window <= 0 || (r.cfg.MaxBackoff > 0 && window > r.cfg.MaxBackoff) {
+			window = r.cfg.MaxBackoff
+		}
+		wait = time.Duration(rand.Int64N(int64(window) + 1))
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+		return 0, false
+	}
+	return wait, true
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// breaker is a consecutive-failure circuit breaker. Only unavailability
+// counts as failure, and only success resets the count: rate limits,
+// rejected content and the caller's cancellation say nothing about whether
+// the provider is up.
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+// allow reports whether a call may proceed, and whether it is the trial
+// call. Once the cooldown has passed a single trial call is let through;
+// while it is in flight, and while the breaker is open, allow returns how
+// long callers should wait.
+func (b *breaker) allow() (wait time.Duration, probe, ok bool) {
+	if b.threshold <= 0 {
+		return 0, false, true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < b.threshold {
+		return 0, false, true
+	}
+	if wait := b.openUntil.Sub(b.now()); wait > 0 {
+		return wait, false, false
+	}
+	if b.probing {
+		return b.cooldown, false, false
+	}
+	b.probing = true
+	return 0, true, true
+}
+
+// record counts the outcome of a call made with ctx. Only the trial call
+// ends the trial; calls that started before the breaker opened may still be
+// finishing.
+func (b *breaker) record(ctx context.Context, err error, probe bool) {
+	if b.threshold <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if probe {
+		b.probing = false
+	}
+	switch {
+	case err == nil:
+		b.failures = 0
+	case ctx.Err() != nil || !errors.Is(err, ErrUnavailable):
+		// Inconclusive: a failed trial only lets the next call try again.
+	default:
+		b.failures++
+		if b.failures >= b.threshold {
+			b.openUntil = b.now().Add(b.cooldown)
+		}
+	}
+}
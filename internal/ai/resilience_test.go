@@ -0,0 +1,316 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestResilient(next Client, cfg ResilienceConfig) (*resilientClient, *[]time.Duration) {
+	r := WithResilience(next, cfg).(*resilientClient)
+	var waits []time.Duration
+	r.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return ctx.Err()
+	}
+	return r, &waits
+}
+
+func TestResilience_RetriesUntilSuccess(t *testing.T) {
+	calls := 0
+	next := &ClientMock{TransformFunc: func(ctx context.Context, text string, opts Options) (string, error) {
+		calls++
+		if calls < 3 {
+			return "", &ProviderError{Kind: ErrUnavailable, Err: errors.New("502")}
+		}
+		return "post", nil
+	}}
+	r, waits := newTestResilient(next, ResilienceConfig{MaxRetries: 2, BaseBackoff: time.Second, MaxBackoff: 4 * time.Second})
+
+	out, err := r.Transform(context.Background(), "text", Options{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "post", out)
+	assert.Equal(t, 3, calls)
+	require.Len(t, *waits, 2)
+	assert.LessOrEqual(t, (*waits)[0], time.Second)
+	assert.LessOrEqual(t, (*waits)[1], 2*time.Second)
+}
+
+func TestResilience_HonoursRetryAfterAndGivesUp(t *testing.T) {
+	calls := 0
+	next := &ClientMock{TransformFunc: func(ctx context.Context, text string, opts Options) (string, error) {
+		calls++
+		return "", &ProviderError{Kind: ErrRateLimited, RetryAfter: 7 * time.Second}
+	}}
+	r, waits := newTestResilient(next, ResilienceConfig{MaxRetries: 1, BaseBackoff: time.Second})
+
+	_, err := r.Transform(context.Background(), "text", Options{})
+
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.Equal(t, 7*time.Second, RetryAfter(err))
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, []time.Duration{7 * time.Second}, *waits)
+}
+
+func TestResilience_DoesNotWaitPastMaxBackoffOrDeadline(t *testing.T) {
+	calls := 0
+	next := &ClientMock{TransformFunc: func(ctx context.Context, text string, opts Options) (string, error) {
+		calls++
+		return "", &ProviderError{Kind: ErrRateLimited, RetryAfter: time.Hour}
+	}}
+	r, waits := newTestResilient(next, ResilienceConfig{MaxRetries: 3, BaseBackoff: time.Second, MaxBackoff: 10 * time.Second})
+
+	_, err := r.Transform(context.Background(), "text", Options{})
+
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.Equal(t, time.Hour, RetryAfter(err), "the caller still learns when to come back")
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, *waits)
+
+	calls = 0
+	next.TransformFunc = func(ctx context.Context, text string, opts Options) (string, error) {
+		calls++
+		return "", &ProviderError{Kind: ErrRateLimited, RetryAfter: 5 * time.Second}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err = r.Transform(ctx, "text", Options{})
+
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, *waits)
+}
+
+func TestResilience_DoesNotRetryPermanentErrors(t *testing.T) {
+	for _, fail := range []error{
+		&ProviderError{Kind: ErrContentFiltered},
+		errors.New("invalid api key"),
+	} {
+		calls := 0
+		next := &ClientMock{TranslateFunc: func(ctx context.Context, text, language string) (string, error) {
+			calls++
+			return "", fail
+		}}
+		r, _ := newTestResilient(next, ResilienceConfig{MaxRetries: 3})
+
+		_, err := r.Translate(context.Background(), "text", "de")
+
+		assert.ErrorIs(t, err, fail)
+		assert.Equal(t, 1, calls, "%v", fail)
+	}
+}
+
+func TestResilience_AttemptTimeout(t *testing.T) {
+	next := &ClientMock{DescribeVoiceFunc: func(ctx context.Context, examples []string) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	}}
+	r, _ := newTestResilient(next, ResilienceConfig{Timeout: 10 * time.Millisecond})
+
+	_, err := r.DescribeVoice(context.Background(), []string{"a"})
+
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestResilience_CallerCancellationStopsRetries(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	next := &ClientMock{TransformFunc: func(ctx context.Context, text string, opts Options) (string, error) {
+		calls++
+		cancel()
+		return "", &ProviderError{Kind: ErrUnavailable}
+	}}
+	r, _ := newTestResilient(next, ResilienceConfig{MaxRetries: 5})
+
+	_, err := r.Transform(ctx, "text", Options{})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestResilience_CircuitBreaker(t *testing.T) {
+	now := time.Unix(0, 0)
+	up := false
+	calls := 0
+	next := &ClientMock{TransformFunc: func(ctx context.Context, text string, opts Options) (string, error) {
+		calls++
+		if up {
+			return "post", nil
+		}
+		return "", &ProviderError{Kind: ErrUnavailable}
+	}}
+	r, _ := newTestResilient(next, ResilienceConfig{BreakerThreshold: 2, BreakerCooldown: 30 * time.Second})
+	r.breaker.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for range 2 {
+		_, err := r.Transform(ctx, "text", Options{})
+		assert.ErrorIs(t, err, ErrUnavailable)
+	}
+	require.Equal(t, 2, calls)
+
+	// Open: fails fast without calling the provider.
+	now = now.Add(10 * time.Second)
+	_, err := r.Transform(ctx, "text", Options{})
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.Equal(t, 20*time.Second, RetryAfter(err))
+	assert.Equal(t, 2, calls)
+
+	// Half-open: a failed trial reopens the breaker.
+	now = now.Add(20 * time.Second)
+	_, _ = r.Transform(ctx, "text", Options{})
+	assert.Equal(t, 3, calls)
+	_, _ = r.Transform(ctx, "text", Options{})
+	assert.Equal(t, 3, calls)
+
+	// A successful trial closes it.
+	now = now.Add(30 * time.Second)
+	up = true
+	out, err := r.Transform(ctx, "text", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "post", out)
+	_, err = r.Transform(ctx, "text", Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, calls)
+}
+
+func TestBreaker_RateLimitsDoNotTrip(t *testing.T) {
+	b := &breaker{threshold: 1, cooldown: time.Minute, now: time.Now}
+	b.record(context.Background(), &ProviderError{Kind: ErrRateLimited}, false)
+	_, _, ok := b.allow()
+	assert.True(t, ok)
+}
+
+func TestBreaker_OnlySuccessResetsFailures(t *testing.T) {
+	b := &breaker{threshold: 2, cooldown: time.Minute, now: time.Now}
+	b.record(context.Background(), &ProviderError{Kind: ErrUnavailable}, false)
+	b.record(context.Background(), &ProviderError{Kind: ErrRateLimited}, false)
+	b.record(context.Background(), ErrContentFiltered, false)
+	b.record(context.Background(), &ProviderError{Kind: ErrUnavailable}, false)
+	_, _, ok := b.allow()
+	assert.False(t, ok, "failures in between inconclusive outcomes are still consecutive")
+}
+
+func TestResilience_CancelledTrialKeepsBreakerOpen(t *testing.T) {
+	now := time.Unix(0, 0)
+	calls := 0
+	ctx, cancel := context.WithCancel(context.Background())
+	next := &ClientMock{TransformFunc: func(ctx context.Context, text string, opts Options) (string, error) {
+		calls++
+		if calls == 2 {
+			// The caller gives up during the trial call.
+			cancel()
+			return "", ctx.Err()
+		}
+		return "", &ProviderError{Kind: ErrUnavailable}
+	}}
+	r, _ := newTestResilient(next, ResilienceConfig{BreakerThreshold: 1, BreakerCooldown: 30 * time.Second})
+	r.breaker.now = func() time.Time { return now }
+
+	_, err := r.Transform(context.Background(), "text", Options{})
+	require.ErrorIs(t, err, ErrUnavailable)
+
+	now = now.Add(30 * time.Second)
+	_, err = r.Transform(ctx, "text", Options{})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 2, calls)
+
+	// The breaker is still half-open: the next call is a fresh trial, and
+	// its failure reopens the breaker.
+	_, probe, ok := r.breaker.allow()
+	require.True(t, ok)
+	assert.True(t, probe)
+	r.breaker.record(context.Background(), &ProviderError{Kind: ErrUnavailable}, probe)
+	_, err = r.Transform(context.Background(), "text", Options{})
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.Equal(t, 2, calls, "the reopened breaker fails fast")
+}
+
+func TestBreaker_OnlyTheTrialEndsTheTrial(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := &breaker{threshold: 1, cooldown: time.Minute, now: func() time.Time { return now }}
+	b.record(context.Background(), &ProviderError{Kind: ErrUnavailable}, false)
+	now = now.Add(time.Minute)
+
+	_, probe, ok := b.allow()
+	require.True(t, ok)
+	require.True(t, probe)
+	// A call from before the breaker opened fails while the trial runs.
+	b.record(context.Background(), &ProviderError{Kind: ErrUnavailable}, false)
+	now = now.Add(time.Minute)
+	_, _, ok = b.allow()
+	assert.False(t, ok, "the trial is still in flight")
+
+	b.record(context.Background(), nil, true)
+	_, probe, ok = b.allow()
+	assert.True(t, ok)
+	assert.False(t, probe)
+}
+
+func TestOpenAI_ClassifiesErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		retryAfter string
+		want       error
+		wantWait   time.Duration
+	}{
+		{"rate limited", 429, `{"error":{"message":"slow down","type":"requests","code":"rate_limit_exceeded"}}`, "12", ErrRateLimited, 12 * time.Second},
+		{"quota", 429, `{"error":{"message":"quota","type":"insufficient_quota","code":"insufficient_quota"}}`, "", ErrUnavailable, 0},
+		{"server error", 503, `{"error":{"message":"overloaded","type":"server_error"}}`, "", ErrUnavailable, 0},
+		{"content policy", 400, `{"error":{"message":"no","type":"invalid_request_error","code":"content_policy_violation"}}`, "", ErrContentFiltered, 0},
+		{"filtered completion", 200, `{"choices":[{"message":{"role":"assistant","content":""},"finish_reason":"content_filter"}]}`, "", ErrContentFiltered, 0},
+		{"no choices", 200, `{"choices":[]}`, "", ErrUnavailable, 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if tc.retryAfter != "" {
+					w.Header().Set("Retry-After", tc.retryAfter)
+				}
+				w.WriteHeader(tc.status)
+				fmt.Fprint(w, tc.body)
+			}))
+			defer srv.Close()
+
+			c := NewOpenAI("token", WithBaseURL(srv.URL))
+			_, err := c.Translate(context.Background(), "text", "de")
+
+			assert.ErrorIs(t, err, tc.want)
+			assert.Equal(t, tc.wantWait, RetryAfter(err))
+		})
+	}
+}
+
+func TestOpenAI_UnauthorizedIsNotClassified(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error":{"message":"bad key","type":"invalid_request_error","code":"invalid_api_key"}}`)
+	}))
+	defer srv.Close()
+
+	_, err := NewOpenAI("token", WithBaseURL(srv.URL)).Translate(context.Background(), "text", "de")
+
+	require.Error(t, err)
+	assert.False(t, retryable(err))
+	assert.NotErrorIs(t, err, ErrContentFiltered)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, 3*time.Second, parseRetryAfter("3", now))
+	assert.Equal(t, time.Minute, parseRetryAfter(now.Add(time.Minute).Format(http.TimeFormat), now))
+	assert.Zero(t, parseRetryAfter("soon", now))
+	assert.Zero(t, parseRetryAfter("", now))
+}
@@ -0,0 +1,147 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/you/linkedinify/internal/logging"
+)
+
+// Backend is a provider and model that calls can be routed to.
+type Backend struct {
+	Provider string
+	Model    string
+	Client   Client
+	// Weight is the backend's share of first attempts among the weighted
+	// backends of its route. Unweighted backends only serve as fallbacks.
+	Weight int
+}
+
+// Route sends calls matching all of its non-empty conditions to Backends.
+type Route struct {
+	Styles   []string
+	Tiers    []string
+	Backends []Backend
+}
+
+func (r *Route) matches(style, tier string) bool {
+	if len(r.Styles) > 0 && !slices.Contains(r.Styles, style) {
+		return false
+	}
+	return len(r.Tiers) == 0 || slices.Contains(r.Tiers, tier)
+}
+
+// Served identifies the backend that produced a result.
+type Served struct {
+	Provider string
+	Model    string
+}
+
+type servedKey struct{}
+
+// TrackServed returns a context under which routed calls record the
+// backend that served them in the returned Served.
+func TrackServed(ctx context.Context) (context.Context, *Served) {
+	s := &Served{}
+	return context.WithValue(ctx, servedKey{}, s), s
+}
+
+// routingClient sends each call to the first matching route and falls back
+// through the route's backends until one succeeds.
+type routingClient struct {
+	routes []Route
+	// intn returns a number in [0, n); replaced in tests.
+	intn func(n int) int
+}
+
+// NewRouting creates a Client routing calls by style and tier. Transform
+// calls match routes on Options.Style and Options.Tier; the other calls
+// only match routes without conditions. A call matching no route fails
+// with ErrUnavailable.
+func NewRouting(routes []Route) Client {
+	return &routingClient{routes: routes, intn: rand.IntN}
+}
+
+func (rc *routingClient) Transform(ctx context.Context, text string, opts Options) (string, error) {
+	return rc.call(ctx, opts.Style, opts.Tier, func(c Client) (string, error) {
+		return c.Transform(ctx, text, opts)
+	})
+}
+
+func (rc *routingClient) DescribeVoice(ctx context.Context, examples []string) (string, error) {
+	return rc.call(ctx, "", "", func(c Client) (string, error) {
+		return c.DescribeVoice(ctx, examples)
+	})
+}
+
+func (rc *routingClient) Translate(ctx context.Context, text, language string) (string, error) {
+	return rc.call(ctx, "", "", func(c Client) (string, error) {
+		return c.Translate(ctx, text, language)
+	})
+}
+
+// Classify matches only routes without conditions, like DescribeVoice.
+func (rc *routingClient) Classify(ctx context.Context, text string) ([]string, error) {
+	var flagged []string
+	_, err := rc.call(ctx, "", "", func(c Client) (string, error) {
+		var err error
+		flagged, err = classify(ctx, c, text)
+		return "", err
+	})
+	return flagged, err
+}
+
+func (rc *routingClient) call(ctx context.Context, style, tier string, fn func(Client) (string, error)) (string, error) {
+	i := slices.IndexFunc(rc.routes, func(r Route) bool { return r.matches(style, tier) })
+	if i < 0 {
+		return "", &ProviderError{Kind: ErrUnavailable, Err: errors.New("no route matches the call")}
+	}
+	var err error
+	for n, b := range rc.order(rc.routes[i].Backends) {
+		if n > 0 {
+			logging.FromContext(ctx).Warn("AI provider failed, falling back",
+				"provider", b.Provider, "model", b.Model, "err", err)
+		}
+		var out string
+		out, err = fn(b.Client)
+		if err == nil {
+			if s, ok := ctx.Value(servedKey{}).(*Served); ok {
+				s.Provider, s.Model = b.Provider, b.Model
+			}
+			return out, nil
+		}
+		// Another provider would refuse the same content, and a caller
+		// that gave up does not want an answer from anyone.
+		if errors.Is(err, ErrContentFiltered) || ctx.Err() != nil {
+			return "", err
+		}
+	}
+	return "", err
+}
+
+// order returns backends in the order they are tried: one weighted backend
+// picked at random by weight, then the remaining backends as listed.
+func (rc *routingClient) order(backends []Backend) []Backend {
+	total := 0
+	for _, b := range backends {
+		total += b.Weight
+	}
+	if total == 0 {
+		return backends
+	}
+	pick := rc.intn(total)
+	first := 0
+	for i, b := range backends {
+		if pick < b.Weight {
+			first = i
+			break
+		}
+		pick -= b.Weight
+	}
+	ordered := make([]Backend, 0, len(backends))
+	ordered = append(ordered, backends[first])
+	ordered = append(ordered, backends[:first]...)
+	return append(ordered, backends[first+1:]...)
+}
@@ -0,0 +1,156 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func answering(out string, err error) *ClientMock {
+	return &ClientMock{
+		TransformFunc: func(ctx context.Context, text string, opts Options) (string, error) { return out, err },
+		TranslateFunc: func(ctx context.Context, text, language string) (string, error) { return out, err },
+	}
+}
+
+func TestRouting_FallsBackInOrder(t *testing.T) {
+	down := answering("", &ProviderError{Kind: ErrUnavailable})
+	misconfigured := answering("", errors.New("invalid api key"))
+	up := answering("post", nil)
+	rc := NewRouting([]Route{{Backends: []Backend{
+		{Provider: "a", Model: "m1", Client: down},
+		{Provider: "b", Model: "m2", Client: misconfigured},
+		{Provider: "c", Model: "m3", Client: up},
+	}}})
+
+	ctx, served := TrackServed(context.Background())
+	out, err := rc.Transform(ctx, "text", Options{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "post", out)
+	assert.Equal(t, Served{Provider: "c", Model: "m3"}, *served)
+	assert.Len(t, down.TransformCalls(), 1)
+	assert.Len(t, misconfigured.TransformCalls(), 1)
+}
+
+func TestRouting_StopsOnContentFilter(t *testing.T) {
+	filtered := answering("", &ProviderError{Kind: ErrContentFiltered})
+	other := answering("post", nil)
+	rc := NewRouting([]Route{{Backends: []Backend{
+		{Provider: "a", Client: filtered},
+		{Provider: "b", Client: other},
+	}}})
+
+	_, err := rc.Transform(context.Background(), "text", Options{})
+
+	assert.ErrorIs(t, err, ErrContentFiltered)
+	assert.Empty(t, other.TransformCalls())
+}
+
+func TestRouting_ReturnsLastErrorWhenAllFail(t *testing.T) {
+	rc := NewRouting([]Route{{Backends: []Backend{
+		{Provider: "a", Client: answering("", &ProviderError{Kind: ErrUnavailable})},
+		{Provider: "b", Client: answering("", &ProviderError{Kind: ErrRateLimited})},
+	}}})
+
+	_, err := rc.Translate(context.Background(), "text", "de")
+
+	assert.ErrorIs(t, err, ErrRateLimited)
+}
+
+func TestRouting_MatchesStyleAndTier(t *testing.T) {
+	formal := answering("formal", nil)
+	pro := answering("pro", nil)
+	fallback := answering("default", nil)
+	rc := NewRouting([]Route{
+		{Styles: []string{"formal"}, Backends: []Backend{{Provider: "formal", Client: formal}}},
+		{Tiers: []string{"pro", "enterprise"}, Backends: []Backend{{Provider: "pro", Client: pro}}},
+		{Backends: []Backend{{Provider: "default", Client: fallback}}},
+	})
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		opts Options
+		want string
+	}{
+		{Options{Style: "formal", Tier: "pro"}, "formal"},
+		{Options{Style: "witty", Tier: "enterprise"}, "pro"},
+		{Options{Style: "witty"}, "default"},
+	} {
+		out, err := rc.Transform(ctx, "text", tc.opts)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, out, "%+v", tc.opts)
+	}
+
+	out, err := rc.Translate(ctx, "text", "de")
+	require.NoError(t, err)
+	assert.Equal(t, "default", out, "calls without options only match unconditional routes")
+}
+
+func TestRouting_NoMatchingRoute(t *testing.T) {
+	rc := NewRouting([]Route{{Tiers: []string{"pro"}, Backends: []Backend{{Provider: "a", Client: answering("post", nil)}}}})
+
+	_, err := rc.Transform(context.Background(), "text", Options{})
+
+	assert.ErrorIs(t, err, ErrUnavailable)
+}
+
+func TestRouting_WeightedSplit(t *testing.T) {
+	backends := []Backend{
+		{Provider: "fallback"},
+		{Provider: "a", Weight: 90},
+		{Provider: "b", Weight: 10},
+	}
+	rc := NewRouting(nil).(*routingClient)
+
+	providers := func(bs []Backend) []string {
+		var names []string
+		for _, b := range bs {
+			names = append(names, b.Provider)
+		}
+		return names
+	}
+	rc.intn = func(n int) int { assert.Equal(t, 100, n); return 89 }
+	assert.Equal(t, []string{"a", "fallback", "b"}, providers(rc.order(backends)))
+	rc.intn = func(int) int { return 90 }
+	assert.Equal(t, []string{"b", "fallback", "a"}, providers(rc.order(backends)))
+
+	rc.intn = func(int) int { t.Fatal("unweighted routes are not randomized"); return 0 }
+	assert.Equal(t, []string{"fallback"}, providers(rc.order(backends[:1])))
+}
+
+// classifyingClient is a Client whose provider can also classify text.
+type classifyingClient struct {
+	*ClientMock
+	classify func(ctx context.Context, text string) ([]string, error)
+}
+
+func (c classifyingClient) Classify(ctx context.Context, text string) ([]string, error) {
+	return c.classify(ctx, text)
+}
+
+func TestRouting_ClassifiesThroughDecorators(t *testing.T) {
+	calls := 0
+	flaky := classifyingClient{ClientMock: &ClientMock{}, classify: func(ctx context.Context, text string) ([]string, error) {
+		calls++
+		if calls < 2 {
+			return nil, &ProviderError{Kind: ErrUnavailable, Err: errors.New("502")}
+		}
+		return []string{"harassment"}, nil
+	}}
+	resilient, waits := newTestResilient(flaky, ResilienceConfig{MaxRetries: 1})
+	rc := NewRouting([]Route{{Backends: []Backend{
+		{Provider: "a", Client: WithTracing(answering("", nil), "a", "m1")},
+		{Provider: "b", Client: WithTracing(resilient, "b", "m2")},
+	}}})
+
+	flagged, err := rc.(Classifier).Classify(context.Background(), "text")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"harassment"}, flagged)
+	assert.Equal(t, 2, calls)
+	assert.Len(t, *waits, 1)
+}
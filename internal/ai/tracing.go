@@ -0,0 +1,78 @@
+package ai
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracedClient wraps a Client and records a span around every call.
+type tracedClient struct {
+	next   Client
+	tracer trace.Tracer
+	attrs  []attribute.KeyValue
+}
+
+// WithTracing decorates c so each call is recorded as a client span tagged
+// with the provider and model that served it.
+func WithTracing(c Client, provider, model string) Client {
+	return &tracedClient{
+		next:   c,
+		tracer: otel.Tracer("github.com/you/linkedinify/internal/ai"),
+		attrs: []attribute.KeyValue{
+			attribute.String("gen_ai.system", provider),
+			attribute.String("gen_ai.request.model", model),
+		},
+	}
+}
+
+func (t *tracedClient) start(ctx context.Context, op string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return t.tracer.Start(ctx, "ai."+op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(t.attrs...),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+func end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (t *tracedClient) Transform(ctx context.Context, text string, opts Options) (string, error) {
+	ctx, span := t.start(ctx, "Transform",
+		attribute.Int("ai.input_chars", len(text)),
+		attribute.String("ai.language", opts.Language),
+		attribute.Bool("ai.voice", !opts.Voice.empty()),
+	)
+	out, err := t.next.Transform(ctx, text, opts)
+	end(span, err)
+	return out, err
+}
+
+func (t *tracedClient) DescribeVoice(ctx context.Context, examples []string) (string, error) {
+	ctx, span := t.start(ctx, "DescribeVoice", attribute.Int("ai.examples", len(examples)))
+	out, err := t.next.DescribeVoice(ctx, examples)
+	end(span, err)
+	return out, err
+}
+
+func (t *tracedClient) Classify(ctx context.Context, text string) ([]string, error) {
+	ctx, span := t.start(ctx, "Classify", attribute.Int("ai.input_chars", len(text)))
+	flagged, err := classify(ctx, t.next, text)
+	end(span, err)
+	return flagged, err
+}
+
+func (t *tracedClient) Translate(ctx context.Context, text, language string) (string, error) {
+	ctx, span := t.start(ctx, "Translate", attribute.String("ai.language", language))
+	out, err := t.next.Translate(ctx, text, language)
+	end(span, err)
+	return out, err
+}
@@ -0,0 +1,146 @@
+// Package carousel turns a post into a LinkedIn carousel: a PDF document
+// with one square slide per idea, which LinkedIn shows as swipeable
+// pages.
+package carousel
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/you/linkedinify/internal/postformat"
+)
+
+// Slide limits. A slide holds about this much text at the normal type
+// size; more is set smaller.
+const (
+	MaxSlides     = 20
+	wordsPerSlide = 60
+	itemsPerSlide = 5
+)
+
+// Slide is one page of a carousel.
+type Slide struct {
+	// Heading is set on the cover, which shows nothing else.
+	Heading string
+	// Body holds paragraphs and list items, one per entry. List items
+	// start with "- " or their number.
+	Body []string
+	// Footer is set on the last slide to the post's hashtags.
+	Footer string
+}
+
+var (
+	listItem    = regexp.MustCompile(`^(?:- |\d+\. )`)
+	hashtagLine = regexp.MustCompile(`^(?:#[\p{L}\p{N}_]+\s*)+$`)
+	sentenceEnd = regexp.MustCompile(`[.!?]["')\]]?\s+`)
+)
+
+// Slides splits a post into a cover with its first line, then a slide per
+// paragraph, with long paragraphs split between sentences and lists
+// spread over slides of five items. Lines of only hashtags become the
+// last slide's footer. There are at most MaxSlides slides.
+func Slides(post string) []Slide {
+	text, err := postformat.Render(post, postformat.Plain)
+	if err != nil {
+		text = post
+	}
+
+	var blocks [][]string
+	var hashtags []string
+	var block []string
+	flush := func() {
+		if len(block) > 0 {
+			blocks = append(blocks, block)
+			block = nil
+		}
+	}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			flush()
+		case hashtagLine.MatchString(line):
+			hashtags = append(hashtags, strings.Fields(line)...)
+		default:
+			block = append(block, line)
+		}
+	}
+	flush()
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	slides := []Slide{{Heading: blocks[0][0]}}
+	if rest := blocks[0][1:]; len(rest) > 0 {
+		blocks[0] = rest
+	} else {
+		blocks = blocks[1:]
+	}
+	for _, b := range blocks {
+		slides = append(slides, split(b)...)
+	}
+	if len(slides) > MaxSlides {
+		slides = slides[:MaxSlides]
+	}
+	if len(hashtags) > 0 {
+		slides[len(slides)-1].Footer = strings.Join(hashtags, " ")
+	}
+	return slides
+}
+
+// split lays out one block of lines: list items in slides of
+// itemsPerSlide, prose in slides of about wordsPerSlide words. Each line
+// stays a paragraph of its own unless it is split between slides.
+func split(lines []string) []Slide {
+	var slides []Slide
+	var cur Slide
+	words, items := 0, 0
+	next := func() {
+		if len(cur.Body) > 0 {
+			slides = append(slides, cur)
+		}
+		cur, words, items = Slide{}, 0, 0
+	}
+	for _, line := range lines {
+		if listItem.MatchString(line) {
+			if items == itemsPerSlide {
+				next()
+			}
+			cur.Body = append(cur.Body, line)
+			words += len(strings.Fields(line))
+			items++
+			continue
+		}
+		para := -1 // index of this line's paragraph on the current slide
+		for _, chunk := range sentences(line) {
+			n := len(strings.Fields(chunk))
+			if words > 0 && words+n > wordsPerSlide {
+				next()
+				para = -1
+			}
+			if para < 0 {
+				cur.Body = append(cur.Body, chunk)
+				para = len(cur.Body) - 1
+			} else {
+				cur.Body[para] += " " + chunk
+			}
+			words += n
+		}
+	}
+	next()
+	return slides
+}
+
+// sentences splits a paragraph after each sentence.
+func sentences(text string) []string {
+	var out []string
+	last := 0
+	for _, loc := range sentenceEnd.FindAllStringIndex(text, -1) {
+		out = append(out, strings.TrimSpace(text[last:loc[1]]))
+		last = loc[1]
+	}
+	if rest := strings.TrimSpace(text[last:]); rest != "" {
+		out = append(out, rest)
+	}
+	return out
+}
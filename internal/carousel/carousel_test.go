@@ -0,0 +1,104 @@
+package carousel_test
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/carousel"
+)
+
+const post = "**3 lessons** from migrating to Kubernetes\n\n" +
+	"We moved 40 services in 6 weeks. Nobody got paged.\n\n" +
+	"- Start with the boring services\n- Automate the rollback first\n- Measure before and after\n\n" +
+	"What would you add?\n\n" +
+	"#Kubernetes #DevOps"
+
+func TestSlides(t *testing.T) {
+	slides := carousel.Slides(post)
+
+	require.Len(t, slides, 4)
+	assert.Equal(t, "3 lessons from migrating to Kubernetes", slides[0].Heading)
+	assert.Equal(t, []string{"We moved 40 services in 6 weeks. Nobody got paged."}, slides[1].Body)
+	assert.Equal(t, []string{"- Start with the boring services", "- Automate the rollback first", "- Measure before and after"}, slides[2].Body)
+	assert.Equal(t, []string{"What would you add?"}, slides[3].Body)
+	assert.Equal(t, "#Kubernetes #DevOps", slides[3].Footer)
+	for _, s := range slides[:3] {
+		assert.Empty(t, s.Footer)
+	}
+}
+
+func TestSlides_SplitsLongParagraphsAndLists(t *testing.T) {
+	sentence := "This sentence has exactly eight words in it. "
+	var items []string
+	for i := 1; i <= 7; i++ {
+		items = append(items, fmt.Sprintf("%d. item", i))
+	}
+	slides := carousel.Slides("Hook\n\n" + strings.Repeat(sentence, 10) + "\n\n" + strings.Join(items, "\n"))
+
+	require.Len(t, slides, 5)
+	assert.Len(t, strings.Fields(slides[1].Body[0]), 56, "split between sentences")
+	assert.Len(t, strings.Fields(slides[2].Body[0]), 24)
+	assert.Len(t, slides[3].Body, 5)
+	assert.Equal(t, []string{"6. item", "7. item"}, slides[4].Body)
+}
+
+func TestSlides_Empty(t *testing.T) {
+	assert.Empty(t, carousel.Slides(""))
+	assert.Empty(t, carousel.Slides("#OnlyHashtags"))
+}
+
+var objectAt = regexp.MustCompile(`^(\d+) 0 obj\n`)
+
+func TestPDF_IsWellFormed(t *testing.T) {
+	slides := carousel.Slides(post + "\n\nEmoji \U0001F680 and (parentheses) \\ stay safe")
+	doc := carousel.PDF(slides, carousel.Options{Title: "Lessons"})
+
+	require.True(t, bytes.HasPrefix(doc, []byte("%PDF-1.4\n")))
+	require.True(t, bytes.HasSuffix(doc, []byte("%%EOF\n")))
+	assert.Equal(t, len(slides), bytes.Count(doc, []byte("/Type /Page ")))
+	assert.Contains(t, string(doc), "/Count 5")
+	assert.Contains(t, string(doc), `(Emoji  and \(parentheses\) \\ stay safe)`)
+	assert.Contains(t, string(doc), "(\x95 Start with the boring services)", "bullets in WinAnsiEncoding")
+
+	// Every cross-reference entry points at its object.
+	start := bytes.LastIndex(doc, []byte("startxref\n"))
+	xref, err := strconv.Atoi(strings.Fields(string(doc[start+len("startxref\n"):]))[0])
+	require.NoError(t, err)
+	entries := strings.Split(string(doc[xref:]), "\n")
+	require.Equal(t, "xref", entries[0])
+	count, err := strconv.Atoi(strings.Fields(entries[1])[1])
+	require.NoError(t, err)
+	for n := 1; n < count; n++ {
+		off, err := strconv.Atoi(entries[2+n][:10])
+		require.NoError(t, err)
+		m := objectAt.FindSubmatch(doc[off:])
+		require.NotNil(t, m, "object %d", n)
+		assert.Equal(t, strconv.Itoa(n), string(m[1]))
+	}
+}
+
+func TestUnsupported(t *testing.T) {
+	assert.Empty(t, carousel.Unsupported(carousel.Slides("Café – naïve 𝐁𝐨𝐥𝐝 \U0001F680\n\nÜber → weiter")))
+
+	slides := carousel.Slides("Привет, мир!\n\nΓεια σου 2 ½ and 東京\n\n#日本")
+	assert.Equal(t, []rune("ПриветмΓειασου東京日本"), carousel.Unsupported(slides))
+}
+
+var streamPattern = regexp.MustCompile(`(?s)<< /Length (\d+) >>\nstream\n(.*?)\nendstream`)
+
+func TestPDF_StreamLengths(t *testing.T) {
+	doc := carousel.PDF(carousel.Slides(post), carousel.Options{})
+
+	streams := streamPattern.FindAllSubmatch(doc, -1)
+	require.Len(t, streams, 4)
+	for _, s := range streams {
+		assert.Equal(t, string(s[1]), strconv.Itoa(len(s[2])))
+	}
+}
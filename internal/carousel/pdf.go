@@ -0,0 +1,278 @@
+package carousel
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Page layout in points. LinkedIn shows square documents best.
+const (
+	pageSize   = 1080
+	margin     = 96
+	bodySize   = 44
+	minSize    = 26
+	coverSize  = 72
+	footerSize = 28
+	leading    = 1.3
+)
+
+// Colours as PDF "r g b" operands.
+const (
+	coverBackground = "0.039 0.400 0.761" // LinkedIn blue
+	white           = "1 1 1"
+	ink             = "0.122 0.161 0.216"
+	muted           = "0.420 0.447 0.502"
+	accent          = "0.039 0.400 0.761"
+)
+
+// Options describe the document as a whole.
+type Options struct {
+	Title string
+}
+
+// PDF renders slides as a PDF document with one page per slide, set in
+// the standard Helvetica fonts so that nothing needs to be embedded.
+// Characters those fonts lack, such as emoji, are left out; see
+// Unsupported.
+func PDF(slides []Slide, opts Options) []byte {
+	w := &pdfWriter{}
+	w.buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-5 are fixed; page i is object 6+2i and its content 7+2i.
+	kids := make([]string, len(slides))
+	for i := range slides {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+2*i)
+	}
+	w.object("<< /Type /Catalog /Pages 2 0 R >>")
+	w.object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(slides)))
+	w.object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	w.object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	w.object(fmt.Sprintf("<< /Title %s /Producer (linkedinify) >>", pdfString(opts.Title)))
+	for i, s := range slides {
+		content := renderSlide(s, i+1, len(slides))
+		w.object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>", pageSize, pageSize, 7+2*i))
+		w.object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	}
+
+	xref := w.buf.Len()
+	fmt.Fprintf(&w.buf, "xref\n0 %d\n0000000000 65535 f \n", len(w.offsets)+1)
+	for _, off := range w.offsets {
+		fmt.Fprintf(&w.buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&w.buf, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(w.offsets)+1, xref)
+	return w.buf.Bytes()
+}
+
+type pdfWriter struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+// object writes the next numbered object.
+func (w *pdfWriter) object(body string) {
+	w.offsets = append(w.offsets, w.buf.Len())
+	fmt.Fprintf(&w.buf, "%d 0 obj\n%s\nendobj\n", len(w.offsets), body)
+}
+
+// renderSlide returns the content stream of one page.
+func renderSlide(s Slide, n, total int) string {
+	var c strings.Builder
+	text := func(font string, size int, colour string, x, y int, s string) {
+		fmt.Fprintf(&c, "BT /%s %d Tf %s rg %d %d Td %s Tj ET\n", font, size, colour, x, y, pdfString(s))
+	}
+	width := pageSize - 2*margin
+
+	if s.Heading != "" {
+		fmt.Fprintf(&c, "%s rg 0 0 %d %d re f\n", coverBackground, pageSize, pageSize)
+		size, lines := fit([]string{s.Heading}, width, pageSize-3*margin, coverSize, true)
+		step := int(float64(size) * leading)
+		y := (pageSize+step*len(lines))/2 - size
+		for _, l := range lines {
+			text("F2", size, white, margin, y, l.text)
+			y -= step
+		}
+	} else {
+		fmt.Fprintf(&c, "%s rg 0 0 %d %d re f\n", white, pageSize, pageSize)
+		size, lines := fit(s.Body, width, pageSize-3*margin, bodySize, false)
+		step := int(float64(size) * leading)
+		y := pageSize - margin - size
+		for _, l := range lines {
+			if l.gap {
+				y -= step / 2
+				continue
+			}
+			text("F1", size, ink, margin+l.indent*size/100, y, l.text)
+			y -= step
+		}
+	}
+
+	footer, number := accent, muted
+	if s.Heading != "" {
+		footer, number = white, white
+	}
+	if s.Footer != "" {
+		text("F2", footerSize, footer, margin, margin/2, s.Footer)
+	}
+	page := fmt.Sprintf("%d/%d", n, total)
+	text("F1", footerSize, number, pageSize-margin-textWidth(page, footerSize, false), margin/2, page)
+	return strings.TrimSuffix(c.String(), "\n")
+}
+
+// line is a line of set text. Gaps separate paragraphs; indent is in
+// hundredths of the type size.
+type line struct {
+	text   string
+	indent int
+	gap    bool
+}
+
+// fit wraps paragraphs to width at the largest size from size down to
+// minSize whose lines fit in height, and returns it with the lines. Text
+// that does not fit even then runs past height.
+func fit(paragraphs []string, width, height, size int, bold bool) (int, []line) {
+	for ; ; size -= 2 {
+		lines := setLines(paragraphs, width, size, bold)
+		used := 0
+		for _, l := range lines {
+			if l.gap {
+				used += int(float64(size) * leading / 2)
+			} else {
+				used += int(float64(size) * leading)
+			}
+		}
+		if used <= height || size <= minSize {
+			return size, lines
+		}
+	}
+}
+
+func setLines(paragraphs []string, width, size int, bold bool) []line {
+	var lines []line
+	for i, p := range paragraphs {
+		if i > 0 {
+			lines = append(lines, line{gap: true})
+		}
+		indent := 0
+		if strings.HasPrefix(p, "- ") {
+			p = "• " + p[2:]
+			indent = 60 // hang wrapped lines under the text, not the bullet
+		}
+		cur := ""
+		first := true
+		for _, word := range strings.Fields(p) {
+			try := word
+			if cur != "" {
+				try = cur + " " + word
+			}
+			avail := width
+			if !first {
+				avail -= indent * size / 100
+			}
+			if cur == "" || textWidth(try, size, bold) <= avail {
+				cur = try
+				continue
+			}
+			lines = append(lines, wrapped(cur, first, indent))
+			cur, first = word, false
+		}
+		if cur != "" {
+			lines = append(lines, wrapped(cur, first, indent))
+		}
+	}
+	return lines
+}
+
+func wrapped(text string, first bool, indent int) line {
+	if first {
+		return line{text: text}
+	}
+	return line{text: text, indent: indent}
+}
+
+// textWidth estimates the width of s in Helvetica at size from classes of
+// glyph widths, in points.
+func textWidth(s string, size int, bold bool) int {
+	var em float64
+	for _, r := range s {
+		switch {
+		case strings.ContainsRune("iljI.,:;'|!", r):
+			em += 0.28
+		case strings.ContainsRune("ftr ()-", r):
+			em += 0.33
+		case strings.ContainsRune("mwMW", r):
+			em += 0.86
+		case unicode.IsUpper(r):
+			em += 0.69
+		default:
+			em += 0.56
+		}
+	}
+	if bold {
+		em *= 1.06
+	}
+	return int(em * float64(size))
+}
+
+// winAnsi maps the characters outside Latin-1 that WinAnsiEncoding has.
+var winAnsi = map[rune]byte{
+	'€': 0x80, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93,
+	'”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// pdfString encodes s as a literal PDF string in WinAnsiEncoding. Letters
+// styled with Unicode become plain, and characters the encoding lacks are
+// left out.
+func pdfString(s string) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, r := range norm.NFKC.String(s) {
+		c, ok := winAnsiByte(r)
+		if !ok {
+			continue
+		}
+		if r == '(' || r == ')' || r == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+func winAnsiByte(r rune) (byte, bool) {
+	if r >= 0x20 && r < 0x7f || r >= 0xa0 && r <= 0xff {
+		return byte(r), true
+	}
+	c, ok := winAnsi[r]
+	return c, ok
+}
+
+// Unsupported returns the letters and digits of slides that PDF leaves out,
+// such as Greek or Chinese, each once. Emoji and other symbols it leaves
+// out are not reported, as the text reads the same without them.
+func Unsupported(slides []Slide) []rune {
+	var missing []rune
+	check := func(s string) {
+		for _, r := range norm.NFKC.String(s) {
+			if _, ok := winAnsiByte(r); ok || !unicode.IsLetter(r) && !unicode.IsNumber(r) {
+				continue
+			}
+			if !slices.Contains(missing, r) {
+				missing = append(missing, r)
+			}
+		}
+	}
+	for _, sl := range slides {
+		check(sl.Heading)
+		for _, p := range sl.Body {
+			check(p)
+		}
+		check(sl.Footer)
+	}
+	return missing
+}
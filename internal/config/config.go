@@ -2,8 +2,14 @@
 package config
 
 import (
-	"log"
-	"os"
+	"time"
+)
+
+// Observers that can receive a copy of API traffic, see Config.Observer.
+const (
+	ObserverNone    = "none"
+	ObserverTreblle = "treblle"
+	ObserverJSONL   = "jsonl"
 )
 
 type Config struct {
@@ -13,44 +19,83 @@ type Config struct {
 	OpenAIToken   string
 	TreblleToken  string
 	TreblleAPIKey string
-}
-
-func Load() Config {
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		log.Fatal("FATAL: JWT_SECRET environment variable is required")
-	}
+	TreblleDebug  bool
+	// Observer selects where API traffic is mirrored: none, treblle or
+	// jsonl. It defaults to treblle when Treblle credentials are set.
+	Observer string
+	// ObserverLogPath is the file the jsonl observer appends to.
+	ObserverLogPath string
+	// DeletionGracePeriod is how long a deleted account can still be restored.
+	DeletionGracePeriod time.Duration
+	// LogLevel is one of debug, info, warn or error; LogFormat is json or text.
+	LogLevel  string
+	LogFormat string
+	// TraceExporter is none, stdout or otlp; the OTLP endpoint comes from
+	// the standard OTEL_EXPORTER_OTLP_* variables.
+	TraceExporter    string
+	TraceSampleRatio float64
+	// HealthProbeAI adds the AI provider to the readiness checks. Its
+	// failure marks the service degraded but still ready.
+	HealthProbeAI bool
+	// AdminToken guards the admin API; the API is disabled when it is empty.
+	AdminToken string
+	// SettingsPollInterval is how often runtime settings and feature flags
+	// are reloaded.
+	SettingsPollInterval time.Duration
+	// AITimeout bounds each attempt to call the AI provider. Rate-limited
+	// and failed calls are retried up to AIMaxRetries times, and
+	// AIBreakerThreshold consecutive failures stop calls to the provider
+	// for AIBreakerCooldown.
+	AITimeout          time.Duration
+	AIMaxRetries       int
+	AIBreakerThreshold int
+	AIBreakerCooldown  time.Duration
+	// PostMaxLength, PostHookLength and PostMaxHashtags constrain generated
+	// posts; a post breaking them is regenerated up to PostReprompts times
+	// before it is truncated.
+	PostMaxLength   int
+	PostHookLength  int
+	PostMaxHashtags int
+	PostReprompts   int
+	// InputMaxLength caps transform input in characters, counted after it
+	// is normalized.
+	InputMaxLength int
+	// ModerationBlocklist terms reject a transform's input or output.
+	// ModerationProfanity, ModerationPII and ModerationInjection are
+	// allow, flag or block.
+	// ModerationLLM adds the provider's moderation model, whose findings
+	// block.
+	ModerationBlocklist []string
+	ModerationProfanity string
+	ModerationPII       string
+	ModerationInjection string
+	ModerationLLM       bool
+	// AIRoutingFile names the provider routing policy; AIRouting is what
+	// was loaded from it, or nil to send every call to OpenAI.
+	AIRoutingFile string
+	AIRouting     *Routing
+	// ImageGenerator is placeholder, which renders SVGs locally, or openai.
+	ImageGenerator string
+	// BlobStore is where files such as images are kept: local, under
+	// BlobDir, or s3, in S3Bucket of an S3-compatible service.
+	BlobStore         string
+	BlobDir           string
+	S3Endpoint        string
+	S3Bucket          string
+	S3Region          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	// StorageQuotaBytes is how much each user may store, 0 for no limit.
+	// BlobURLTTL is how long signed download links work.
+	StorageQuotaBytes int64
+	BlobURLTTL        time.Duration
 
-	openAIToken := os.Getenv("OPENAI_TOKEN")
-	if openAIToken == "" {
-		log.Fatal("FATAL: OPENAI_TOKEN environment variable is required")
-	}
-
-	treblleToken := os.Getenv("TREBLLE_SDK_TOKEN")
-	if treblleToken == "" {
-		log.Fatal("FATAL: TREBLLE_SDK_TOKEN environment variable is required")
-	}
-
-	treblleAPIKey := os.Getenv("TREBLLE_API_KEY")
-	if treblleAPIKey == "" {
-		log.Fatal("FATAL: TREBLLE_API_KEY environment variable is required")
-	}
-
-	return Config{
-		HTTPAddr:      envDefault("HTTP_ADDR", ":8080"),
-		DSN:           envDefault("DATABASE_DSN", "postgres:///linkedinify?sslmode=disable"),
-		JWTSecret:     []byte(jwtSecret),
-		OpenAIToken:   openAIToken,
-		TreblleToken:  treblleToken,
-		TreblleAPIKey: treblleAPIKey,
-	}
-}
+	// PrintConfig is set by --print-config: the caller should print the
+	// configuration with Print and exit instead of serving.
+	PrintConfig bool
 
-func envDefault(key, def string) string {
-	if v := os.Getenv(key); v != "" {
-		return v
-	}
-	return def
+	// sources records where each setting's value came from, by key.
+	sources map[string]string
 }
 
 func (c Config) GetJWTSecret() []byte {
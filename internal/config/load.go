@@ -0,0 +1,464 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Sources a setting's value can come from, in increasing precedence.
+const (
+	sourceDefault = "default"
+	sourceFile    = "file"
+	sourceEnv     = "env"
+	sourceFlag    = "flag"
+)
+
+// setting describes one configuration value. Its key is used in config
+// files; the environment variable is env (or env+"_FILE" for a file holding
+// the value) and the command-line flag is the key with dashes.
+type setting struct {
+	key    string
+	env    string
+	def    string
+	secret bool
+	usage  string
+	set    func(c *Config, v string) error
+	get    func(c Config) string
+}
+
+var settings = []setting{
+	{key: "http_addr", env: "HTTP_ADDR", def: ":8080", usage: "address to listen on",
+		set: func(c *Config, v string) error { c.HTTPAddr = v; return nil },
+		get: func(c Config) string { return c.HTTPAddr }},
+	{key: "database_dsn", env: "DATABASE_DSN", def: "postgres:///linkedinify?sslmode=disable", secret: true, usage: "Postgres connection string",
+		set: func(c *Config, v string) error { c.DSN = v; return nil },
+		get: func(c Config) string { return c.DSN }},
+	{key: "jwt_secret", env: "JWT_SECRET", secret: true, usage: "secret used to sign JWTs (required)",
+		set: func(c *Config, v string) error { c.JWTSecret = []byte(v); return nil },
+		get: func(c Config) string { return string(c.JWTSecret) }},
+	{key: "openai_token", env: "OPENAI_TOKEN", secret: true, usage: "OpenAI API key (required)",
+		set: func(c *Config, v string) error { c.OpenAIToken = v; return nil },
+		get: func(c Config) string { return c.OpenAIToken }},
+	{key: "treblle_sdk_token", env: "TREBLLE_SDK_TOKEN", secret: true, usage: "Treblle SDK token",
+		set: func(c *Config, v string) error { c.TreblleToken = v; return nil },
+		get: func(c Config) string { return c.TreblleToken }},
+	{key: "treblle_api_key", env: "TREBLLE_API_KEY", secret: true, usage: "Treblle API key",
+		set: func(c *Config, v string) error { c.TreblleAPIKey = v; return nil },
+		get: func(c Config) string { return c.TreblleAPIKey }},
+	{key: "treblle_debug", env: "TREBLLE_DEBUG", def: "false", usage: "print what is sent to Treblle",
+		set: func(c *Config, v string) (err error) { c.TreblleDebug, err = strconv.ParseBool(v); return err },
+		get: func(c Config) string { return strconv.FormatBool(c.TreblleDebug) }},
+	{key: "observer", env: "OBSERVER", usage: "where API traffic is mirrored: none, treblle or jsonl (default treblle when Treblle credentials are set)",
+		set: func(c *Config, v string) error { c.Observer = v; return nil },
+		get: func(c Config) string { return c.Observer }},
+	{key: "observer_log_path", env: "OBSERVER_LOG_PATH", def: "api-requests.jsonl", usage: "file the jsonl observer appends to",
+		set: func(c *Config, v string) error { c.ObserverLogPath = v; return nil },
+		get: func(c Config) string { return c.ObserverLogPath }},
+	{key: "account_deletion_grace_period", env: "ACCOUNT_DELETION_GRACE_PERIOD", def: "720h", usage: "how long deleted accounts can be restored",
+		set: func(c *Config, v string) (err error) { c.DeletionGracePeriod, err = time.ParseDuration(v); return err },
+		get: func(c Config) string { return c.DeletionGracePeriod.String() }},
+	{key: "log_level", env: "LOG_LEVEL", def: "info", usage: "debug, info, warn or error",
+		set: func(c *Config, v string) error { c.LogLevel = v; return nil },
+		get: func(c Config) string { return c.LogLevel }},
+	{key: "log_format", env: "LOG_FORMAT", def: "json", usage: "json or text",
+		set: func(c *Config, v string) error { c.LogFormat = v; return nil },
+		get: func(c Config) string { return c.LogFormat }},
+	{key: "trace_exporter", env: "TRACE_EXPORTER", def: "none", usage: "none, stdout or otlp",
+		set: func(c *Config, v string) error { c.TraceExporter = v; return nil },
+		get: func(c Config) string { return c.TraceExporter }},
+	{key: "trace_sample_ratio", env: "TRACE_SAMPLE_RATIO", def: "1", usage: "fraction of new traces to sample",
+		set: func(c *Config, v string) (err error) { c.TraceSampleRatio, err = strconv.ParseFloat(v, 64); return err },
+		get: func(c Config) string { return strconv.FormatFloat(c.TraceSampleRatio, 'g', -1, 64) }},
+	{key: "health_probe_ai", env: "HEALTH_PROBE_AI", def: "false", usage: "include the AI provider in readiness checks",
+		set: func(c *Config, v string) (err error) { c.HealthProbeAI, err = strconv.ParseBool(v); return err },
+		get: func(c Config) string { return strconv.FormatBool(c.HealthProbeAI) }},
+	{key: "admin_token", env: "ADMIN_TOKEN", secret: true, usage: "bearer token for the admin API (disabled when empty)",
+		set: func(c *Config, v string) error { c.AdminToken = v; return nil },
+		get: func(c Config) string { return c.AdminToken }},
+	{key: "settings_poll_interval", env: "SETTINGS_POLL_INTERVAL", def: "10s", usage: "how often runtime settings and feature flags are reloaded",
+		set: func(c *Config, v string) (err error) { c.SettingsPollInterval, err = time.ParseDuration(v); return err },
+		get: func(c Config) string { return c.SettingsPollInterval.String() }},
+	{key: "ai_timeout", env: "AI_TIMEOUT", def: "30s", usage: "timeout of each attempt to call the AI provider",
+		set: func(c *Config, v string) (err error) { c.AITimeout, err = time.ParseDuration(v); return err },
+		get: func(c Config) string { return c.AITimeout.String() }},
+	{key: "ai_max_retries", env: "AI_MAX_RETRIES", def: "2", usage: "retries of a rate-limited or failed AI call",
+		set: func(c *Config, v string) (err error) { c.AIMaxRetries, err = strconv.Atoi(v); return err },
+		get: func(c Config) string { return strconv.Itoa(c.AIMaxRetries) }},
+	{key: "ai_breaker_threshold", env: "AI_BREAKER_THRESHOLD", def: "5", usage: "consecutive AI failures that open the circuit breaker (0 disables it)",
+		set: func(c *Config, v string) (err error) { c.AIBreakerThreshold, err = strconv.Atoi(v); return err },
+		get: func(c Config) string { return strconv.Itoa(c.AIBreakerThreshold) }},
+	{key: "ai_breaker_cooldown", env: "AI_BREAKER_COOLDOWN", def: "30s", usage: "how long the open circuit breaker fails AI calls fast",
+		set: func(c *Config, v string) (err error) { c.AIBreakerCooldown, err = time.ParseDuration(v); return err },
+		get: func(c Config) string { return c.AIBreakerCooldown.String() }},
+	{key: "input_max_length", env: "INPUT_MAX_LENGTH", def: "5000", usage: "character limit of transform input after normalization",
+		set: func(c *Config, v string) (err error) { c.InputMaxLength, err = strconv.Atoi(v); return err },
+		get: func(c Config) string { return strconv.Itoa(c.InputMaxLength) }},
+	{key: "post_max_length", env: "POST_MAX_LENGTH", def: "3000", usage: "character limit of generated posts",
+		set: func(c *Config, v string) (err error) { c.PostMaxLength, err = strconv.Atoi(v); return err },
+		get: func(c Config) string { return strconv.Itoa(c.PostMaxLength) }},
+	{key: "post_hook_length", env: "POST_HOOK_LENGTH", def: "210", usage: "character limit of a generated post's first line",
+		set: func(c *Config, v string) (err error) { c.PostHookLength, err = strconv.Atoi(v); return err },
+		get: func(c Config) string { return strconv.Itoa(c.PostHookLength) }},
+	{key: "post_max_hashtags", env: "POST_MAX_HASHTAGS", def: "5", usage: "hashtags kept in a generated post (0 keeps all)",
+		set: func(c *Config, v string) (err error) { c.PostMaxHashtags, err = strconv.Atoi(v); return err },
+		get: func(c Config) string { return strconv.Itoa(c.PostMaxHashtags) }},
+	{key: "post_reprompts", env: "POST_REPROMPTS", def: "1", usage: "regenerations of a post breaking the limits before it is truncated",
+		set: func(c *Config, v string) (err error) { c.PostReprompts, err = strconv.Atoi(v); return err },
+		get: func(c Config) string { return strconv.Itoa(c.PostReprompts) }},
+	{key: "moderation_blocklist", env: "MODERATION_BLOCKLIST", usage: "comma-separated terms that reject a post",
+		set: func(c *Config, v string) error { c.ModerationBlocklist = splitList(v); return nil },
+		get: func(c Config) string { return strings.Join(c.ModerationBlocklist, ",") }},
+	{key: "moderation_profanity", env: "MODERATION_PROFANITY", def: "flag", usage: "allow, flag or block profanity",
+		set: func(c *Config, v string) error { c.ModerationProfanity = v; return nil },
+		get: func(c Config) string { return c.ModerationProfanity }},
+	{key: "moderation_pii", env: "MODERATION_PII", def: "flag", usage: "allow, flag or block email addresses, phone numbers and IBANs",
+		set: func(c *Config, v string) error { c.ModerationPII = v; return nil },
+		get: func(c Config) string { return c.ModerationPII }},
+	{key: "moderation_injection", env: "MODERATION_INJECTION", def: "flag", usage: "allow, flag or block input that looks like a prompt-injection attempt",
+		set: func(c *Config, v string) error { c.ModerationInjection = v; return nil },
+		get: func(c Config) string { return c.ModerationInjection }},
+	{key: "moderation_llm", env: "MODERATION_LLM", def: "false", usage: "also screen posts with the OpenAI moderation model",
+		set: func(c *Config, v string) (err error) { c.ModerationLLM, err = strconv.ParseBool(v); return err },
+		get: func(c Config) string { return strconv.FormatBool(c.ModerationLLM) }},
+	{key: "ai_routing_file", env: "AI_ROUTING_FILE", usage: "YAML or TOML file routing AI calls across providers (OpenAI only when empty)",
+		set: func(c *Config, v string) error { c.AIRoutingFile = v; return nil },
+		get: func(c Config) string { return c.AIRoutingFile }},
+	{key: "image_generator", env: "IMAGE_GENERATOR", def: "placeholder", usage: "placeholder (offline SVG) or openai",
+		set: func(c *Config, v string) error { c.ImageGenerator = v; return nil },
+		get: func(c Config) string { return c.ImageGenerator }},
+	{key: "blob_store", env: "BLOB_STORE", def: "local", usage: "where files are stored: local or s3",
+		set: func(c *Config, v string) error { c.BlobStore = v; return nil },
+		get: func(c Config) string { return c.BlobStore }},
+	{key: "blob_dir", env: "BLOB_DIR", def: "data/blobs", usage: "directory the local store keeps files in",
+		set: func(c *Config, v string) error { c.BlobDir = v; return nil },
+		get: func(c Config) string { return c.BlobDir }},
+	{key: "s3_endpoint", env: "S3_ENDPOINT", usage: "URL of the S3-compatible service, such as http://minio:9000",
+		set: func(c *Config, v string) error { c.S3Endpoint = v; return nil },
+		get: func(c Config) string { return c.S3Endpoint }},
+	{key: "s3_bucket", env: "S3_BUCKET", usage: "bucket files are stored in",
+		set: func(c *Config, v string) error { c.S3Bucket = v; return nil },
+		get: func(c Config) string { return c.S3Bucket }},
+	{key: "s3_region", env: "S3_REGION", def: "us-east-1", usage: "region requests are signed for",
+		set: func(c *Config, v string) error { c.S3Region = v; return nil },
+		get: func(c Config) string { return c.S3Region }},
+	{key: "s3_access_key_id", env: "S3_ACCESS_KEY_ID", secret: true, usage: "S3 access key ID",
+		set: func(c *Config, v string) error { c.S3AccessKeyID = v; return nil },
+		get: func(c Config) string { return c.S3AccessKeyID }},
+	{key: "s3_secret_access_key", env: "S3_SECRET_ACCESS_KEY", secret: true, usage: "S3 secret access key",
+		set: func(c *Config, v string) error { c.S3SecretAccessKey = v; return nil },
+		get: func(c Config) string { return c.S3SecretAccessKey }},
+	{key: "storage_quota_bytes", env: "STORAGE_QUOTA_BYTES", def: "104857600", usage: "bytes each user may store, 0 for no limit",
+		set: func(c *Config, v string) (err error) {
+			c.StorageQuotaBytes, err = strconv.ParseInt(v, 10, 64)
+			return err
+		},
+		get: func(c Config) string { return strconv.FormatInt(c.StorageQuotaBytes, 10) }},
+	{key: "blob_url_ttl", env: "BLOB_URL_TTL", def: "15m", usage: "how long signed download links work",
+		set: func(c *Config, v string) (err error) { c.BlobURLTTL, err = time.ParseDuration(v); return err },
+		get: func(c Config) string { return c.BlobURLTTL.String() }},
+}
+
+// Load builds the configuration from, in increasing precedence: defaults,
+// the file named by --config or CONFIG_FILE (YAML or TOML by extension),
+// environment variables and command-line flags in args. Every problem found
+// is reported in the returned error, joined with errors.Join. When
+// --print-config is given the configuration is returned even if invalid.
+func Load(args []string) (Config, error) {
+	values := map[string]string{}
+	sources := map[string]string{}
+	for _, s := range settings {
+		if s.def != "" {
+			values[s.key], sources[s.key] = s.def, sourceDefault
+		}
+	}
+
+	fs := flag.NewFlagSet("linkedinify", flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "YAML or TOML config file")
+	printConfig := fs.Bool("print-config", false, "print the configuration with secrets masked and exit")
+	flagValues := map[string]*string{}
+	for _, s := range settings {
+		flagValues[s.key] = fs.String(strings.ReplaceAll(s.key, "_", "-"), "", s.usage+" (env "+s.env+")")
+	}
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+
+	var errs []error
+	if *configFile != "" {
+		fileValues, err := readFile(*configFile)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		for k, v := range fileValues {
+			values[k], sources[k] = v, sourceFile
+		}
+	}
+
+	for _, s := range settings {
+		v, ok, err := lookupEnv(s.env)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if ok {
+			values[s.key], sources[s.key] = v, sourceEnv
+		}
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		key := strings.ReplaceAll(f.Name, "-", "_")
+		if p, ok := flagValues[key]; ok {
+			values[key], sources[key] = *p, sourceFlag
+		}
+	})
+
+	cfg := Config{PrintConfig: *printConfig, sources: sources}
+	for _, s := range settings {
+		v := values[s.key]
+		if v == "" {
+			continue
+		}
+		if err := s.set(&cfg, v); err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid value %q", s.key, v))
+		}
+	}
+	if cfg.Observer == "" {
+		cfg.Observer = ObserverNone
+		if cfg.TreblleToken != "" && cfg.TreblleAPIKey != "" {
+			cfg.Observer = ObserverTreblle
+		}
+	}
+	if cfg.AIRoutingFile != "" {
+		routing, err := LoadRouting(cfg.AIRoutingFile, cfg.OpenAIToken)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			cfg.AIRouting = &routing
+		}
+	}
+	errs = append(errs, cfg.validate()...)
+	return cfg, errors.Join(errs...)
+}
+
+// validate checks constraints beyond what parsing each value enforces.
+func (c Config) validate() []error {
+	var errs []error
+	if len(c.JWTSecret) == 0 {
+		errs = append(errs, errors.New("jwt_secret is required"))
+	}
+	if c.OpenAIToken == "" {
+		errs = append(errs, errors.New("openai_token is required"))
+	}
+	switch c.Observer {
+	case ObserverNone, ObserverJSONL:
+	case ObserverTreblle:
+		if c.TreblleToken == "" || c.TreblleAPIKey == "" {
+			errs = append(errs, errors.New("observer treblle requires treblle_sdk_token and treblle_api_key"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("observer must be none, treblle or jsonl, got %q", c.Observer))
+	}
+	if !oneOf(strings.ToLower(c.LogLevel), "debug", "info", "warn", "error") {
+		errs = append(errs, fmt.Errorf("log_level must be debug, info, warn or error, got %q", c.LogLevel))
+	}
+	if !oneOf(strings.ToLower(c.LogFormat), "json", "text") {
+		errs = append(errs, fmt.Errorf("log_format must be json or text, got %q", c.LogFormat))
+	}
+	if !oneOf(c.TraceExporter, "none", "stdout", "otlp") {
+		errs = append(errs, fmt.Errorf("trace_exporter must be none, stdout or otlp, got %q", c.TraceExporter))
+	}
+	if c.TraceSampleRatio < 0 || c.TraceSampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("trace_sample_ratio must be between 0 and 1, got %g", c.TraceSampleRatio))
+	}
+	if c.SettingsPollInterval <= 0 {
+		errs = append(errs, errors.New("settings_poll_interval must be positive"))
+	}
+	if c.DeletionGracePeriod < 0 {
+		errs = append(errs, errors.New("account_deletion_grace_period must not be negative"))
+	}
+	if c.AITimeout <= 0 {
+		errs = append(errs, errors.New("ai_timeout must be positive"))
+	}
+	if c.AIMaxRetries < 0 || c.AIMaxRetries > 10 {
+		errs = append(errs, errors.New("ai_max_retries must be between 0 and 10"))
+	}
+	for key, v := range map[string]string{"moderation_profanity": c.ModerationProfanity, "moderation_pii": c.ModerationPII, "moderation_injection": c.ModerationInjection} {
+		if !oneOf(v, "allow", "flag", "block") {
+			errs = append(errs, fmt.Errorf("%s must be allow, flag or block, got %q", key, v))
+		}
+	}
+	if !oneOf(c.ImageGenerator, "placeholder", "openai") {
+		errs = append(errs, fmt.Errorf("image_generator must be placeholder or openai, got %q", c.ImageGenerator))
+	}
+	switch c.BlobStore {
+	case "local":
+		if c.BlobDir == "" {
+			errs = append(errs, errors.New("blob_dir is required"))
+		}
+	case "s3":
+		if c.S3Endpoint == "" || c.S3Bucket == "" || c.S3AccessKeyID == "" || c.S3SecretAccessKey == "" {
+			errs = append(errs, errors.New("blob_store s3 requires s3_endpoint, s3_bucket, s3_access_key_id and s3_secret_access_key"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("blob_store must be local or s3, got %q", c.BlobStore))
+	}
+	if c.StorageQuotaBytes < 0 {
+		errs = append(errs, errors.New("storage_quota_bytes must not be negative"))
+	}
+	if c.BlobURLTTL <= 0 {
+		errs = append(errs, errors.New("blob_url_ttl must be positive"))
+	}
+	if c.InputMaxLength < 1 || c.InputMaxLength > 100000 {
+		errs = append(errs, errors.New("input_max_length must be between 1 and 100000"))
+	}
+	if c.PostMaxLength < 1 || c.PostMaxLength > 3000 {
+		errs = append(errs, errors.New("post_max_length must be between 1 and 3000"))
+	}
+	if c.PostHookLength < 1 {
+		errs = append(errs, errors.New("post_hook_length must be positive"))
+	}
+	if c.PostMaxHashtags < 0 {
+		errs = append(errs, errors.New("post_max_hashtags must not be negative"))
+	}
+	if c.PostReprompts < 0 || c.PostReprompts > 3 {
+		errs = append(errs, errors.New("post_reprompts must be between 0 and 3"))
+	}
+	if c.AIBreakerThreshold < 0 {
+		errs = append(errs, errors.New("ai_breaker_threshold must not be negative"))
+	}
+	if c.AIBreakerThreshold > 0 && c.AIBreakerCooldown <= 0 {
+		errs = append(errs, errors.New("ai_breaker_cooldown must be positive when the breaker is enabled"))
+	}
+	return errs
+}
+
+// lookupEnv reads name, or the file named by name_FILE as used for Docker
+// secrets. Setting both is an error.
+func lookupEnv(name string) (string, bool, error) {
+	v, ok := os.LookupEnv(name)
+	path, fromFile := os.LookupEnv(name + "_FILE")
+	switch {
+	case ok && fromFile:
+		return "", false, fmt.Errorf("%s and %s_FILE are both set", name, name)
+	case fromFile:
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return "", false, fmt.Errorf("%s_FILE: %w", name, err)
+		}
+		return strings.TrimRight(string(b), "\r\n"), true, nil
+	default:
+		return v, ok && v != "", nil
+	}
+}
+
+// readFile reads a flat YAML or TOML document of setting keys to values.
+func readFile(path string) (map[string]string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config file: %w", err)
+	}
+	raw := map[string]interface{}{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(b, &raw)
+	case ".toml":
+		err = toml.Unmarshal(b, &raw)
+	default:
+		return nil, fmt.Errorf("config file %s: unsupported extension %q, want .yaml, .yml or .toml", path, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	known := map[string]bool{}
+	for _, s := range settings {
+		known[s.key] = true
+	}
+	values := map[string]string{}
+	var errs []error
+	for k, v := range raw {
+		if !known[k] {
+			errs = append(errs, fmt.Errorf("config file %s: unknown key %q", path, k))
+			continue
+		}
+		value, err := fileValue(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("config file %s: %s: %w", path, k, err))
+			continue
+		}
+		values[k] = value
+	}
+	return values, errors.Join(errs...)
+}
+
+// fileValue renders a config file value as its environment variable would
+// be written: scalars as text and lists joined with commas.
+func fileValue(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			s, err := fileValue(item)
+			if err != nil {
+				return "", err
+			}
+			if _, nested := item.([]interface{}); nested || strings.Contains(s, ",") {
+				return "", fmt.Errorf("list items must be scalars without commas, got %q", s)
+			}
+			items[i] = s
+		}
+		return strings.Join(items, ","), nil
+	case map[string]interface{}, map[interface{}]interface{}:
+		return "", errors.New("must be a scalar or a list, not a table")
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+// Print writes the configuration as YAML with secrets masked, annotating
+// each value with where it came from.
+func (c Config) Print(w io.Writer) error {
+	for _, s := range settings {
+		v := s.get(c)
+		if s.secret && v != "" {
+			v = "********"
+		}
+		source := c.sources[s.key]
+		if source == "" {
+			source = sourceDefault
+		}
+		if _, err := fmt.Fprintf(w, "%s: %s # %s\n", s.key, strconv.Quote(v), source); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitList splits a comma-separated value, dropping blank items.
+func splitList(v string) []string {
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func oneOf(v string, allowed ...string) bool {
+	for _, a := range allowed {
+		if v == a {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+package config_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/config"
+)
+
+func setRequired(t *testing.T) {
+	t.Helper()
+	t.Setenv("JWT_SECRET", "jwt")
+	t.Setenv("OPENAI_TOKEN", "sk-test")
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	setRequired(t)
+
+	cfg, err := config.Load(nil)
+	require.NoError(t, err)
+	assert.Equal(t, ":8080", cfg.HTTPAddr)
+	assert.Equal(t, 720*time.Hour, cfg.DeletionGracePeriod)
+	assert.Equal(t, config.ObserverNone, cfg.Observer)
+	assert.Equal(t, []byte("jwt"), cfg.JWTSecret)
+	assert.Equal(t, 30*time.Second, cfg.AITimeout)
+	assert.Equal(t, 2, cfg.AIMaxRetries)
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	setRequired(t)
+	file := writeFile(t, "linkedinify.yaml", "http_addr: \":9000\"\nlog_level: debug\ntrace_sample_ratio: 0.5\n")
+	t.Setenv("LOG_LEVEL", "warn")
+	t.Setenv("TRACE_SAMPLE_RATIO", "0.25")
+
+	cfg, err := config.Load([]string{"--config", file, "--trace-sample-ratio", "0.1"})
+	require.NoError(t, err)
+	assert.Equal(t, ":9000", cfg.HTTPAddr, "file overrides default")
+	assert.Equal(t, "warn", cfg.LogLevel, "env overrides file")
+	assert.Equal(t, 0.1, cfg.TraceSampleRatio, "flag overrides env")
+}
+
+func TestLoad_TOMLFile(t *testing.T) {
+	setRequired(t)
+	t.Setenv("CONFIG_FILE", writeFile(t, "linkedinify.toml", "health_probe_ai = true\naccount_deletion_grace_period = \"48h\"\n"))
+
+	cfg, err := config.Load(nil)
+	require.NoError(t, err)
+	assert.True(t, cfg.HealthProbeAI)
+	assert.Equal(t, 48*time.Hour, cfg.DeletionGracePeriod)
+}
+
+func TestLoad_FileLists(t *testing.T) {
+	setRequired(t)
+	t.Setenv("CONFIG_FILE", writeFile(t, "linkedinify.yaml", "moderation_blocklist: [foo, bar baz]\n"))
+
+	cfg, err := config.Load(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo", "bar baz"}, cfg.ModerationBlocklist)
+
+	t.Setenv("CONFIG_FILE", writeFile(t, "linkedinify.toml", "moderation_blocklist = [\"a,b\"]\nlog_level = { level = \"debug\" }\n"))
+	_, err = config.Load(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `moderation_blocklist: list items must be scalars without commas, got "a,b"`)
+	assert.Contains(t, err.Error(), "log_level: must be a scalar or a list, not a table")
+}
+
+func TestLoad_FileSuffix(t *testing.T) {
+	t.Setenv("OPENAI_TOKEN", "sk-test")
+	t.Setenv("JWT_SECRET_FILE", writeFile(t, "jwt", "from-secret-file\n"))
+
+	cfg, err := config.Load(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("from-secret-file"), cfg.JWTSecret)
+}
+
+func TestLoad_ReportsAllErrors(t *testing.T) {
+	t.Setenv("JWT_SECRET", "")
+	t.Setenv("OPENAI_TOKEN", "sk-test")
+	t.Setenv("OPENAI_TOKEN_FILE", "/run/secrets/openai")
+	t.Setenv("TRACE_SAMPLE_RATIO", "2")
+	t.Setenv("ACCOUNT_DELETION_GRACE_PERIOD", "forever")
+	t.Setenv("OBSERVER", "treblle")
+	t.Setenv("AI_MAX_RETRIES", "-1")
+	file := writeFile(t, "linkedinify.yml", "log_format: xml\nunknown_key: 1\n")
+
+	_, err := config.Load([]string{"--config", file})
+	require.Error(t, err)
+	for _, want := range []string{
+		"jwt_secret is required",
+		"OPENAI_TOKEN and OPENAI_TOKEN_FILE are both set",
+		"trace_sample_ratio must be between 0 and 1",
+		`account_deletion_grace_period: invalid value "forever"`,
+		"observer treblle requires",
+		"ai_max_retries must be between 0 and 10",
+		`log_format must be json or text, got "xml"`,
+		`unknown key "unknown_key"`,
+	} {
+		assert.Contains(t, err.Error(), want)
+	}
+}
+
+func TestLoad_BlobStore(t *testing.T) {
+	setRequired(t)
+	t.Setenv("BLOB_STORE", "s3")
+	t.Setenv("S3_ENDPOINT", "http://minio:9000")
+	t.Setenv("STORAGE_QUOTA_BYTES", "-1")
+
+	_, err := config.Load(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "blob_store s3 requires")
+	assert.Contains(t, err.Error(), "storage_quota_bytes must not be negative")
+
+	t.Setenv("S3_BUCKET", "linkedinify")
+	t.Setenv("S3_ACCESS_KEY_ID", "minio")
+	t.Setenv("S3_SECRET_ACCESS_KEY", "minio123")
+	t.Setenv("STORAGE_QUOTA_BYTES", "0")
+	cfg, err := config.Load(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "us-east-1", cfg.S3Region)
+	assert.Equal(t, 15*time.Minute, cfg.BlobURLTTL)
+}
+
+func TestConfig_PrintMasksSecrets(t *testing.T) {
+	setRequired(t)
+	cfg, err := config.Load([]string{"--print-config", "--log-level", "debug"})
+	require.NoError(t, err)
+	require.True(t, cfg.PrintConfig)
+
+	var out bytes.Buffer
+	require.NoError(t, cfg.Print(&out))
+	assert.Contains(t, out.String(), `jwt_secret: "********" # env`)
+	assert.Contains(t, out.String(), `openai_token: "********" # env`)
+	assert.Contains(t, out.String(), `log_level: "debug" # flag`)
+	assert.Contains(t, out.String(), `http_addr: ":8080" # default`)
+	assert.Contains(t, out.String(), `treblle_sdk_token: "" # default`)
+	assert.NotContains(t, out.String(), "sk-test")
+}
+
+func TestLoad_AIRouting(t *testing.T) {
+	setRequired(t)
+	t.Setenv("GROQ_API_KEY", "gsk-test")
+	t.Setenv("AI_ROUTING_FILE", writeFile(t, "routing.yaml", `
+providers:
+  - name: openai
+    model: gpt-4o-mini
+  - name: groq
+    model: llama-3.1-70b-versatile
+    base_url: https://api.groq.com/openai/v1
+    token_env: GROQ_API_KEY
+routes:
+  - tiers: [pro]
+    targets:
+      - {provider: openai, weight: 80}
+      - {provider: groq, weight: 20}
+  - targets:
+      - {provider: openai}
+      - {provider: groq}
+`))
+
+	cfg, err := config.Load(nil)
+	require.NoError(t, err)
+	require.NotNil(t, cfg.AIRouting)
+	assert.Equal(t, "sk-test", cfg.AIRouting.Providers[0].Token)
+	assert.Equal(t, "gsk-test", cfg.AIRouting.Providers[1].Token)
+	assert.Equal(t, []string{"pro"}, cfg.AIRouting.Routes[0].Tiers)
+	assert.Equal(t, 20, cfg.AIRouting.Routes[0].Targets[1].Weight)
+}
+
+func TestLoad_AIRoutingErrors(t *testing.T) {
+	setRequired(t)
+	t.Setenv("AI_ROUTING_FILE", writeFile(t, "routing.toml", `
+[[providers]]
+name = "openai"
+token_env = "MISSING_KEY"
+
+[[routes]]
+styles = ["formal"]
+targets = [{ provider = "anthropic", weight = -1 }]
+`))
+
+	_, err := config.Load(nil)
+	require.Error(t, err)
+	for _, want := range []string{
+		`provider "openai": MISSING_KEY is not set`,
+		`provider "openai": model is required`,
+		`route 1: unknown provider "anthropic"`,
+		`route 1: weight of "anthropic" must not be negative`,
+		"the last route must have no conditions",
+	} {
+		assert.Contains(t, err.Error(), want)
+	}
+}
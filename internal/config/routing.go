@@ -0,0 +1,139 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Routing declares the AI providers and which of them serve which calls.
+// It is read from the file named by ai_routing_file.
+type Routing struct {
+	Providers []Provider `yaml:"providers" toml:"providers"`
+	// Routes are tried in order and the first matching one is used, so the
+	// last route should have no conditions. Calls that carry no style or
+	// tier, such as translations, only match such unconditional routes.
+	Routes []Route `yaml:"routes" toml:"routes"`
+}
+
+// Provider is an OpenAI-compatible API and the model used with it.
+type Provider struct {
+	Name  string `yaml:"name" toml:"name"`
+	Model string `yaml:"model" toml:"model"`
+	// BaseURL defaults to the OpenAI API.
+	BaseURL string `yaml:"base_url" toml:"base_url"`
+	// TokenEnv names the environment variable holding the API key; it
+	// defaults to the OpenAI token. Keys never go in the routing file.
+	TokenEnv string `yaml:"token_env" toml:"token_env"`
+	// Token is resolved from TokenEnv by LoadRouting.
+	Token string `yaml:"-" toml:"-"`
+}
+
+// Route sends calls matching all of its non-empty conditions to Targets.
+type Route struct {
+	Styles  []string `yaml:"styles" toml:"styles"`
+	Tiers   []string `yaml:"tiers" toml:"tiers"`
+	Targets []Target `yaml:"targets" toml:"targets"`
+}
+
+// Target refers to a provider of a route. Targets with a weight share the
+// first attempt of each call in proportion to it; the other targets, in
+// order, are only used as fallbacks.
+type Target struct {
+	Provider string `yaml:"provider" toml:"provider"`
+	Weight   int    `yaml:"weight" toml:"weight"`
+}
+
+// LoadRouting reads a YAML or TOML routing file, resolves provider tokens
+// and validates it. Providers without token_env use openAIToken.
+func LoadRouting(path, openAIToken string) (Routing, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Routing{}, fmt.Errorf("ai_routing_file: %w", err)
+	}
+	var r Routing
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(b))
+		dec.KnownFields(true)
+		err = dec.Decode(&r)
+	case ".toml":
+		var md toml.MetaData
+		md, err = toml.Decode(string(b), &r)
+		if err == nil && len(md.Undecoded()) > 0 {
+			err = fmt.Errorf("unknown key %q", md.Undecoded()[0].String())
+		}
+	default:
+		return Routing{}, fmt.Errorf("ai_routing_file %s: unsupported extension %q, want .yaml, .yml or .toml", path, ext)
+	}
+	if err != nil {
+		return Routing{}, fmt.Errorf("ai_routing_file %s: %w", path, err)
+	}
+
+	var errs []error
+	for i := range r.Providers {
+		p := &r.Providers[i]
+		p.Token = openAIToken
+		if p.TokenEnv != "" {
+			v, ok, err := lookupEnv(p.TokenEnv)
+			switch {
+			case err != nil:
+				errs = append(errs, err)
+			case !ok:
+				errs = append(errs, fmt.Errorf("provider %q: %s is not set", p.Name, p.TokenEnv))
+			}
+			p.Token = v
+		}
+	}
+	errs = append(errs, r.validate()...)
+	if len(errs) > 0 {
+		return Routing{}, fmt.Errorf("ai_routing_file %s: %w", path, errors.Join(errs...))
+	}
+	return r, nil
+}
+
+func (r Routing) validate() []error {
+	var errs []error
+	if len(r.Providers) == 0 {
+		errs = append(errs, errors.New("at least one provider is required"))
+	}
+	known := map[string]bool{}
+	for i, p := range r.Providers {
+		switch {
+		case p.Name == "":
+			errs = append(errs, fmt.Errorf("provider %d: name is required", i+1))
+		case known[p.Name]:
+			errs = append(errs, fmt.Errorf("provider %q is declared twice", p.Name))
+		}
+		if p.Model == "" {
+			errs = append(errs, fmt.Errorf("provider %q: model is required", p.Name))
+		}
+		known[p.Name] = true
+	}
+	if len(r.Routes) == 0 {
+		errs = append(errs, errors.New("at least one route is required"))
+	}
+	for i, rt := range r.Routes {
+		if len(rt.Targets) == 0 {
+			errs = append(errs, fmt.Errorf("route %d: at least one target is required", i+1))
+		}
+		for _, t := range rt.Targets {
+			if !known[t.Provider] {
+				errs = append(errs, fmt.Errorf("route %d: unknown provider %q", i+1, t.Provider))
+			}
+			if t.Weight < 0 {
+				errs = append(errs, fmt.Errorf("route %d: weight of %q must not be negative", i+1, t.Provider))
+			}
+		}
+	}
+	if n := len(r.Routes); n > 0 && (len(r.Routes[n-1].Styles) > 0 || len(r.Routes[n-1].Tiers) > 0) {
+		errs = append(errs, errors.New("the last route must have no conditions so that every call is routed"))
+	}
+	return errs
+}
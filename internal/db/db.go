@@ -7,11 +7,15 @@ import (
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect/pgdialect"
 	"github.com/uptrace/bun/driver/pgdriver"
+	"github.com/uptrace/bun/extra/bunotel"
 
 	"github.com/you/linkedinify/internal/config"
 )
 
 func New(cfg config.Config) *bun.DB {
 	sqldb := sql.OpenDB(pgdriver.NewConnector(pgdriver.WithDSN(cfg.DSN)))
-	return bun.NewDB(sqldb, pgdialect.New())
+	database := bun.NewDB(sqldb, pgdialect.New())
+	// Record a span for every query, parented to the calling request's span.
+	database.AddQueryHook(bunotel.NewQueryHook(bunotel.WithDBName("linkedinify")))
+	return database
 }
@@ -0,0 +1,35 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+// SchemaVersion is the number of the latest migration in migrations/ that
+// this build expects to have been applied.
+const SchemaVersion = 12
+
+// AppliedSchemaVersion returns the highest migration version recorded in
+// schema_migrations.
+func AppliedSchemaVersion(ctx context.Context, db bun.IDB) (int, error) {
+	var version int
+	err := db.NewSelect().
+		TableExpr("schema_migrations").
+		ColumnExpr("coalesce(max(version), 0)").
+		Scan(ctx, &version)
+	return version, err
+}
+
+// CheckSchema fails when the database is behind SchemaVersion.
+func CheckSchema(ctx context.Context, db bun.IDB) error {
+	version, err := AppliedSchemaVersion(ctx, db)
+	if err != nil {
+		return fmt.Errorf("reading schema version: %w", err)
+	}
+	if version < SchemaVersion {
+		return fmt.Errorf("schema version %d is behind required version %d", version, SchemaVersion)
+	}
+	return nil
+}
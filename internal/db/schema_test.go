@@ -0,0 +1,30 @@
+package db_test
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/db"
+)
+
+func TestSchemaVersion_MatchesLatestMigration(t *testing.T) {
+	entries, err := os.ReadDir("../../migrations")
+	require.NoError(t, err)
+
+	latest := 0
+	for _, e := range entries {
+		prefix, _, ok := strings.Cut(e.Name(), "_")
+		if !ok || !strings.HasSuffix(e.Name(), ".sql") {
+			continue
+		}
+		n, err := strconv.Atoi(prefix)
+		require.NoError(t, err, e.Name())
+		latest = max(latest, n)
+	}
+	assert.Equal(t, latest, db.SchemaVersion, "bump db.SchemaVersion and record the version in the new migration")
+}
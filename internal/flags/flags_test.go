@@ -0,0 +1,99 @@
+package flags_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/you/linkedinify/internal/flags"
+	"github.com/you/linkedinify/internal/model"
+)
+
+func TestEvaluate_RulesInOrder(t *testing.T) {
+	beta := uuid.New()
+	f := &model.FeatureFlag{
+		Key:            "post-style",
+		Enabled:        true,
+		Variants:       []string{"default", "witty", "formal"},
+		DefaultVariant: "default",
+		Rules: []model.FlagRule{
+			{UserIDs: []uuid.UUID{beta}, Percentage: 100, Variant: "witty"},
+			{Organizations: []string{"acme.com"}, Percentage: 100, Variant: "formal"},
+		},
+	}
+
+	assert.Equal(t, "witty", flags.Evaluate(f, flags.Subject{UserID: beta, Organization: "acme.com"}))
+	assert.Equal(t, "formal", flags.Evaluate(f, flags.Subject{UserID: uuid.New(), Organization: "acme.com"}))
+	assert.Equal(t, "default", flags.Evaluate(f, flags.Subject{UserID: uuid.New(), Organization: "other.org"}))
+
+	f.Enabled = false
+	assert.Equal(t, "default", flags.Evaluate(f, flags.Subject{UserID: beta}), "disabled flags serve the default")
+}
+
+func TestEvaluate_TargetingOnlyRuleMatchesEveryTarget(t *testing.T) {
+	beta := uuid.New()
+	f := &model.FeatureFlag{
+		Key:            "post-style",
+		Enabled:        true,
+		Variants:       []string{"default", "witty", "formal"},
+		DefaultVariant: "default",
+		Rules: []model.FlagRule{
+			{UserIDs: []uuid.UUID{beta}, Variant: "witty"},
+			{Organizations: []string{"acme.com"}, Variant: "formal"},
+			{Variant: "witty"},
+		},
+	}
+
+	assert.Equal(t, "witty", flags.Evaluate(f, flags.Subject{UserID: beta}))
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, "formal", flags.Evaluate(f, flags.Subject{UserID: uuid.New(), Organization: "acme.com"}))
+		assert.Equal(t, "default", flags.Evaluate(f, flags.Subject{UserID: uuid.New(), Organization: "other.org"}),
+			"a rule without targets or percentage matches no one")
+	}
+}
+
+func TestEvaluate_PercentageRolloutIsStableAndMonotonic(t *testing.T) {
+	f := &model.FeatureFlag{
+		Key: "new-editor", Enabled: true, Variants: []string{flags.Off, flags.On}, DefaultVariant: flags.Off,
+		Rules: []model.FlagRule{{Percentage: 20, Variant: flags.On}},
+	}
+	users := make([]flags.Subject, 2000)
+	for i := range users {
+		users[i] = flags.Subject{UserID: uuid.New()}
+	}
+
+	on := map[uuid.UUID]bool{}
+	for _, u := range users {
+		if flags.Evaluate(f, u) == flags.On {
+			on[u.UserID] = true
+		}
+		assert.Equal(t, flags.Evaluate(f, u), flags.Evaluate(f, u))
+	}
+	assert.InDelta(t, 400, len(on), 120)
+
+	f.Rules[0].Percentage = 50
+	for id := range on {
+		assert.Equal(t, flags.On, flags.Evaluate(f, flags.Subject{UserID: id}), "users keep the flag as the rollout grows")
+	}
+}
+
+func TestContext_ResolvesSubjectAtEvaluation(t *testing.T) {
+	type userKey struct{}
+	user := uuid.New()
+	set := flags.Set{"beta": {
+		Key: "beta", Enabled: true, Variants: []string{flags.Off, flags.On}, DefaultVariant: flags.Off,
+		Rules: []model.FlagRule{{UserIDs: []uuid.UUID{user}, Percentage: 100, Variant: flags.On}},
+	}}
+	subject := func(ctx context.Context) flags.Subject {
+		id, _ := ctx.Value(userKey{}).(uuid.UUID)
+		return flags.Subject{UserID: id}
+	}
+
+	ctx := flags.WithSet(context.Background(), set, subject)
+	assert.False(t, flags.Enabled(ctx, "beta"))
+	assert.True(t, flags.Enabled(context.WithValue(ctx, userKey{}, user), "beta"))
+	assert.Equal(t, "", flags.Variant(ctx, "missing"))
+	assert.False(t, flags.Enabled(context.Background(), "beta"), "no flags in context")
+}
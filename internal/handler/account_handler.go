@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/logging"
+	"github.com/you/linkedinify/internal/middleware"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/service"
+)
+
+type AccountHandler struct {
+	svc service.AccountServiceInteractor
+}
+
+func NewAccount(svc service.AccountServiceInteractor) *AccountHandler {
+	return &AccountHandler{svc: svc}
+}
+
+func (h *AccountHandler) Routes(secret []byte) chi.Router {
+	r := chi.NewRouter()
+	r.Use(middleware.Auth(secret))
+	r.Get("/", h.profile)
+	r.Patch("/", h.updateProfile)
+	r.Delete("/", h.requestDeletion)
+	r.Delete("/deletion", h.cancelDeletion)
+	r.Get("/export", h.exportArchive)
+	r.Get("/storage", h.storageUsage)
+	return r
+}
+
+type profileResponse struct {
+	ID                uuid.UUID `json:"id"`
+	Email             string    `json:"email"`
+	DisplayName       string    `json:"display_name"`
+	JobTitle          string    `json:"job_title"`
+	Industry          string    `json:"industry"`
+	PreferredLanguage string    `json:"preferred_language"`
+	DefaultStyle      string    `json:"default_style"`
+	DefaultHashtags   []string  `json:"default_hashtags"`
+	Signature         string    `json:"signature"`
+	MaxLength         int       `json:"max_length"`
+	CreatedAt         time.Time `json:"created_at"`
+	DeleteAfter       *string   `json:"delete_after,omitempty"`
+}
+
+func newProfileResponse(u *model.User) profileResponse {
+	res := profileResponse{
+		ID:                u.ID,
+		Email:             u.Email,
+		DisplayName:       u.DisplayName,
+		JobTitle:          u.JobTitle,
+		Industry:          u.Industry,
+		PreferredLanguage: u.PreferredLanguage,
+		DefaultStyle:      u.DefaultStyle,
+		DefaultHashtags:   u.DefaultHashtags,
+		Signature:         u.Signature,
+		MaxLength:         u.MaxLength,
+		CreatedAt:         u.CreatedAt,
+	}
+	if res.DefaultHashtags == nil {
+		res.DefaultHashtags = []string{}
+	}
+	if u.PendingDeletion() {
+		at := u.DeleteAfter.UTC().Format(time.RFC3339)
+		res.DeleteAfter = &at
+	}
+	return res
+}
+
+// profilePatch is the PATCH /me body; omitted fields are left unchanged.
+type profilePatch struct {
+	DisplayName       *string   `json:"display_name"`
+	JobTitle          *string   `json:"job_title"`
+	Industry          *string   `json:"industry"`
+	PreferredLanguage *string   `json:"preferred_language"`
+	DefaultStyle      *string   `json:"default_style"`
+	DefaultHashtags   *[]string `json:"default_hashtags"`
+	Signature         *string   `json:"signature"`
+	MaxLength         *int      `json:"max_length"`
+}
+
+func (h *AccountHandler) profile(w http.ResponseWriter, r *http.Request) {
+	u, err := h.svc.Profile(r.Context(), middleware.UserID(r.Context()))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to load profile")
+		return
+	}
+	respondJSON(w, http.StatusOK, newProfileResponse(u))
+}
+
+func (h *AccountHandler) updateProfile(w http.ResponseWriter, r *http.Request) {
+	var in profilePatch
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&in); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	u, err := h.svc.UpdateProfile(r.Context(), middleware.UserID(r.Context()), service.ProfileUpdate(in))
+	if errors.Is(err, service.ErrInvalidProfile) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update profile")
+		return
+	}
+	respondJSON(w, http.StatusOK, newProfileResponse(u))
+}
+
+func (h *AccountHandler) requestDeletion(w http.ResponseWriter, r *http.Request) {
+	u, err := h.svc.RequestDeletion(r.Context(), middleware.UserID(r.Context()))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to schedule account deletion")
+		return
+	}
+	respondJSON(w, http.StatusAccepted, map[string]interface{}{
+		"status":       "pending_deletion",
+		"requested_at": u.DeletionRequestedAt.UTC().Format(time.RFC3339),
+		"delete_after": u.DeleteAfter.UTC().Format(time.RFC3339),
+	})
+}
+
+func (h *AccountHandler) cancelDeletion(w http.ResponseWriter, r *http.Request) {
+	err := h.svc.CancelDeletion(r.Context(), middleware.UserID(r.Context()))
+	if errors.Is(err, service.ErrNoPendingDeletion) {
+		respondError(w, http.StatusConflict, "No account deletion is pending")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to cancel account deletion")
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]string{"status": "active"})
+}
+
+func (h *AccountHandler) exportArchive(w http.ResponseWriter, r *http.Request) {
+	uid := middleware.UserID(r.Context())
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="linkedinify-account.zip"`)
+	tw := &trackingWriter{ResponseWriter: w}
+	if err := h.svc.ExportArchive(r.Context(), uid, tw); err != nil {
+		logging.FromContext(r.Context()).Error("account export failed", "err", err)
+		// The archive is streamed; once bytes are out the status can't change.
+		if !tw.wrote {
+			w.Header().Del("Content-Disposition")
+			respondError(w, http.StatusInternalServerError, "Failed to export account data")
+		}
+	}
+}
+
+// storageUsage reports the bytes the user's files take and their quota,
+// where 0 means unlimited.
+func (h *AccountHandler) storageUsage(w http.ResponseWriter, r *http.Request) {
+	usage, err := h.svc.StorageUsage(r.Context(), middleware.UserID(r.Context()))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to read storage usage")
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]int64{"used_bytes": usage.Used, "quota_bytes": usage.Quota})
+}
+
+// trackingWriter records whether any body bytes have been written.
+type trackingWriter struct {
+	http.ResponseWriter
+	wrote bool
+}
+
+func (t *trackingWriter) Write(b []byte) (int, error) {
+	t.wrote = true
+	return t.ResponseWriter.Write(b)
+}
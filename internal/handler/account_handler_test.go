@@ -0,0 +1,174 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/handler"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/service"
+)
+
+func TestAccountHandler_RequestDeletion_Accepted(t *testing.T) {
+	testUserID := uuid.New()
+	testSecret := []byte("your-test-jwt-secret")
+	now := time.Now()
+	mockService := &service.AccountServiceInteractorMock{
+		RequestDeletionFunc: func(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+			assert.Equal(t, testUserID, userID)
+			return &model.User{ID: userID, DeletionRequestedAt: now, DeleteAfter: now.Add(time.Hour)}, nil
+		},
+	}
+	server := httptest.NewServer(handler.NewAccount(mockService).Routes(testSecret))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodDelete, server.URL+"/", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+generateTestToken(t, testUserID, testSecret))
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Len(t, mockService.RequestDeletionCalls(), 1)
+}
+
+func TestAccountHandler_CancelDeletion_NothingPending(t *testing.T) {
+	testUserID := uuid.New()
+	testSecret := []byte("your-test-jwt-secret")
+	mockService := &service.AccountServiceInteractorMock{
+		CancelDeletionFunc: func(ctx context.Context, userID uuid.UUID) error {
+			return service.ErrNoPendingDeletion
+		},
+	}
+	server := httptest.NewServer(handler.NewAccount(mockService).Routes(testSecret))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodDelete, server.URL+"/deletion", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+generateTestToken(t, testUserID, testSecret))
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestAccountHandler_ExportArchive_ErrorBeforeStreaming(t *testing.T) {
+	testUserID := uuid.New()
+	testSecret := []byte("your-test-jwt-secret")
+	mockService := &service.AccountServiceInteractorMock{
+		ExportArchiveFunc: func(ctx context.Context, userID uuid.UUID, w io.Writer) error {
+			return errors.New("user lookup failed")
+		},
+	}
+	server := httptest.NewServer(handler.NewAccount(mockService).Routes(testSecret))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/export", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+generateTestToken(t, testUserID, testSecret))
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.Empty(t, resp.Header.Get("Content-Disposition"))
+}
+
+func TestAccountHandler_UpdateProfile_ValidationError(t *testing.T) {
+	testUserID := uuid.New()
+	testSecret := []byte("your-test-jwt-secret")
+	mockService := &service.AccountServiceInteractorMock{
+		UpdateProfileFunc: func(ctx context.Context, userID uuid.UUID, upd service.ProfileUpdate) (*model.User, error) {
+			require.NotNil(t, upd.MaxLength)
+			assert.Equal(t, 9000, *upd.MaxLength)
+			assert.Nil(t, upd.DisplayName)
+			return nil, fmt.Errorf("%w: max_length must be between 0 and 3000", service.ErrInvalidProfile)
+		},
+	}
+	server := httptest.NewServer(handler.NewAccount(mockService).Routes(testSecret))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPatch, server.URL+"/", strings.NewReader(`{"max_length":9000}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+generateTestToken(t, testUserID, testSecret))
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	var body map[string]string
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Contains(t, body["error"], "max_length")
+}
+
+func TestAccountHandler_Profile(t *testing.T) {
+	testUserID := uuid.New()
+	testSecret := []byte("your-test-jwt-secret")
+	mockService := &service.AccountServiceInteractorMock{
+		ProfileFunc: func(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+			return &model.User{ID: userID, Email: "me@example.com", PasswordHash: "hash", DisplayName: "Ada"}, nil
+		},
+	}
+	server := httptest.NewServer(handler.NewAccount(mockService).Routes(testSecret))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+generateTestToken(t, testUserID, testSecret))
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "Ada", body["display_name"])
+	assert.Equal(t, []interface{}{}, body["default_hashtags"])
+	assert.NotContains(t, body, "password_hash")
+}
+
+func TestAccountHandler_StorageUsage(t *testing.T) {
+	testUserID := uuid.New()
+	testSecret := []byte("your-test-jwt-secret")
+	mockService := &service.AccountServiceInteractorMock{
+		StorageUsageFunc: func(ctx context.Context, userID uuid.UUID) (*service.StorageUsage, error) {
+			assert.Equal(t, testUserID, userID)
+			return &service.StorageUsage{Used: 1024, Quota: 4096}, nil
+		},
+	}
+	server := httptest.NewServer(handler.NewAccount(mockService).Routes(testSecret))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/storage", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+generateTestToken(t, testUserID, testSecret))
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var body map[string]int64
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, map[string]int64{"used_bytes": 1024, "quota_bytes": 4096}, body)
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/you/linkedinify/internal/ai"
+)
+
+// respondAIError answers classified AI provider failures and reports
+// whether it did. Other errors are left to the caller.
+func respondAIError(w http.ResponseWriter, err error) bool {
+	var code int
+	var message string
+	switch {
+	case errors.Is(err, ai.ErrRateLimited):
+		code, message = http.StatusTooManyRequests, "The AI provider is rate limiting requests, try again later"
+	case errors.Is(err, ai.ErrContentFiltered):
+		code, message = http.StatusUnprocessableEntity, "The AI provider refused this content"
+	case errors.Is(err, ai.ErrUnavailable):
+		code, message = http.StatusServiceUnavailable, "The AI provider is unavailable, try again later"
+	default:
+		return false
+	}
+	if wait := ai.RetryAfter(err); wait > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	}
+	respondError(w, code, message)
+	return true
+}
@@ -3,11 +3,11 @@ package handler
 
 import (
 	"encoding/json"
-	"log"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/you/linkedinify/internal/logging"
 	"github.com/you/linkedinify/internal/service"
 )
 
@@ -54,7 +54,7 @@ func (h *AuthHandler) register(w http.ResponseWriter, r *http.Request) {
 	}
 	token, err := h.svc.Register(r.Context(), c.Email, c.Password)
 	if err != nil {
-		log.Printf("Registration error: %v", err)
+		logging.FromContext(r.Context()).Error("registration failed", "email", c.Email, "err", err)
 		http.Error(w, "registration failed", http.StatusInternalServerError)
 		return
 	}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/you/linkedinify/internal/logging"
+	"github.com/you/linkedinify/internal/storage"
+)
+
+// BlobHandler serves blobs to holders of a signed link, without a login,
+// so that links can be put in <img> tags and shared for a while.
+type BlobHandler struct {
+	store  storage.BlobStore
+	signer *storage.URLSigner
+}
+
+func NewBlob(store storage.BlobStore, signer *storage.URLSigner) *BlobHandler {
+	return &BlobHandler{store: store, signer: signer}
+}
+
+func (h *BlobHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+	r.Get("/*", h.download)
+	return r
+}
+
+// download serves the blob the link was signed for. Blobs are served with
+// their detected type, never sniffed by the browser, and sandboxed so that
+// an SVG or HTML blob cannot run scripts on the API's origin.
+func (h *BlobHandler) download(w http.ResponseWriter, r *http.Request) {
+	key := chi.URLParam(r, "*")
+	now := time.Now()
+	if err := h.signer.Verify(key, r.URL.Query(), now); err != nil {
+		respondError(w, http.StatusForbidden, "Invalid or expired link")
+		return
+	}
+	rc, blob, err := h.store.Open(r.Context(), key)
+	if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrInvalidKey) {
+		respondError(w, http.StatusNotFound, "File not found")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to read file")
+		return
+	}
+	defer rc.Close()
+
+	w.Header().Set("Content-Type", blob.ContentType)
+	if blob.Size >= 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(blob.Size, 10))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="%s"`, path.Base(key)))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; sandbox")
+	// Verify has checked expires; caches may keep the blob until then.
+	expires, _ := strconv.ParseInt(r.URL.Query().Get("expires"), 10, 64)
+	w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", max(0, expires-now.Unix())))
+	if _, err := io.Copy(w, rc); err != nil {
+		logging.FromContext(r.Context()).Error("file download aborted", "key", key, "err", err)
+	}
+}
@@ -0,0 +1,61 @@
+package handler_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/handler"
+	"github.com/you/linkedinify/internal/storage"
+)
+
+func TestBlobHandler_Download(t *testing.T) {
+	store, err := storage.NewLocal(t.TempDir())
+	require.NoError(t, err)
+	_, err = store.Put(context.Background(), "u1/images/a.svg", strings.NewReader(`<svg xmlns="http://www.w3.org/2000/svg"/>`), "")
+	require.NoError(t, err)
+	signer := storage.NewURLSigner([]byte("secret"), "", time.Minute)
+	server := httptest.NewServer(handler.NewBlob(store, signer).Routes())
+	defer server.Close()
+
+	link, _ := signer.URL("u1/images/a.svg", time.Now())
+	resp, err := http.Get(server.URL + link)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "image/svg+xml", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "nosniff", resp.Header.Get("X-Content-Type-Options"))
+	assert.Contains(t, resp.Header.Get("Content-Security-Policy"), "sandbox")
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, `<svg xmlns="http://www.w3.org/2000/svg"/>`, string(body))
+}
+
+func TestBlobHandler_RejectsBadLinks(t *testing.T) {
+	store, err := storage.NewLocal(t.TempDir())
+	require.NoError(t, err)
+	signer := storage.NewURLSigner([]byte("secret"), "", time.Minute)
+	server := httptest.NewServer(handler.NewBlob(store, signer).Routes())
+	defer server.Close()
+
+	expired, _ := signer.URL("u1/images/a.svg", time.Now().Add(-time.Hour))
+	missing, _ := signer.URL("u1/images/missing.svg", time.Now())
+	for link, want := range map[string]int{
+		"/u1/images/a.svg":                        http.StatusForbidden,
+		"/u1/images/a.svg?expires=1&signature=00": http.StatusForbidden,
+		expired: http.StatusForbidden,
+		missing: http.StatusNotFound,
+	} {
+		resp, err := http.Get(server.URL + link)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, want, resp.StatusCode, link)
+	}
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/flags"
+	"github.com/you/linkedinify/internal/middleware"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/service"
+)
+
+type FlagHandler struct {
+	svc service.FlagServiceInteractor
+}
+
+func NewFlag(svc service.FlagServiceInteractor) *FlagHandler {
+	return &FlagHandler{svc: svc}
+}
+
+// Routes serves the admin API for feature flags, guarded by the admin token.
+func (h *FlagHandler) Routes(adminToken string) chi.Router {
+	r := chi.NewRouter()
+	r.Use(middleware.AdminToken(adminToken))
+	r.Get("/", h.list)
+	r.Get("/{key}", h.get)
+	r.Put("/{key}", h.put)
+	r.Delete("/{key}", h.delete)
+	r.Get("/{key}/evaluate", h.evaluate)
+	return r
+}
+
+type flagBody struct {
+	Description    string           `json:"description"`
+	Enabled        bool             `json:"enabled"`
+	Variants       []string         `json:"variants"`
+	DefaultVariant string           `json:"default_variant"`
+	Rules          []model.FlagRule `json:"rules"`
+}
+
+type flagResponse struct {
+	Key string `json:"key"`
+	flagBody
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func newFlagResponse(f *model.FeatureFlag) flagResponse {
+	return flagResponse{
+		Key: f.Key,
+		flagBody: flagBody{
+			Description:    f.Description,
+			Enabled:        f.Enabled,
+			Variants:       f.Variants,
+			DefaultVariant: f.DefaultVariant,
+			Rules:          f.Rules,
+		},
+		UpdatedAt: f.UpdatedAt,
+	}
+}
+
+func (h *FlagHandler) list(w http.ResponseWriter, r *http.Request) {
+	all, err := h.svc.List(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list feature flags")
+		return
+	}
+	res := make([]flagResponse, 0, len(all))
+	for i := range all {
+		res = append(res, newFlagResponse(&all[i]))
+	}
+	respondJSON(w, http.StatusOK, res)
+}
+
+func (h *FlagHandler) get(w http.ResponseWriter, r *http.Request) {
+	f, err := h.svc.Get(r.Context(), chi.URLParam(r, "key"))
+	if errors.Is(err, service.ErrFlagNotFound) {
+		respondError(w, http.StatusNotFound, "Feature flag not found")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to load feature flag")
+		return
+	}
+	respondJSON(w, http.StatusOK, newFlagResponse(f))
+}
+
+func (h *FlagHandler) put(w http.ResponseWriter, r *http.Request) {
+	var in flagBody
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&in); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	f, err := h.svc.Put(r.Context(), &model.FeatureFlag{
+		Key:            chi.URLParam(r, "key"),
+		Description:    in.Description,
+		Enabled:        in.Enabled,
+		Variants:       in.Variants,
+		DefaultVariant: in.DefaultVariant,
+		Rules:          in.Rules,
+	})
+	if errors.Is(err, service.ErrInvalidFlag) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to save feature flag")
+		return
+	}
+	respondJSON(w, http.StatusOK, newFlagResponse(f))
+}
+
+func (h *FlagHandler) delete(w http.ResponseWriter, r *http.Request) {
+	err := h.svc.Delete(r.Context(), chi.URLParam(r, "key"))
+	if errors.Is(err, service.ErrFlagNotFound) {
+		respondError(w, http.StatusNotFound, "Feature flag not found")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to delete feature flag")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// evaluate shows which variant a user would get, for checking rules
+// before rolling them out.
+func (h *FlagHandler) evaluate(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.URL.Query().Get("user_id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "The 'user_id' query parameter must be a UUID")
+		return
+	}
+	f, err := h.svc.Get(r.Context(), chi.URLParam(r, "key"))
+	if errors.Is(err, service.ErrFlagNotFound) {
+		respondError(w, http.StatusNotFound, "Feature flag not found")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to load feature flag")
+		return
+	}
+	subject := flags.Subject{UserID: userID, Organization: r.URL.Query().Get("organization")}
+	respondJSON(w, http.StatusOK, map[string]string{"key": f.Key, "variant": flags.Evaluate(f, subject)})
+}
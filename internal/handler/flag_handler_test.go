@@ -0,0 +1,92 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/handler"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/service"
+)
+
+func TestFlagHandler_Put(t *testing.T) {
+	mockService := &service.FlagServiceInteractorMock{
+		PutFunc: func(ctx context.Context, f *model.FeatureFlag) (*model.FeatureFlag, error) {
+			assert.Equal(t, "post-style", f.Key)
+			assert.Equal(t, []string{"default", "witty"}, f.Variants)
+			require.Len(t, f.Rules, 1)
+			assert.Equal(t, []string{"acme.com"}, f.Rules[0].Organizations)
+			return f, nil
+		},
+	}
+	server := httptest.NewServer(handler.NewFlag(mockService).Routes(testAdminToken))
+	defer server.Close()
+
+	resp := adminRequest(t, server, http.MethodPut, "/post-style",
+		`{"enabled":true,"variants":["default","witty"],"default_variant":"default","rules":[{"organizations":["acme.com"],"percentage":25,"variant":"witty"}]}`)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var res map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+	assert.Equal(t, "post-style", res["key"])
+	assert.Equal(t, true, res["enabled"])
+}
+
+func TestFlagHandler_Put_Invalid(t *testing.T) {
+	mockService := &service.FlagServiceInteractorMock{
+		PutFunc: func(ctx context.Context, f *model.FeatureFlag) (*model.FeatureFlag, error) {
+			return nil, fmt.Errorf("%w: bad", service.ErrInvalidFlag)
+		},
+	}
+	server := httptest.NewServer(handler.NewFlag(mockService).Routes(testAdminToken))
+	defer server.Close()
+
+	resp := adminRequest(t, server, http.MethodPut, "/x", `{}`)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestFlagHandler_Evaluate(t *testing.T) {
+	userID := uuid.New()
+	mockService := &service.FlagServiceInteractorMock{
+		GetFunc: func(ctx context.Context, key string) (*model.FeatureFlag, error) {
+			return &model.FeatureFlag{
+				Key: key, Enabled: true, Variants: []string{"off", "on"}, DefaultVariant: "off",
+				Rules: []model.FlagRule{{Organizations: []string{"acme.com"}, Percentage: 100, Variant: "on"}},
+			}, nil
+		},
+	}
+	server := httptest.NewServer(handler.NewFlag(mockService).Routes(testAdminToken))
+	defer server.Close()
+
+	resp := adminRequest(t, server, http.MethodGet, "/beta/evaluate?user_id="+userID.String()+"&organization=acme.com", "")
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var res map[string]string
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+	assert.Equal(t, "on", res["variant"])
+}
+
+func TestFlagHandler_Get_NotFound(t *testing.T) {
+	mockService := &service.FlagServiceInteractorMock{
+		GetFunc: func(ctx context.Context, key string) (*model.FeatureFlag, error) {
+			return nil, service.ErrFlagNotFound
+		},
+	}
+	server := httptest.NewServer(handler.NewFlag(mockService).Routes(testAdminToken))
+	defer server.Close()
+
+	resp := adminRequest(t, server, http.MethodGet, "/missing", "")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
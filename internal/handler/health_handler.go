@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/you/linkedinify/internal/service"
+)
+
+type HealthHandler struct {
+	svc service.HealthServiceInteractor
+}
+
+func NewHealth(svc service.HealthServiceInteractor) *HealthHandler {
+	return &HealthHandler{svc: svc}
+}
+
+type dependencyStatus struct {
+	Status    string `json:"status"`
+	Critical  bool   `json:"critical"`
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+type healthResponse struct {
+	Status string                      `json:"status"`
+	Checks map[string]dependencyStatus `json:"checks,omitempty"`
+}
+
+// Live reports that the process is up. It checks no dependencies so that an
+// outage of the database does not get the process restarted.
+func (h *HealthHandler) Live(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, healthResponse{Status: service.HealthOK})
+}
+
+// Ready reports per-dependency status and answers 503 when a critical
+// dependency is down, so the orchestrator stops routing traffic here.
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	report := h.svc.Readiness(r.Context())
+	res := healthResponse{Status: report.Status, Checks: make(map[string]dependencyStatus, len(report.Dependencies))}
+	for _, d := range report.Dependencies {
+		s := dependencyStatus{Status: service.HealthOK, Critical: d.Critical, LatencyMS: d.Latency.Milliseconds()}
+		if !d.Healthy {
+			s.Status = service.HealthUnavailable
+			s.Error = d.Err.Error()
+		}
+		res.Checks[d.Name] = s
+	}
+
+	status := http.StatusOK
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	respondJSON(w, status, res)
+}
@@ -0,0 +1,70 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/handler"
+	"github.com/you/linkedinify/internal/service"
+)
+
+func TestHealthHandler_Live(t *testing.T) {
+	h := handler.NewHealth(&service.HealthServiceInteractorMock{})
+	rr := httptest.NewRecorder()
+	h.Live(rr, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rr.Body.String())
+}
+
+func TestHealthHandler_Ready_CriticalFailureIs503(t *testing.T) {
+	mockService := &service.HealthServiceInteractorMock{
+		ReadinessFunc: func(ctx context.Context) service.HealthReport {
+			return service.HealthReport{Status: service.HealthUnavailable, Dependencies: []service.DependencyHealth{
+				{Name: "postgres", Critical: true, Err: errors.New("connection refused"), Latency: 3 * time.Millisecond},
+				{Name: "schema", Critical: true, Healthy: true},
+			}}
+		},
+	}
+	rr := httptest.NewRecorder()
+	handler.NewHealth(mockService).Ready(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	var res struct {
+		Status string `json:"status"`
+		Checks map[string]struct {
+			Status    string `json:"status"`
+			LatencyMS int64  `json:"latency_ms"`
+			Error     string `json:"error"`
+		} `json:"checks"`
+	}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.Equal(t, "unavailable", res.Status)
+	assert.Equal(t, "unavailable", res.Checks["postgres"].Status)
+	assert.Equal(t, "connection refused", res.Checks["postgres"].Error)
+	assert.Equal(t, int64(3), res.Checks["postgres"].LatencyMS)
+	assert.Equal(t, "ok", res.Checks["schema"].Status)
+}
+
+func TestHealthHandler_Ready_DegradedStillServes(t *testing.T) {
+	mockService := &service.HealthServiceInteractorMock{
+		ReadinessFunc: func(ctx context.Context) service.HealthReport {
+			return service.HealthReport{Status: service.HealthDegraded, Dependencies: []service.DependencyHealth{
+				{Name: "ai", Err: errors.New("timeout")},
+			}}
+		},
+	}
+	rr := httptest.NewRecorder()
+	handler.NewHealth(mockService).Ready(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"status":"degraded"`)
+}
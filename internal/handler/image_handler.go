@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/logging"
+	"github.com/you/linkedinify/internal/middleware"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/service"
+	"github.com/you/linkedinify/internal/storage"
+)
+
+// WithImages adds the image and carousel endpoints of posts and lets
+// transforms ask for a generated image.
+func WithImages(svc service.ImageServiceInteractor) LinkedInOption {
+	return func(h *LinkedInHandler) { h.images = svc }
+}
+
+// WithBlobURLs adds signed download links, served by BlobHandler, to
+// listed images.
+func WithBlobURLs(s *storage.URLSigner) LinkedInOption {
+	return func(h *LinkedInHandler) { h.blobURLs = s }
+}
+
+// imageRoutes mounts the image endpoints. Generating an image costs as
+// much as a transform, so transformMiddleware applies to it too.
+func (h *LinkedInHandler) imageRoutes(r chi.Router, transformMiddleware ...func(http.Handler) http.Handler) {
+	r.Get("/{id}/images", h.listImages)
+	r.Post("/{id}/images", h.attachImage)
+	r.With(transformMiddleware...).Post("/{id}/images/generate", h.generateImage)
+	r.Get("/{id}/images/{imageID}", h.downloadImage)
+	r.Get("/{id}/carousel", h.carousel)
+}
+
+// imageItem describes an image. URL is a link that works without logging
+// in until URLExpiresAt, for <img> tags.
+type imageItem struct {
+	ID           uuid.UUID  `json:"id"`
+	ContentType  string     `json:"content_type"`
+	Size         int64      `json:"size"`
+	Source       string     `json:"source"`
+	CreatedAt    time.Time  `json:"created_at"`
+	URL          string     `json:"url,omitempty"`
+	URLExpiresAt *time.Time `json:"url_expires_at,omitempty"`
+}
+
+func (h *LinkedInHandler) newImageItem(img *model.PostImage) imageItem {
+	item := imageItem{ID: img.ID, ContentType: img.ContentType, Size: img.Size, Source: img.Source, CreatedAt: img.CreatedAt}
+	if h.blobURLs != nil {
+		url, expires := h.blobURLs.URL(img.BlobKey, time.Now())
+		item.URL, item.URLExpiresAt = url, &expires
+	}
+	return item
+}
+
+// postID parses the {id} URL parameter and answers 404 when it is not a
+// post ID.
+func postID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Post not found")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (h *LinkedInHandler) listImages(w http.ResponseWriter, r *http.Request) {
+	id, ok := postID(w, r)
+	if !ok {
+		return
+	}
+	images, err := h.images.List(r.Context(), middleware.UserID(r.Context()), id)
+	if errors.Is(err, service.ErrPostNotFound) {
+		respondError(w, http.StatusNotFound, "Post not found")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list images")
+		return
+	}
+	res := make([]imageItem, 0, len(images))
+	for i := range images {
+		res = append(res, h.newImageItem(&images[i]))
+	}
+	respondJSON(w, http.StatusOK, res)
+}
+
+// attachImage stores the request body, a PNG, JPEG, GIF or WebP image, as
+// an image of the post.
+func (h *LinkedInHandler) attachImage(w http.ResponseWriter, r *http.Request) {
+	id, ok := postID(w, r)
+	if !ok {
+		return
+	}
+	img, err := h.images.Attach(r.Context(), middleware.UserID(r.Context()), id, r.Body)
+	switch {
+	case errors.Is(err, service.ErrPostNotFound):
+		respondError(w, http.StatusNotFound, "Post not found")
+	case errors.Is(err, service.ErrInvalidImage):
+		respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, storage.ErrQuotaExceeded):
+		respondError(w, http.StatusRequestEntityTooLarge, "Storage quota exceeded")
+	case err != nil:
+		respondError(w, http.StatusInternalServerError, "Failed to store image")
+	default:
+		respondJSON(w, http.StatusCreated, h.newImageItem(img))
+	}
+}
+
+func (h *LinkedInHandler) generateImage(w http.ResponseWriter, r *http.Request) {
+	id, ok := postID(w, r)
+	if !ok {
+		return
+	}
+	img, err := h.images.Generate(r.Context(), middleware.UserID(r.Context()), id)
+	if errors.Is(err, service.ErrPostNotFound) {
+		respondError(w, http.StatusNotFound, "Post not found")
+		return
+	}
+	if errors.Is(err, storage.ErrQuotaExceeded) {
+		respondError(w, http.StatusRequestEntityTooLarge, "Storage quota exceeded")
+		return
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Error("image generation failed", "err", err)
+		respondError(w, http.StatusBadGateway, "Failed to generate image")
+		return
+	}
+	respondJSON(w, http.StatusCreated, h.newImageItem(img))
+}
+
+// downloadImage serves an image. Generated SVGs are served with a policy
+// that keeps them from running scripts when opened directly.
+func (h *LinkedInHandler) downloadImage(w http.ResponseWriter, r *http.Request) {
+	id, ok := postID(w, r)
+	if !ok {
+		return
+	}
+	imageID, err := uuid.Parse(chi.URLParam(r, "imageID"))
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Image not found")
+		return
+	}
+	img, rc, err := h.images.Open(r.Context(), middleware.UserID(r.Context()), id, imageID)
+	if errors.Is(err, service.ErrImageNotFound) {
+		respondError(w, http.StatusNotFound, "Image not found")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to read image")
+		return
+	}
+	defer rc.Close()
+
+	w.Header().Set("Content-Type", img.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(img.Size, 10))
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="%s%s"`, img.ID, path.Ext(img.BlobKey)))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if img.ContentType == "image/svg+xml" {
+		w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; sandbox")
+	}
+	if _, err := io.Copy(w, rc); err != nil {
+		logging.FromContext(r.Context()).Error("image download aborted", "image_id", img.ID, "err", err)
+	}
+}
+
+func (h *LinkedInHandler) carousel(w http.ResponseWriter, r *http.Request) {
+	id, ok := postID(w, r)
+	if !ok {
+		return
+	}
+	pdf, err := h.images.Carousel(r.Context(), middleware.UserID(r.Context()), id)
+	switch {
+	case errors.Is(err, service.ErrPostNotFound):
+		respondError(w, http.StatusNotFound, "Post not found")
+		return
+	case errors.Is(err, service.ErrInvalidImage):
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		respondError(w, http.StatusInternalServerError, "Failed to render carousel")
+		return
+	}
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", `attachment; filename="linkedinify-carousel.pdf"`)
+	w.Header().Set("Content-Length", strconv.Itoa(len(pdf)))
+	if _, err := w.Write(pdf); err != nil {
+		logging.FromContext(r.Context()).Error("carousel download aborted", "post_id", id, "err", err)
+	}
+}
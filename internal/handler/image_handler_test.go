@@ -0,0 +1,260 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/handler"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/service"
+	"github.com/you/linkedinify/internal/storage"
+)
+
+// imageServer serves the post routes with images and returns an
+// authenticated request maker.
+func imageServer(t *testing.T, posts service.LinkedInServiceInteractor, images service.ImageServiceInteractor, userID uuid.UUID) func(method, path string, body io.Reader) *http.Response {
+	t.Helper()
+	secret := []byte("your-test-jwt-secret")
+	server := httptest.NewServer(handler.NewLinkedIn(posts, handler.WithImages(images)).Routes(secret))
+	t.Cleanup(server.Close)
+	token := generateTestToken(t, userID, secret)
+	return func(method, path string, body io.Reader) *http.Response {
+		req, err := http.NewRequest(method, server.URL+path, body)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+}
+
+func TestImageHandler_Attach(t *testing.T) {
+	userID, postID := uuid.New(), uuid.New()
+	images := &service.ImageServiceInteractorMock{
+		AttachFunc: func(ctx context.Context, uid, pid uuid.UUID, r io.Reader) (*model.PostImage, error) {
+			assert.Equal(t, userID, uid)
+			assert.Equal(t, postID, pid)
+			data, err := io.ReadAll(r)
+			require.NoError(t, err)
+			if string(data) != "png bytes" {
+				return nil, fmt.Errorf("%w: only PNG, JPEG, GIF and WebP images are accepted", service.ErrInvalidImage)
+			}
+			return &model.PostImage{ID: uuid.New(), ContentType: "image/png", Size: 9, Source: model.ImageSourceUpload}, nil
+		},
+	}
+	do := imageServer(t, &service.LinkedInServiceInteractorMock{}, images, userID)
+
+	resp := do(http.MethodPost, "/"+postID.String()+"/images", strings.NewReader("png bytes"))
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	var item map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&item))
+	assert.Equal(t, "image/png", item["content_type"])
+	assert.Equal(t, "upload", item["source"])
+
+	resp = do(http.MethodPost, "/"+postID.String()+"/images", strings.NewReader("<svg/>"))
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestImageHandler_NotFound(t *testing.T) {
+	userID := uuid.New()
+	images := &service.ImageServiceInteractorMock{
+		ListFunc: func(ctx context.Context, uid, pid uuid.UUID) ([]model.PostImage, error) {
+			return nil, service.ErrPostNotFound
+		},
+		GenerateFunc: func(ctx context.Context, uid, pid uuid.UUID) (*model.PostImage, error) {
+			return nil, service.ErrPostNotFound
+		},
+		CarouselFunc: func(ctx context.Context, uid, pid uuid.UUID) ([]byte, error) {
+			return nil, service.ErrPostNotFound
+		},
+		OpenFunc: func(ctx context.Context, uid, pid, id uuid.UUID) (*model.PostImage, io.ReadCloser, error) {
+			return nil, nil, service.ErrImageNotFound
+		},
+	}
+	do := imageServer(t, &service.LinkedInServiceInteractorMock{}, images, userID)
+	id := uuid.New().String()
+
+	for _, tc := range []struct{ method, path string }{
+		{http.MethodGet, "/not-a-uuid/images"},
+		{http.MethodGet, "/" + id + "/images"},
+		{http.MethodPost, "/" + id + "/images/generate"},
+		{http.MethodGet, "/" + id + "/carousel"},
+		{http.MethodGet, "/" + id + "/images/" + uuid.New().String()},
+		{http.MethodGet, "/" + id + "/images/nope"},
+	} {
+		resp := do(tc.method, tc.path, nil)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, tc.path)
+	}
+}
+
+func TestImageHandler_Generate_Failure(t *testing.T) {
+	images := &service.ImageServiceInteractorMock{
+		GenerateFunc: func(ctx context.Context, uid, pid uuid.UUID) (*model.PostImage, error) {
+			return nil, errors.New("upstream timeout")
+		},
+	}
+	do := imageServer(t, &service.LinkedInServiceInteractorMock{}, images, uuid.New())
+
+	resp := do(http.MethodPost, "/"+uuid.New().String()+"/images/generate", nil)
+
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+}
+
+func TestImageHandler_Generate_TransformMiddleware(t *testing.T) {
+	userID := uuid.New()
+	secret := []byte("your-test-jwt-secret")
+	images := &service.ImageServiceInteractorMock{
+		ListFunc: func(ctx context.Context, uid, pid uuid.UUID) ([]model.PostImage, error) { return nil, nil },
+	}
+	limited := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+	}
+	server := httptest.NewServer(handler.NewLinkedIn(&service.LinkedInServiceInteractorMock{}, handler.WithImages(images)).Routes(secret, limited))
+	defer server.Close()
+
+	for path, want := range map[string]int{
+		"/" + uuid.New().String() + "/images/generate": http.StatusTooManyRequests,
+		"/" + uuid.New().String() + "/images":          http.StatusOK,
+	} {
+		method := http.MethodGet
+		if strings.HasSuffix(path, "/generate") {
+			method = http.MethodPost
+		}
+		req, err := http.NewRequest(method, server.URL+path, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+generateTestToken(t, userID, secret))
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, want, resp.StatusCode, path)
+	}
+	assert.Empty(t, images.GenerateCalls())
+}
+
+func TestImageHandler_Download(t *testing.T) {
+	imageID := uuid.New()
+	images := &service.ImageServiceInteractorMock{
+		OpenFunc: func(ctx context.Context, uid, pid, id uuid.UUID) (*model.PostImage, io.ReadCloser, error) {
+			svg := "<svg></svg>"
+			img := &model.PostImage{ID: id, ContentType: "image/svg+xml", Size: int64(len(svg)), BlobKey: "u/images/" + id.String() + ".svg"}
+			return img, io.NopCloser(strings.NewReader(svg)), nil
+		},
+	}
+	do := imageServer(t, &service.LinkedInServiceInteractorMock{}, images, uuid.New())
+
+	resp := do(http.MethodGet, "/"+uuid.New().String()+"/images/"+imageID.String(), nil)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "image/svg+xml", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "nosniff", resp.Header.Get("X-Content-Type-Options"))
+	assert.Contains(t, resp.Header.Get("Content-Security-Policy"), "sandbox")
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), imageID.String()+".svg")
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "<svg></svg>", string(body))
+}
+
+func TestImageHandler_Carousel(t *testing.T) {
+	images := &service.ImageServiceInteractorMock{
+		CarouselFunc: func(ctx context.Context, uid, pid uuid.UUID) ([]byte, error) {
+			return []byte("%PDF-1.4\n"), nil
+		},
+	}
+	do := imageServer(t, &service.LinkedInServiceInteractorMock{}, images, uuid.New())
+
+	resp := do(http.MethodGet, "/"+uuid.New().String()+"/carousel", nil)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/pdf", resp.Header.Get("Content-Type"))
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "linkedinify-carousel.pdf")
+}
+
+func TestLinkedInHandler_Transform_GeneratesImage(t *testing.T) {
+	userID, postID := uuid.New(), uuid.New()
+	posts := &service.LinkedInServiceInteractorMock{
+		TransformFunc: func(ctx context.Context, uid uuid.UUID, text string, opts service.TransformOptions) (*model.LinkedInPost, error) {
+			return &model.LinkedInPost{ID: postID, UserID: uid, OutputText: "post"}, nil
+		},
+	}
+	fail := false
+	images := &service.ImageServiceInteractorMock{
+		GenerateFunc: func(ctx context.Context, uid, pid uuid.UUID) (*model.PostImage, error) {
+			assert.Equal(t, postID, pid)
+			if fail {
+				return nil, errors.New("generator down")
+			}
+			return &model.PostImage{ID: uuid.New(), PostID: pid, ContentType: "image/svg+xml", Source: "placeholder"}, nil
+		},
+	}
+	do := imageServer(t, posts, images, userID)
+	body := `{"text":"some input text","generate_image":true}`
+
+	var res struct {
+		ID     uuid.UUID                `json:"id"`
+		Images []map[string]interface{} `json:"images"`
+	}
+	resp := do(http.MethodPost, "/", bytes.NewBufferString(body))
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+	assert.Equal(t, postID, res.ID)
+	require.Len(t, res.Images, 1)
+	assert.Equal(t, "placeholder", res.Images[0]["source"])
+
+	fail = true
+	res.Images = nil
+	resp = do(http.MethodPost, "/", bytes.NewBufferString(body))
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "a failed image does not fail the transform")
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+	assert.Empty(t, res.Images)
+	assert.Len(t, images.GenerateCalls(), 2)
+}
+
+func TestImageHandler_List_SignedURLs(t *testing.T) {
+	userID, postID := uuid.New(), uuid.New()
+	key := userID.String() + "/images/a.svg"
+	images := &service.ImageServiceInteractorMock{
+		ListFunc: func(ctx context.Context, uid, pid uuid.UUID) ([]model.PostImage, error) {
+			return []model.PostImage{{ID: uuid.New(), PostID: pid, UserID: uid, BlobKey: key, ContentType: "image/svg+xml"}}, nil
+		},
+	}
+	secret := []byte("your-test-jwt-secret")
+	signer := storage.NewURLSigner([]byte("blob-secret"), "/api/v1/blobs", time.Minute)
+	server := httptest.NewServer(handler.NewLinkedIn(&service.LinkedInServiceInteractorMock{}, handler.WithImages(images), handler.WithBlobURLs(signer)).Routes(secret))
+	defer server.Close()
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/"+postID.String()+"/images", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+generateTestToken(t, userID, secret))
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var items []struct {
+		URL          string    `json:"url"`
+		URLExpiresAt time.Time `json:"url_expires_at"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&items))
+	require.Len(t, items, 1)
+	u, err := url.Parse(items[0].URL)
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/blobs/"+key, u.Path)
+	assert.NoError(t, signer.Verify(key, u.Query(), time.Now()))
+	assert.WithinDuration(t, time.Now().Add(time.Minute), items[0].URLExpiresAt, 2*time.Second)
+}
@@ -3,60 +3,181 @@ package handler
 
 import (
 	"encoding/json"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
-
 	"strconv"
-
-	"github.com/microcosm-cc/bluemonday"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 
+	"github.com/you/linkedinify/internal/logging"
 	"github.com/you/linkedinify/internal/middleware"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/moderation"
+	"github.com/you/linkedinify/internal/normalize"
+	"github.com/you/linkedinify/internal/postformat"
+	"github.com/you/linkedinify/internal/repository"
 	"github.com/you/linkedinify/internal/service"
+	"github.com/you/linkedinify/internal/storage"
 )
 
 type LinkedInHandler struct {
-	svc service.LinkedInServiceInteractor
+	svc        service.LinkedInServiceInteractor
+	normalizer *normalize.Normalizer
+	images     service.ImageServiceInteractor
+	blobURLs   *storage.URLSigner
 }
 
-func NewLinkedIn(svc service.LinkedInServiceInteractor) *LinkedInHandler {
-	return &LinkedInHandler{svc: svc}
+// LinkedInOption configures the LinkedIn handler.
+type LinkedInOption func(*LinkedInHandler)
+
+// WithNormalizer prepares transform input with n instead of a normalizer
+// with the default maximum length.
+func WithNormalizer(n *normalize.Normalizer) LinkedInOption {
+	return func(h *LinkedInHandler) { h.normalizer = n }
 }
 
-func (h *LinkedInHandler) Routes(secret []byte) chi.Router {
+func NewLinkedIn(svc service.LinkedInServiceInteractor, opts ...LinkedInOption) *LinkedInHandler {
+	h := &LinkedInHandler{svc: svc, normalizer: normalize.New(normalize.DefaultMaxLength)}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Routes mounts the post endpoints. transformMiddleware, such as rate
+// limiting, runs after authentication and only on transforms and image
+// generation.
+func (h *LinkedInHandler) Routes(secret []byte, transformMiddleware ...func(http.Handler) http.Handler) chi.Router {
 	r := chi.NewRouter()
 	r.Use(middleware.Auth(secret))
-	r.Post("/", h.transform)
+	r.With(transformMiddleware...).Post("/", h.transform)
 	r.Get("/history", h.history)
+	r.Get("/export", h.export)
+	r.Post("/import", h.importPosts)
+	r.Post("/{id}/translations", h.translate)
+	if h.images != nil {
+		h.imageRoutes(r, transformMiddleware...)
+	}
 	return r
 }
 
 type reqBody struct {
-	Text string `json:"text"`
+	Text           string `json:"text"`
+	VoiceProfileID string `json:"voice_profile_id"`
+	Language       string `json:"language"`
+	// SuggestHashtags adds suggested hashtags to the profile's defaults.
+	SuggestHashtags bool `json:"suggest_hashtags"`
+	// GenerateImage attaches a generated image to the new post.
+	GenerateImage bool `json:"generate_image"`
+}
+
+// transformResponse carries the post, its quality score, any generated
+// images and what normalization changed in the input, so clients can show
+// why it differs from what was typed.
+type transformResponse struct {
+	ID           uuid.UUID          `json:"id"`
+	Post         string             `json:"post"`
+	Quality      *model.PostQuality `json:"quality,omitempty"`
+	Images       []imageItem        `json:"images,omitempty"`
+	InputChanges []normalize.Change `json:"input_changes,omitempty"`
+}
+
+// bodyLimit caps the JSON request bodies of post endpoints: room for the
+// longest input the normalizer accepts, even with every character escaped
+// as \uXXXX, plus the other fields.
+func (h *LinkedInHandler) bodyLimit() int64 {
+	return 6*int64(h.normalizer.MaxLength()) + 4<<10
+}
+
+// decodeBody decodes the JSON request body into v. It answers 413 for a
+// body over limit bytes and 400 for invalid JSON, and reports whether v
+// was decoded.
+func decodeBody(w http.ResponseWriter, r *http.Request, limit int64, v any) bool {
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, limit)).Decode(v)
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		respondError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("Request body must be at most %d bytes", tooLarge.Limit))
+		return false
+	case err != nil:
+		respondError(w, http.StatusBadRequest, "Invalid request payload")
+		return false
+	}
+	return true
 }
 
 func (h *LinkedInHandler) transform(w http.ResponseWriter, r *http.Request) {
 	var in reqBody
-	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request payload")
+	if !decodeBody(w, r, h.bodyLimit(), &in) {
 		return
 	}
 	if in.Text == "" {
 		respondError(w, http.StatusBadRequest, "The 'text' field is required")
 		return
 	}
+	if in.GenerateImage && h.images == nil {
+		respondError(w, http.StatusBadRequest, "Image generation is not enabled")
+		return
+	}
+
+	opts := service.TransformOptions{Language: in.Language, SuggestHashtags: in.SuggestHashtags}
+	if in.VoiceProfileID != "" {
+		id, err := uuid.Parse(in.VoiceProfileID)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "The 'voice_profile_id' field must be a UUID")
+			return
+		}
+		opts.VoiceProfileID = id
+	}
+	format, ok := postFormat(w, r)
+	if !ok {
+		return
+	}
+
+	text, changes, err := h.normalizer.Normalize(in.Text)
+	var tooLong *normalize.TooLongError
+	if errors.As(err, &tooLong) {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("The 'text' field must be at most %d characters", tooLong.Max))
+		return
+	}
+	if text == "" {
+		respondError(w, http.StatusBadRequest, "The 'text' field has no content once normalized")
+		return
+	}
 
-	p := bluemonday.StrictPolicy()
-	sanitizedText := p.Sanitize(in.Text)
 	uid := middleware.UserID(r.Context())
-	out, err := h.svc.Transform(r.Context(), uid, sanitizedText)
+	out, err := h.svc.Transform(r.Context(), uid, text, opts)
+	if errors.Is(err, service.ErrVoiceProfileNotFound) {
+		respondError(w, http.StatusBadRequest, "Unknown voice profile")
+		return
+	}
+	if errors.Is(err, service.ErrInvalidLanguage) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if respondRejection(w, err) || respondAIError(w, err) {
+		return
+	}
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to transform text")
 		return
 	}
-	respondJSON(w, http.StatusCreated, map[string]string{"post": out})
+	res := transformResponse{ID: out.ID, Post: renderPost(out.OutputText, format), Quality: out.Quality, InputChanges: changes}
+	// The post is saved by now; an image is a nicety that can be generated
+	// again later, so failing to make one does not fail the transform.
+	if in.GenerateImage {
+		img, err := h.images.Generate(r.Context(), uid, out.ID)
+		if err != nil {
+			logging.FromContext(r.Context()).Warn("image generation failed", "post_id", out.ID, "err", err)
+		} else {
+			res.Images = []imageItem{h.newImageItem(img)}
+		}
+	}
+	respondJSON(w, http.StatusCreated, res)
 }
 
 func (h *LinkedInHandler) history(w http.ResponseWriter, r *http.Request) {
@@ -72,23 +193,200 @@ func (h *LinkedInHandler) history(w http.ResponseWriter, r *http.Request) {
 		pageSize = 10 // Default page size
 	}
 
-	items, err := h.svc.History(r.Context(), uid, page, pageSize)
+	filter := repository.PostFilter{Language: r.URL.Query().Get("language"), Sort: r.URL.Query().Get("sort")}
+	if filter.Sort != "" && filter.Sort != repository.SortNewest && filter.Sort != repository.SortScore {
+		respondError(w, http.StatusBadRequest, "The 'sort' parameter must be newest or score")
+		return
+	}
+	format, ok := postFormat(w, r)
+	if !ok {
+		return
+	}
+
+	items, err := h.svc.History(r.Context(), uid, page, pageSize, filter)
+	if errors.Is(err, service.ErrInvalidLanguage) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to retrieve history")
 		return
 	}
-	type item struct {
-		ID    uuid.UUID `json:"id"`
-		Input string    `json:"input"`
-		Post  string    `json:"post"`
-	}
-	var res []item
-	for _, p := range items {
-		res = append(res, item{ID: p.ID, Input: p.InputText, Post: p.OutputText})
+	var res []historyItem
+	for i := range items {
+		item := newHistoryItem(&items[i])
+		item.Post = renderPost(item.Post, format)
+		res = append(res, item)
 	}
 	respondJSON(w, http.StatusOK, res)
 }
 
+// postFormat reads the optional Accept-style 'format' parameter, answering
+// 400 when it names no supported format. Without it posts are returned as
+// generated.
+func postFormat(w http.ResponseWriter, r *http.Request) (postformat.Format, bool) {
+	accept := r.URL.Query().Get("format")
+	if accept == "" {
+		return "", true
+	}
+	f, err := postformat.Parse(accept)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "The 'format' parameter must be linkedin, plain, html or markdown")
+		return "", false
+	}
+	return f, true
+}
+
+func renderPost(post string, f postformat.Format) string {
+	if f == "" {
+		return post
+	}
+	out, err := postformat.Render(post, f)
+	if err != nil {
+		return post
+	}
+	return out
+}
+
+// rejectionBody explains why moderation rejected a transform.
+type rejectionBody struct {
+	Error   string               `json:"error"`
+	Reasons []moderation.Finding `json:"reasons"`
+}
+
+// respondRejection answers a *service.RejectedError with its reasons and
+// reports whether err was one.
+func respondRejection(w http.ResponseWriter, err error) bool {
+	var rejected *service.RejectedError
+	if !errors.As(err, &rejected) {
+		return false
+	}
+	respondJSON(w, http.StatusUnprocessableEntity, rejectionBody{Error: "Content rejected by moderation", Reasons: rejected.Findings})
+	return true
+}
+
+type historyItem struct {
+	ID           uuid.UUID  `json:"id"`
+	Input        string     `json:"input"`
+	Post         string     `json:"post"`
+	Language     string     `json:"language,omitempty"`
+	SourcePostID *uuid.UUID `json:"source_post_id,omitempty"`
+	Provider     string     `json:"provider,omitempty"`
+	Model        string     `json:"model,omitempty"`
+	// ModerationFlags are findings that flagged but did not block the post.
+	ModerationFlags []model.ModerationFlag `json:"moderation_flags,omitempty"`
+	Quality         *model.PostQuality     `json:"quality,omitempty"`
+}
+
+func newHistoryItem(p *model.LinkedInPost) historyItem {
+	item := historyItem{ID: p.ID, Input: p.InputText, Post: p.OutputText, Language: p.Language, Provider: p.Provider, Model: p.Model, ModerationFlags: p.ModerationFlags, Quality: p.Quality}
+	if p.SourcePostID != uuid.Nil {
+		item.SourcePostID = &p.SourcePostID
+	}
+	return item
+}
+
+type translateBody struct {
+	Languages []string `json:"languages"`
+}
+
+func (h *LinkedInHandler) translate(w http.ResponseWriter, r *http.Request) {
+	postID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Post not found")
+		return
+	}
+	var in translateBody
+	if !decodeBody(w, r, h.bodyLimit(), &in) {
+		return
+	}
+
+	variants, err := h.svc.Translate(r.Context(), middleware.UserID(r.Context()), postID, in.Languages)
+	switch {
+	case errors.Is(err, service.ErrPostNotFound):
+		respondError(w, http.StatusNotFound, "Post not found")
+		return
+	case errors.Is(err, service.ErrInvalidLanguage):
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	case respondRejection(w, err), respondAIError(w, err):
+		return
+	case err != nil:
+		respondError(w, http.StatusInternalServerError, "Failed to translate post")
+		return
+	}
+	res := make([]historyItem, 0, len(variants))
+	for i := range variants {
+		res = append(res, newHistoryItem(&variants[i]))
+	}
+	respondJSON(w, http.StatusCreated, res)
+}
+
+// maxImportBytes caps the size of an import upload.
+const maxImportBytes = 10 << 20
+
+func (h *LinkedInHandler) export(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("format")
+	if name == "" {
+		name = "jsonl"
+	}
+	format, ok := exportFormats[name]
+	if !ok {
+		respondError(w, http.StatusBadRequest, "The 'format' parameter must be one of csv, jsonl or markdown")
+		return
+	}
+
+	w.Header().Set("Content-Type", format.contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="linkedinify-posts.%s"`, format.extension))
+	logger := logging.FromContext(r.Context())
+	enc, err := format.newEncoder(w)
+	if err != nil {
+		logger.Error("failed to start export", "err", err)
+		return
+	}
+	// Headers are already sent once the first record is written, so failures
+	// past this point can only be logged and the stream cut short.
+	uid := middleware.UserID(r.Context())
+	if err := h.svc.Export(r.Context(), uid, enc.Encode); err != nil {
+		logger.Error("export aborted", "format", name, "err", err)
+		return
+	}
+	if err := enc.Close(); err != nil {
+		logger.Error("failed to finish export", "format", name, "err", err)
+	}
+}
+
+func (h *LinkedInHandler) importPosts(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "jsonl"
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "text/csv") {
+			format = "csv"
+		}
+	}
+
+	posts, err := decodeImport(http.MaxBytesReader(w, r.Body, maxImportBytes), format)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid import file: "+err.Error())
+		return
+	}
+
+	uid := middleware.UserID(r.Context())
+	imported, err := h.svc.Import(r.Context(), uid, posts)
+	if errors.Is(err, service.ErrInvalidLanguage) {
+		respondError(w, http.StatusBadRequest, "Invalid import file: "+err.Error())
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to import posts")
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]int{
+		"imported": imported,
+		"skipped":  len(posts) - imported,
+	})
+}
+
 // --- Response Helpers ---
 
 // respondJSON writes a JSON response with a given status code and payload.
@@ -96,17 +394,17 @@ func respondJSON(w http.ResponseWriter, status int, payload interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	response, err := json.Marshal(payload)
 	if err != nil {
-		log.Printf("ERROR: Failed to marshal JSON response: %v", err)
+		slog.Error("failed to marshal JSON response", "err", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		// We'll try to write an error response, but this might also fail
 		if _, writeErr := w.Write([]byte(`{"error":"Internal server error"}`)); writeErr != nil {
-			log.Printf("ERROR: Could not write error response: %v", writeErr)
+			slog.Error("could not write error response", "err", writeErr)
 		}
 		return
 	}
 	w.WriteHeader(status)
 	if _, err := w.Write(response); err != nil {
-		log.Printf("ERROR: Failed to write JSON response: %v", err)
+		slog.Error("failed to write JSON response", "err", err)
 	}
 }
 
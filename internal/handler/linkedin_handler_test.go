@@ -5,8 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,8 +17,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/you/linkedinify/internal/ai"
 	"github.com/you/linkedinify/internal/handler"
 	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/moderation"
+	"github.com/you/linkedinify/internal/normalize"
+	"github.com/you/linkedinify/internal/repository"
 	"github.com/you/linkedinify/internal/service"
 )
 
@@ -35,10 +41,10 @@ func generateTestToken(t *testing.T, userID uuid.UUID, secret []byte) string {
 
 func TestLinkedInHandler_transform_Success(t *testing.T) {
 	mockService := &service.LinkedInServiceInteractorMock{
-		TransformFunc: func(ctx context.Context, userID uuid.UUID, text string) (string, error) {
+		TransformFunc: func(ctx context.Context, userID uuid.UUID, text string, opts service.TransformOptions) (*model.LinkedInPost, error) {
 			assert.Equal(t, "00000000-0000-0000-0000-000000000001", userID.String())
 			assert.Equal(t, "some input text", text)
-			return "transformed linkedin post", nil
+			return &model.LinkedInPost{OutputText: "transformed linkedin post"}, nil
 		},
 	}
 	testUserID, _ := uuid.Parse("00000000-0000-0000-0000-000000000001")
@@ -108,7 +114,7 @@ func TestLinkedInHandler_History_Success(t *testing.T) {
 	}
 
 	mockService := &service.LinkedInServiceInteractorMock{
-		HistoryFunc: func(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]model.LinkedInPost, error) {
+		HistoryFunc: func(ctx context.Context, userID uuid.UUID, page, pageSize int, filter repository.PostFilter) ([]model.LinkedInPost, error) {
 			assert.Equal(t, testUserID, userID)
 			assert.Equal(t, 1, page)
 			assert.Equal(t, 5, pageSize)
@@ -146,7 +152,7 @@ func TestLinkedInHandler_History_ServiceError(t *testing.T) {
 	serviceErr := errors.New("service error")
 
 	mockService := &service.LinkedInServiceInteractorMock{
-		HistoryFunc: func(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]model.LinkedInPost, error) {
+		HistoryFunc: func(ctx context.Context, userID uuid.UUID, page, pageSize int, filter repository.PostFilter) ([]model.LinkedInPost, error) {
 			return nil, serviceErr
 		},
 	}
@@ -171,10 +177,10 @@ func TestLinkedInHandler_History_ServiceError(t *testing.T) {
 
 func TestLinkedInHandler_transform_SanitizesInput(t *testing.T) {
 	mockService := &service.LinkedInServiceInteractorMock{
-		TransformFunc: func(ctx context.Context, userID uuid.UUID, text string) (string, error) {
+		TransformFunc: func(ctx context.Context, userID uuid.UUID, text string, opts service.TransformOptions) (*model.LinkedInPost, error) {
 			// Assert that the text received by the service is sanitized
 			assert.Equal(t, "Hello world", text, "Expected input to be sanitized")
-			return "sanitized and transformed", nil
+			return &model.LinkedInPost{OutputText: "sanitized and transformed"}, nil
 		},
 	}
 	testUserID, _ := uuid.Parse("00000000-0000-0000-0000-000000000005")
@@ -201,4 +207,437 @@ func TestLinkedInHandler_transform_SanitizesInput(t *testing.T) {
 
 	assert.Equal(t, http.StatusCreated, resp.StatusCode)
 	assert.Len(t, mockService.TransformCalls(), 1)
+
+	var body struct {
+		Post         string             `json:"post"`
+		InputChanges []normalize.Change `json:"input_changes"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "sanitized and transformed", body.Post)
+	assert.Equal(t, []normalize.Change{{Kind: normalize.HTMLTags, Count: 3}}, body.InputChanges)
+}
+
+func TestLinkedInHandler_transform_KeepsIntent(t *testing.T) {
+	mockService := &service.LinkedInServiceInteractorMock{
+		TransformFunc: func(ctx context.Context, userID uuid.UUID, text string, opts service.TransformOptions) (*model.LinkedInPost, error) {
+			return &model.LinkedInPost{OutputText: "post"}, nil
+		},
+	}
+	testSecret := []byte("your-test-jwt-secret")
+	server := httptest.NewServer(handler.NewLinkedIn(mockService).Routes(testSecret))
+	defer server.Close()
+
+	rec := postJSON(t, server, "/", generateTestToken(t, uuid.New(), testSecret), map[string]string{"text": "I <3 shipping when latency < 100ms"})
+
+	assert.Equal(t, http.StatusCreated, rec.StatusCode)
+	require.Len(t, mockService.TransformCalls(), 1)
+	assert.Equal(t, "I <3 shipping when latency < 100ms", mockService.TransformCalls()[0].Text)
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.NotContains(t, body, "input_changes")
+}
+
+func TestLinkedInHandler_transform_InputTooLong(t *testing.T) {
+	mockService := &service.LinkedInServiceInteractorMock{}
+	testSecret := []byte("your-test-jwt-secret")
+	h := handler.NewLinkedIn(mockService, handler.WithNormalizer(normalize.New(10)))
+	server := httptest.NewServer(h.Routes(testSecret))
+	defer server.Close()
+	token := generateTestToken(t, uuid.New(), testSecret)
+
+	rec := postJSON(t, server, "/", token, map[string]string{"text": "far too long for this"})
+	assert.Equal(t, http.StatusBadRequest, rec.StatusCode)
+
+	rec = postJSON(t, server, "/", token, map[string]string{"text": "<br>\u200b"})
+	assert.Equal(t, http.StatusBadRequest, rec.StatusCode)
+	assert.Empty(t, mockService.TransformCalls())
+}
+
+func TestLinkedInHandler_BodyTooLarge(t *testing.T) {
+	mockService := &service.LinkedInServiceInteractorMock{}
+	testSecret := []byte("your-test-jwt-secret")
+	h := handler.NewLinkedIn(mockService, handler.WithNormalizer(normalize.New(10)))
+	server := httptest.NewServer(h.Routes(testSecret))
+	defer server.Close()
+	token := generateTestToken(t, uuid.New(), testSecret)
+	padding := strings.Repeat(" ", 8<<10)
+
+	rec := postJSON(t, server, "/", token, map[string]string{"text": "hi", "padding": padding})
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.StatusCode)
+
+	rec = postJSON(t, server, "/"+uuid.NewString()+"/translations", token, map[string]any{"languages": []string{"fr"}, "padding": padding})
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.StatusCode)
+
+	assert.Empty(t, mockService.TransformCalls())
+	assert.Empty(t, mockService.TranslateCalls())
+}
+
+func postJSON(t *testing.T, server *httptest.Server, path, token string, body any) *http.Response {
+	t.Helper()
+	jsonBody, err := json.Marshal(body)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, server.URL+path, bytes.NewBuffer(jsonBody))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestLinkedInHandler_Formats(t *testing.T) {
+	userID := uuid.New()
+	testSecret := []byte("your-test-jwt-secret")
+	mockService := &service.LinkedInServiceInteractorMock{
+		TransformFunc: func(ctx context.Context, userID uuid.UUID, text string, opts service.TransformOptions) (*model.LinkedInPost, error) {
+			return &model.LinkedInPost{OutputText: "**Big** news\n- shipped"}, nil
+		},
+		HistoryFunc: func(ctx context.Context, userID uuid.UUID, page, pageSize int, filter repository.PostFilter) ([]model.LinkedInPost, error) {
+			return []model.LinkedInPost{{ID: uuid.New(), UserID: userID, OutputText: "**Big** news"}}, nil
+		},
+	}
+	server := httptest.NewServer(handler.NewLinkedIn(mockService).Routes(testSecret))
+	defer server.Close()
+	token := generateTestToken(t, userID, testSecret)
+
+	resp := postJSON(t, server, "/?format=text/html", token, map[string]string{"text": "news"})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var created map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	assert.Equal(t, "<p><strong>Big</strong> news</p>\n<ul>\n<li>shipped</li>\n</ul>", created["post"])
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/history?format=linkedin", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var items []map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&items))
+	require.Len(t, items, 1)
+	assert.Equal(t, "𝗕𝗶𝗴 news", items[0]["post"])
+
+	resp = postJSON(t, server, "/?format=docx", token, map[string]string{"text": "news"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Len(t, mockService.TransformCalls(), 1, "an unknown format fails before the transform")
+}
+
+func TestLinkedInHandler_Export_CSV(t *testing.T) {
+	testUserID, _ := uuid.Parse("00000000-0000-0000-0000-000000000006")
+	testSecret := []byte("your-test-jwt-secret")
+	postID := uuid.New()
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	mockService := &service.LinkedInServiceInteractorMock{
+		ExportFunc: func(ctx context.Context, userID uuid.UUID, fn func(*model.LinkedInPost) error) error {
+			assert.Equal(t, testUserID, userID)
+			return fn(&model.LinkedInPost{
+				ID: postID, InputText: "in, with comma", OutputText: "out", CreatedAt: createdAt,
+				Language: "en", Provider: "openai", Model: "gpt-4o",
+				ModerationFlags: []model.ModerationFlag{{Stage: "input", Category: "pii", Detail: "email"}},
+				Quality:         &model.PostQuality{Score: 72},
+			})
+		},
+	}
+	linkedinHandler := handler.NewLinkedIn(mockService)
+	server := httptest.NewServer(linkedinHandler.Routes(testSecret))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/export?format=csv", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+generateTestToken(t, testUserID, testSecret))
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/csv")
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	expected := "id,created_at,language,source_post_id,provider,model,input,post,moderation_flags,quality\n" +
+		postID.String() + `,2024-05-01T12:00:00Z,en,,openai,gpt-4o,"in, with comma",out,` +
+		`"[{""stage"":""input"",""category"":""pii"",""detail"":""email""}]",` +
+		`"{""score"":72,""emoji_density"":0,""hashtags"":0,""hook"":0,""buzzword_density"":0,""length"":0,""words"":0}"` + "\n"
+	assert.Equal(t, expected, string(body))
+}
+
+func TestLinkedInHandler_Import_CSV(t *testing.T) {
+	testUserID := uuid.New()
+	testSecret := []byte("your-test-jwt-secret")
+	var imported [][]model.LinkedInPost
+	mockService := &service.LinkedInServiceInteractorMock{
+		ImportFunc: func(ctx context.Context, userID uuid.UUID, posts []model.LinkedInPost) (int, error) {
+			imported = append(imported, posts)
+			return len(posts), nil
+		},
+	}
+	server := httptest.NewServer(handler.NewLinkedIn(mockService).Routes(testSecret))
+	defer server.Close()
+
+	for _, body := range []string{
+		"id,created_at,language,source_post_id,provider,model,input,post,moderation_flags,quality\n" +
+			`,2024-05-01T12:00:00Z,de,,anthropic,claude,in,out,"[{""stage"":""output"",""category"":""profanity""}]","{""score"":80}"` + "\n",
+		"id,created_at,input,post\n,,in,out\n",
+	} {
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/import?format=csv", bytes.NewBufferString(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+generateTestToken(t, testUserID, testSecret))
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	require.Len(t, imported, 2)
+	post := imported[0][0]
+	assert.Equal(t, "de", post.Language)
+	assert.Equal(t, "anthropic", post.Provider)
+	assert.Equal(t, "claude", post.Model)
+	assert.Equal(t, []model.ModerationFlag{{Stage: "output", Category: "profanity"}}, post.ModerationFlags)
+	assert.Nil(t, post.Quality, "imported scores are recomputed by the service")
+	assert.Equal(t, "in", imported[1][0].InputText, "exports without metadata columns still import")
+}
+
+func TestLinkedInHandler_Export_UnknownFormat(t *testing.T) {
+	testUserID := uuid.New()
+	testSecret := []byte("your-test-jwt-secret")
+	mockService := &service.LinkedInServiceInteractorMock{}
+	server := httptest.NewServer(handler.NewLinkedIn(mockService).Routes(testSecret))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/export?format=xml", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+generateTestToken(t, testUserID, testSecret))
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Len(t, mockService.ExportCalls(), 0)
+}
+
+func TestLinkedInHandler_Import_JSONL(t *testing.T) {
+	testUserID := uuid.New()
+	testSecret := []byte("your-test-jwt-secret")
+	postID := uuid.New()
+
+	mockService := &service.LinkedInServiceInteractorMock{
+		ImportFunc: func(ctx context.Context, userID uuid.UUID, posts []model.LinkedInPost) (int, error) {
+			assert.Equal(t, testUserID, userID)
+			require.Len(t, posts, 2)
+			assert.Equal(t, postID, posts[0].ID)
+			assert.Equal(t, "second", posts[1].InputText)
+			return 1, nil
+		},
+	}
+	server := httptest.NewServer(handler.NewLinkedIn(mockService).Routes(testSecret))
+	defer server.Close()
+
+	body := `{"id":"` + postID.String() + `","input":"first","post":"out1","created_at":"2024-05-01T12:00:00Z"}` + "\n\n" +
+		`{"input":"second","post":"out2"}` + "\n"
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/import", bytes.NewBufferString(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+generateTestToken(t, testUserID, testSecret))
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var responseBody map[string]int
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+	assert.Equal(t, map[string]int{"imported": 1, "skipped": 1}, responseBody)
+}
+
+func TestLinkedInHandler_Import_InvalidRecord(t *testing.T) {
+	testUserID := uuid.New()
+	testSecret := []byte("your-test-jwt-secret")
+	mockService := &service.LinkedInServiceInteractorMock{}
+	server := httptest.NewServer(handler.NewLinkedIn(mockService).Routes(testSecret))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/import?format=csv", bytes.NewBufferString("id,created_at,input,post\n,,,missing input\n"))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+generateTestToken(t, testUserID, testSecret))
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Len(t, mockService.ImportCalls(), 0)
+}
+
+func TestLinkedInHandler_History_LanguageFilter(t *testing.T) {
+	testUserID := uuid.New()
+	testSecret := []byte("your-test-jwt-secret")
+	sourceID := uuid.New()
+	mockService := &service.LinkedInServiceInteractorMock{
+		HistoryFunc: func(ctx context.Context, userID uuid.UUID, page, pageSize int, filter repository.PostFilter) ([]model.LinkedInPost, error) {
+			assert.Equal(t, "de", filter.Language)
+			return []model.LinkedInPost{{ID: uuid.New(), InputText: "in", OutputText: "aus", Language: "de", SourcePostID: sourceID}}, nil
+		},
+	}
+	server := httptest.NewServer(handler.NewLinkedIn(mockService).Routes(testSecret))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/history?language=de", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+generateTestToken(t, testUserID, testSecret))
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var responseBody []map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+	require.Len(t, responseBody, 1)
+	assert.Equal(t, "de", responseBody[0]["language"])
+	assert.Equal(t, sourceID.String(), responseBody[0]["source_post_id"])
+}
+
+func TestLinkedInHandler_Translate_NotFound(t *testing.T) {
+	testUserID := uuid.New()
+	testSecret := []byte("your-test-jwt-secret")
+	mockService := &service.LinkedInServiceInteractorMock{
+		TranslateFunc: func(ctx context.Context, userID, postID uuid.UUID, languages []string) ([]model.LinkedInPost, error) {
+			assert.Equal(t, []string{"fr"}, languages)
+			return nil, service.ErrPostNotFound
+		},
+	}
+	server := httptest.NewServer(handler.NewLinkedIn(mockService).Routes(testSecret))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/"+uuid.NewString()+"/translations", bytes.NewBufferString(`{"languages":["fr"]}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+generateTestToken(t, testUserID, testSecret))
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestLinkedInHandler_transform_AIErrors(t *testing.T) {
+	tests := []struct {
+		err        error
+		status     int
+		retryAfter string
+	}{
+		{&ai.ProviderError{Kind: ai.ErrRateLimited, RetryAfter: 1500 * time.Millisecond}, http.StatusTooManyRequests, "2"},
+		{&ai.ProviderError{Kind: ai.ErrContentFiltered}, http.StatusUnprocessableEntity, ""},
+		{&ai.ProviderError{Kind: ai.ErrUnavailable, RetryAfter: 30 * time.Second}, http.StatusServiceUnavailable, "30"},
+		{errors.New("invalid api key"), http.StatusInternalServerError, ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.err.Error(), func(t *testing.T) {
+			mockService := &service.LinkedInServiceInteractorMock{
+				TransformFunc: func(ctx context.Context, userID uuid.UUID, text string, opts service.TransformOptions) (*model.LinkedInPost, error) {
+					return nil, tc.err
+				},
+			}
+			secret := []byte("your-test-jwt-secret")
+			server := httptest.NewServer(handler.NewLinkedIn(mockService).Routes(secret))
+			defer server.Close()
+
+			req, err := http.NewRequest(http.MethodPost, server.URL+"/", bytes.NewBufferString(`{"text":"hello"}`))
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+generateTestToken(t, uuid.New(), secret))
+
+			resp, err := server.Client().Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.status, resp.StatusCode)
+			assert.Equal(t, tc.retryAfter, resp.Header.Get("Retry-After"))
+		})
+	}
+}
+
+func TestLinkedInHandler_transform_RejectedByModeration(t *testing.T) {
+	mockService := &service.LinkedInServiceInteractorMock{
+		TransformFunc: func(ctx context.Context, userID uuid.UUID, text string, opts service.TransformOptions) (*model.LinkedInPost, error) {
+			return nil, &service.RejectedError{Findings: []moderation.Finding{
+				{Stage: moderation.Input, Category: moderation.CategoryPII, Detail: moderation.PIIEmail, Action: moderation.Block},
+			}}
+		},
+	}
+	secret := []byte("your-test-jwt-secret")
+	server := httptest.NewServer(handler.NewLinkedIn(mockService).Routes(secret))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/", bytes.NewBufferString(`{"text":"mail me at jane@example.com"}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+generateTestToken(t, uuid.New(), secret))
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"error":"Content rejected by moderation","reasons":[{"stage":"input","category":"pii","detail":"email","action":"block"}]}`, string(body))
+}
+
+func TestLinkedInHandler_Transform_ReturnsQuality(t *testing.T) {
+	testUserID := uuid.New()
+	testSecret := []byte("your-test-jwt-secret")
+	mockService := &service.LinkedInServiceInteractorMock{
+		TransformFunc: func(ctx context.Context, userID uuid.UUID, text string, opts service.TransformOptions) (*model.LinkedInPost, error) {
+			return &model.LinkedInPost{OutputText: "post", Quality: &model.PostQuality{Score: 72, Hashtags: 3, Tips: []string{"Use fewer emojis."}}}, nil
+		},
+	}
+	server := httptest.NewServer(handler.NewLinkedIn(mockService).Routes(testSecret))
+	defer server.Close()
+
+	resp := postJSON(t, server, "/", generateTestToken(t, testUserID, testSecret), map[string]string{"text": "hello"})
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	var res struct {
+		Post    string             `json:"post"`
+		Quality *model.PostQuality `json:"quality"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+	require.NotNil(t, res.Quality)
+	assert.Equal(t, 72, res.Quality.Score)
+	assert.Equal(t, []string{"Use fewer emojis."}, res.Quality.Tips)
+}
+
+func TestLinkedInHandler_History_Sort(t *testing.T) {
+	testUserID := uuid.New()
+	testSecret := []byte("your-test-jwt-secret")
+	mockService := &service.LinkedInServiceInteractorMock{
+		HistoryFunc: func(ctx context.Context, userID uuid.UUID, page, pageSize int, filter repository.PostFilter) ([]model.LinkedInPost, error) {
+			assert.Equal(t, repository.SortScore, filter.Sort)
+			return []model.LinkedInPost{{ID: uuid.New(), OutputText: "best", Quality: &model.PostQuality{Score: 91}}}, nil
+		},
+	}
+	server := httptest.NewServer(handler.NewLinkedIn(mockService).Routes(testSecret))
+	defer server.Close()
+	token := generateTestToken(t, testUserID, testSecret)
+
+	for query, want := range map[string]int{"sort=score": http.StatusOK, "sort=best": http.StatusBadRequest} {
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/history?"+query, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, want, resp.StatusCode, query)
+		if want == http.StatusOK {
+			var responseBody []map[string]interface{}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+			require.Len(t, responseBody, 1)
+			assert.Equal(t, 91.0, responseBody[0]["quality"].(map[string]interface{})["score"])
+		}
+		resp.Body.Close()
+	}
+	assert.Len(t, mockService.HistoryCalls(), 1)
 }
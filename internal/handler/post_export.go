@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/model"
+)
+
+// csvHeader lists the CSV columns. moderation_flags and quality hold the
+// same JSON as in jsonl exports, or nothing. quality is ignored on import.
+var csvHeader = []string{"id", "created_at", "language", "source_post_id", "provider", "model", "input", "post", "moderation_flags", "quality"}
+
+// legacyCSVHeader is the header of exports made before the metadata
+// columns were added; such files can still be imported.
+var legacyCSVHeader = []string{"id", "created_at", "input", "post"}
+
+// postEncoder writes a stream of posts in one export format.
+type postEncoder interface {
+	Encode(p *model.LinkedInPost) error
+	Close() error
+}
+
+type exportFormat struct {
+	contentType string
+	extension   string
+	newEncoder  func(w io.Writer) (postEncoder, error)
+}
+
+var exportFormats = map[string]exportFormat{
+	"jsonl":    {"application/x-ndjson", "jsonl", newJSONLEncoder},
+	"csv":      {"text/csv; charset=utf-8", "csv", newCSVEncoder},
+	"markdown": {"text/markdown; charset=utf-8", "md", newMarkdownEncoder},
+}
+
+type jsonlEncoder struct{ enc *json.Encoder }
+
+func newJSONLEncoder(w io.Writer) (postEncoder, error) {
+	return &jsonlEncoder{enc: json.NewEncoder(w)}, nil
+}
+
+func (e *jsonlEncoder) Encode(p *model.LinkedInPost) error {
+	return e.enc.Encode(model.NewPostRecord(p))
+}
+func (e *jsonlEncoder) Close() error { return nil }
+
+type csvEncoder struct{ w *csv.Writer }
+
+func newCSVEncoder(w io.Writer) (postEncoder, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return nil, err
+	}
+	return &csvEncoder{w: cw}, nil
+}
+
+func (e *csvEncoder) Encode(p *model.LinkedInPost) error {
+	rec := model.NewPostRecord(p)
+	var sourceID, flags, score string
+	if rec.SourcePostID != nil {
+		sourceID = rec.SourcePostID.String()
+	}
+	if rec.ModerationFlags != nil {
+		b, err := json.Marshal(rec.ModerationFlags)
+		if err != nil {
+			return err
+		}
+		flags = string(b)
+	}
+	if rec.Quality != nil {
+		b, err := json.Marshal(rec.Quality)
+		if err != nil {
+			return err
+		}
+		score = string(b)
+	}
+	return e.w.Write([]string{
+		rec.ID.String(), rec.CreatedAt.UTC().Format(time.RFC3339), rec.Language, sourceID, rec.Provider, rec.Model,
+		rec.Input, rec.Post, flags, score,
+	})
+}
+
+func (e *csvEncoder) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type markdownEncoder struct{ w io.Writer }
+
+func newMarkdownEncoder(w io.Writer) (postEncoder, error) {
+	_, err := io.WriteString(w, "# LinkedInify post history\n")
+	return &markdownEncoder{w: w}, err
+}
+
+func (e *markdownEncoder) Encode(p *model.LinkedInPost) error {
+	quoted := "> " + strings.ReplaceAll(p.InputText, "\n", "\n> ")
+	_, err := fmt.Fprintf(e.w, "\n## %s\n\n_ID: %s_\n\n%s\n\n%s\n",
+		p.CreatedAt.UTC().Format(time.RFC1123), p.ID, quoted, p.OutputText)
+	return err
+}
+
+func (e *markdownEncoder) Close() error { return nil }
+
+// decodeImport parses an import body in the given format. Only jsonl and
+// csv, which keep every field of a post except its images, can be imported.
+func decodeImport(r io.Reader, format string) ([]model.LinkedInPost, error) {
+	switch format {
+	case "jsonl":
+		return decodeJSONL(r)
+	case "csv":
+		return decodeCSV(r)
+	default:
+		return nil, fmt.Errorf("unsupported import format %q", format)
+	}
+}
+
+func decodeJSONL(r io.Reader) ([]model.LinkedInPost, error) {
+	var posts []model.LinkedInPost
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; sc.Scan(); line++ {
+		raw := strings.TrimSpace(sc.Text())
+		if raw == "" {
+			continue
+		}
+		var rec model.PostRecord
+		if err := json.Unmarshal([]byte(raw), &rec); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if err := validateRecord(rec); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		posts = append(posts, rec.LinkedInPost())
+	}
+	return posts, sc.Err()
+}
+
+func decodeCSV(r io.Reader) ([]model.LinkedInPost, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+	if h := strings.Join(header, ","); h != strings.Join(csvHeader, ",") && h != strings.Join(legacyCSVHeader, ",") {
+		return nil, fmt.Errorf("unexpected header %q, want %q", header, csvHeader)
+	}
+	col := make(map[string]string, len(header))
+
+	var posts []model.LinkedInPost
+	for line := 2; ; line++ {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return posts, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for i, name := range header {
+			col[name] = row[i]
+		}
+		var rec model.PostRecord
+		if v := col["id"]; v != "" {
+			if rec.ID, err = uuid.Parse(v); err != nil {
+				return nil, fmt.Errorf("line %d: invalid id: %w", line, err)
+			}
+		}
+		if v := col["created_at"]; v != "" {
+			if rec.CreatedAt, err = time.Parse(time.RFC3339, v); err != nil {
+				return nil, fmt.Errorf("line %d: invalid created_at: %w", line, err)
+			}
+		}
+		if v := col["moderation_flags"]; v != "" {
+			if err := json.Unmarshal([]byte(v), &rec.ModerationFlags); err != nil {
+				return nil, fmt.Errorf("line %d: invalid moderation_flags: %w", line, err)
+			}
+		}
+		rec.Input, rec.Post = col["input"], col["post"]
+		rec.Language, rec.Provider, rec.Model = col["language"], col["provider"], col["model"]
+		if err := validateRecord(rec); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		posts = append(posts, rec.LinkedInPost())
+	}
+}
+
+func validateRecord(r model.PostRecord) error {
+	if r.Input == "" || r.Post == "" {
+		return fmt.Errorf("both 'input' and 'post' are required")
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/you/linkedinify/internal/middleware"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/service"
+)
+
+type SettingsHandler struct {
+	svc service.SettingsServiceInteractor
+}
+
+func NewSettings(svc service.SettingsServiceInteractor) *SettingsHandler {
+	return &SettingsHandler{svc: svc}
+}
+
+// Routes serves the admin API for runtime settings, guarded by the admin token.
+func (h *SettingsHandler) Routes(adminToken string) chi.Router {
+	r := chi.NewRouter()
+	r.Use(middleware.AdminToken(adminToken))
+	r.Get("/", h.current)
+	r.Put("/", h.update)
+	r.Get("/history", h.history)
+	return r
+}
+
+type settingsResponse struct {
+	Version   int64               `json:"version"`
+	Values    model.RuntimeValues `json:"values"`
+	ChangedBy string              `json:"changed_by,omitempty"`
+	Comment   string              `json:"comment,omitempty"`
+	CreatedAt *time.Time          `json:"created_at,omitempty"`
+}
+
+func newSettingsResponse(s *model.RuntimeSettings) settingsResponse {
+	res := settingsResponse{Version: s.Version, Values: s.Values, ChangedBy: s.ChangedBy, Comment: s.Comment}
+	if !s.CreatedAt.IsZero() {
+		res.CreatedAt = &s.CreatedAt
+	}
+	return res
+}
+
+// settingsUpdateRequest replaces all values. Version is the version the
+// change was made against, for optimistic concurrency.
+type settingsUpdateRequest struct {
+	Version   *int64              `json:"version"`
+	Values    model.RuntimeValues `json:"values"`
+	ChangedBy string              `json:"changed_by"`
+	Comment   string              `json:"comment"`
+}
+
+func (h *SettingsHandler) current(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, newSettingsResponse(h.svc.Current()))
+}
+
+func (h *SettingsHandler) update(w http.ResponseWriter, r *http.Request) {
+	var in settingsUpdateRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&in); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	if in.Version == nil {
+		respondError(w, http.StatusBadRequest, "The 'version' field is required")
+		return
+	}
+
+	s, err := h.svc.Update(r.Context(), service.SettingsUpdate{
+		BaseVersion: *in.Version,
+		Values:      in.Values,
+		ChangedBy:   in.ChangedBy,
+		Comment:     in.Comment,
+	})
+	switch {
+	case errors.Is(err, service.ErrInvalidSettings):
+		respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrSettingsConflict):
+		respondError(w, http.StatusConflict, "Settings were changed since the given version; reload and retry")
+	case err != nil:
+		respondError(w, http.StatusInternalServerError, "Failed to update settings")
+	default:
+		respondJSON(w, http.StatusOK, newSettingsResponse(s))
+	}
+}
+
+func (h *SettingsHandler) history(w http.ResponseWriter, r *http.Request) {
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 20
+	}
+	versions, err := h.svc.History(r.Context(), limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to load settings history")
+		return
+	}
+	res := make([]settingsResponse, 0, len(versions))
+	for i := range versions {
+		res = append(res, newSettingsResponse(&versions[i]))
+	}
+	respondJSON(w, http.StatusOK, res)
+}
@@ -0,0 +1,90 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/handler"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/service"
+)
+
+const testAdminToken = "admin-test-token"
+
+func adminRequest(t *testing.T, server *httptest.Server, method, path, body string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, server.URL+path, bytes.NewBufferString(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestSettingsHandler_Update(t *testing.T) {
+	mockService := &service.SettingsServiceInteractorMock{
+		UpdateFunc: func(ctx context.Context, upd service.SettingsUpdate) (*model.RuntimeSettings, error) {
+			assert.Equal(t, int64(2), upd.BaseVersion)
+			assert.Equal(t, 30, upd.Values.TransformsPerMinute)
+			return &model.RuntimeSettings{Version: 3, Values: upd.Values, ChangedBy: upd.ChangedBy}, nil
+		},
+	}
+	server := httptest.NewServer(handler.NewSettings(mockService).Routes(testAdminToken))
+	defer server.Close()
+
+	resp := adminRequest(t, server, http.MethodPut, "/", `{"version":2,"values":{"transforms_per_minute":30},"changed_by":"ops"}`)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var res map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+	assert.Equal(t, float64(3), res["version"])
+	assert.Equal(t, float64(30), res["values"].(map[string]interface{})["transforms_per_minute"])
+}
+
+func TestSettingsHandler_Update_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		err  error
+		want int
+	}{
+		{"missing version", `{"values":{}}`, nil, http.StatusBadRequest},
+		{"unknown field", `{"version":1,"values":{"nope":1}}`, nil, http.StatusBadRequest},
+		{"invalid", `{"version":1,"values":{}}`, fmt.Errorf("%w: bad", service.ErrInvalidSettings), http.StatusBadRequest},
+		{"conflict", `{"version":1,"values":{}}`, service.ErrSettingsConflict, http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &service.SettingsServiceInteractorMock{
+				UpdateFunc: func(ctx context.Context, upd service.SettingsUpdate) (*model.RuntimeSettings, error) {
+					return nil, tt.err
+				},
+			}
+			server := httptest.NewServer(handler.NewSettings(mockService).Routes(testAdminToken))
+			defer server.Close()
+
+			resp := adminRequest(t, server, http.MethodPut, "/", tt.body)
+			defer resp.Body.Close()
+			assert.Equal(t, tt.want, resp.StatusCode)
+		})
+	}
+}
+
+func TestSettingsHandler_RequiresAdminToken(t *testing.T) {
+	server := httptest.NewServer(handler.NewSettings(&service.SettingsServiceInteractorMock{}).Routes(testAdminToken))
+	defer server.Close()
+
+	resp, err := server.Client().Get(server.URL + "/history")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/you/linkedinify/internal/hashtag"
+	"github.com/you/linkedinify/internal/middleware"
+	"github.com/you/linkedinify/internal/normalize"
+	"github.com/you/linkedinify/internal/service"
+)
+
+// maxSuggestions caps the 'limit' parameter.
+const maxSuggestions = 20
+
+type SuggestHandler struct {
+	svc        service.SuggestServiceInteractor
+	normalizer *normalize.Normalizer
+}
+
+// NewSuggest creates a SuggestHandler that prepares text with n, as
+// transforms do, so suggestions are made from the text a transform would
+// use.
+func NewSuggest(svc service.SuggestServiceInteractor, n *normalize.Normalizer) *SuggestHandler {
+	return &SuggestHandler{svc: svc, normalizer: n}
+}
+
+func (h *SuggestHandler) Routes(secret []byte) chi.Router {
+	r := chi.NewRouter()
+	r.Use(middleware.Auth(secret))
+	r.Get("/hashtags", h.hashtags)
+	return r
+}
+
+type suggestResponse struct {
+	Hashtags []hashtag.Suggestion `json:"hashtags"`
+	Mentions []hashtag.Mention    `json:"mentions"`
+}
+
+func (h *SuggestHandler) hashtags(w http.ResponseWriter, r *http.Request) {
+	text, _, err := h.normalizer.Normalize(r.URL.Query().Get("text"))
+	var tooLong *normalize.TooLongError
+	if errors.As(err, &tooLong) {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("The 'text' parameter must be at most %d characters", tooLong.Max))
+		return
+	}
+	if text == "" {
+		respondError(w, http.StatusBadRequest, "The 'text' parameter is required")
+		return
+	}
+	limit := hashtag.DefaultLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxSuggestions {
+			respondError(w, http.StatusBadRequest, "The 'limit' parameter must be between 1 and 20")
+			return
+		}
+		limit = n
+	}
+
+	s, err := h.svc.Hashtags(r.Context(), middleware.UserID(r.Context()), text, limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to suggest hashtags")
+		return
+	}
+	res := suggestResponse{Hashtags: s.Hashtags, Mentions: s.Mentions}
+	if res.Hashtags == nil {
+		res.Hashtags = []hashtag.Suggestion{}
+	}
+	if res.Mentions == nil {
+		res.Mentions = []hashtag.Mention{}
+	}
+	respondJSON(w, http.StatusOK, res)
+}
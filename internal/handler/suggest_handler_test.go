@@ -0,0 +1,67 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/handler"
+	"github.com/you/linkedinify/internal/hashtag"
+	"github.com/you/linkedinify/internal/normalize"
+	"github.com/you/linkedinify/internal/service"
+)
+
+func TestSuggestHandler_Hashtags(t *testing.T) {
+	testUserID := uuid.New()
+	testSecret := []byte("your-test-jwt-secret")
+	mockService := &service.SuggestServiceInteractorMock{
+		HashtagsFunc: func(ctx context.Context, userID uuid.UUID, text string, limit int) (*service.Suggestions, error) {
+			assert.Equal(t, testUserID, userID)
+			assert.Equal(t, "Shipped with Acme Corp", text)
+			assert.Equal(t, 3, limit)
+			return &service.Suggestions{Hashtags: []hashtag.Suggestion{{Tag: "Shipping", Score: 3, Sources: []string{hashtag.SourceText}}}}, nil
+		},
+	}
+	server := httptest.NewServer(handler.NewSuggest(mockService, normalize.New(0)).Routes(testSecret))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/hashtags?limit=3&text="+url.QueryEscape("<b>Shipped</b> with **Acme Corp**"), nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+generateTestToken(t, testUserID, testSecret))
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var res map[string][]map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+	require.Len(t, res["hashtags"], 1)
+	assert.Equal(t, "Shipping", res["hashtags"][0]["tag"])
+	assert.NotNil(t, res["mentions"], "an empty list, not null")
+}
+
+func TestSuggestHandler_Hashtags_BadRequest(t *testing.T) {
+	testUserID := uuid.New()
+	testSecret := []byte("your-test-jwt-secret")
+	server := httptest.NewServer(handler.NewSuggest(&service.SuggestServiceInteractorMock{}, normalize.New(10)).Routes(testSecret))
+	defer server.Close()
+
+	for _, query := range []string{"", "text=+", "text=hi&limit=0", "text=hi&limit=21", "text=hi&limit=x", "text=%3Cp%3E%3C%2Fp%3E", "text=01234567890"} {
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/hashtags?"+query, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+generateTestToken(t, testUserID, testSecret))
+
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+	}
+}
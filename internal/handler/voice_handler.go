@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/middleware"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/service"
+)
+
+type VoiceHandler struct {
+	svc service.VoiceServiceInteractor
+}
+
+func NewVoice(svc service.VoiceServiceInteractor) *VoiceHandler {
+	return &VoiceHandler{svc: svc}
+}
+
+func (h *VoiceHandler) Routes(secret []byte) chi.Router {
+	r := chi.NewRouter()
+	r.Use(middleware.Auth(secret))
+	r.Post("/", h.create)
+	r.Get("/", h.list)
+	r.Get("/{id}", h.get)
+	r.Delete("/{id}", h.delete)
+	return r
+}
+
+type voiceRequest struct {
+	Name     string   `json:"name"`
+	Examples []string `json:"examples"`
+}
+
+type voiceResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Guidance  string    `json:"guidance"`
+	Examples  []string  `json:"examples"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newVoiceResponse(v *model.VoiceProfile) voiceResponse {
+	return voiceResponse{ID: v.ID, Name: v.Name, Guidance: v.Guidance, Examples: v.Examples, CreatedAt: v.CreatedAt}
+}
+
+func (h *VoiceHandler) create(w http.ResponseWriter, r *http.Request) {
+	var in voiceRequest
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	v, err := h.svc.Create(r.Context(), middleware.UserID(r.Context()), in.Name, in.Examples)
+	if errors.Is(err, service.ErrInvalidVoiceProfile) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if respondRejection(w, err) || respondAIError(w, err) {
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to create voice profile")
+		return
+	}
+	respondJSON(w, http.StatusCreated, newVoiceResponse(v))
+}
+
+func (h *VoiceHandler) list(w http.ResponseWriter, r *http.Request) {
+	voices, err := h.svc.List(r.Context(), middleware.UserID(r.Context()))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list voice profiles")
+		return
+	}
+	res := make([]voiceResponse, 0, len(voices))
+	for i := range voices {
+		res = append(res, newVoiceResponse(&voices[i]))
+	}
+	respondJSON(w, http.StatusOK, res)
+}
+
+func (h *VoiceHandler) get(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Voice profile not found")
+		return
+	}
+	v, err := h.svc.Get(r.Context(), middleware.UserID(r.Context()), id)
+	if errors.Is(err, service.ErrVoiceProfileNotFound) {
+		respondError(w, http.StatusNotFound, "Voice profile not found")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to load voice profile")
+		return
+	}
+	respondJSON(w, http.StatusOK, newVoiceResponse(v))
+}
+
+func (h *VoiceHandler) delete(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Voice profile not found")
+		return
+	}
+	err = h.svc.Delete(r.Context(), middleware.UserID(r.Context()), id)
+	if errors.Is(err, service.ErrVoiceProfileNotFound) {
+		respondError(w, http.StatusNotFound, "Voice profile not found")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to delete voice profile")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,90 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/handler"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/service"
+)
+
+func TestVoiceHandler_Create_Success(t *testing.T) {
+	testUserID := uuid.New()
+	testSecret := []byte("your-test-jwt-secret")
+	mockService := &service.VoiceServiceInteractorMock{
+		CreateFunc: func(ctx context.Context, userID uuid.UUID, name string, examples []string) (*model.VoiceProfile, error) {
+			assert.Equal(t, testUserID, userID)
+			return &model.VoiceProfile{ID: uuid.New(), UserID: userID, Name: name, Examples: examples, Guidance: "terse"}, nil
+		},
+	}
+	server := httptest.NewServer(handler.NewVoice(mockService).Routes(testSecret))
+	defer server.Close()
+
+	body, _ := json.Marshal(map[string]interface{}{"name": "mine", "examples": []string{"a", "b", "c", "d", "e"}})
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/", bytes.NewBuffer(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+generateTestToken(t, testUserID, testSecret))
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	var res map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+	assert.Equal(t, "terse", res["guidance"])
+}
+
+func TestVoiceHandler_Create_ValidationError(t *testing.T) {
+	testUserID := uuid.New()
+	testSecret := []byte("your-test-jwt-secret")
+	mockService := &service.VoiceServiceInteractorMock{
+		CreateFunc: func(ctx context.Context, userID uuid.UUID, name string, examples []string) (*model.VoiceProfile, error) {
+			return nil, fmt.Errorf("%w: provide between 5 and 10 example posts, got 1", service.ErrInvalidVoiceProfile)
+		},
+	}
+	server := httptest.NewServer(handler.NewVoice(mockService).Routes(testSecret))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/", bytes.NewBufferString(`{"name":"mine","examples":["a"]}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+generateTestToken(t, testUserID, testSecret))
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestVoiceHandler_Delete_NotFound(t *testing.T) {
+	testUserID := uuid.New()
+	testSecret := []byte("your-test-jwt-secret")
+	mockService := &service.VoiceServiceInteractorMock{
+		DeleteFunc: func(ctx context.Context, userID, id uuid.UUID) error {
+			return service.ErrVoiceProfileNotFound
+		},
+	}
+	server := httptest.NewServer(handler.NewVoice(mockService).Routes(testSecret))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodDelete, server.URL+"/"+uuid.NewString(), nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+generateTestToken(t, testUserID, testSecret))
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
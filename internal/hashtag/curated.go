@@ -0,0 +1,104 @@
+package hashtag
+
+import "strings"
+
+// industries maps words that identify an industry in a profile to the
+// hashtags its audience follows. A profile matches every entry whose key
+// occurs in its industry, so "fintech startup" gets finance and startup
+// tags.
+var industries = []struct {
+	keys []string
+	tags []string
+}{
+	{[]string{"software", "tech", "technology", "information technology", "saas", "engineering", "developer"}, []string{"SoftwareEngineering", "Tech", "Programming", "DevOps", "Cloud"}},
+	{[]string{"ai", "machine learning", "data"}, []string{"AI", "MachineLearning", "DataScience", "GenerativeAI", "Analytics"}},
+	{[]string{"fintech", "finance", "financial", "bank", "banking", "insurance", "accounting"}, []string{"Finance", "Fintech", "Banking", "Investing", "Payments"}},
+	{[]string{"health", "healthcare", "medical", "pharma", "biotech"}, []string{"Healthcare", "HealthTech", "DigitalHealth", "Pharma", "PatientCare"}},
+	{[]string{"marketing", "advertising", "brand", "media"}, []string{"Marketing", "DigitalMarketing", "Branding", "ContentMarketing", "SocialMedia"}},
+	{[]string{"sales", "business development"}, []string{"Sales", "B2B", "SalesLeadership", "CustomerSuccess", "Negotiation"}},
+	{[]string{"recruit", "talent", "human resources", "hr", "people"}, []string{"HR", "Recruiting", "Hiring", "TalentAcquisition", "FutureOfWork"}},
+	{[]string{"education", "edtech", "university", "school", "teaching"}, []string{"Education", "EdTech", "Learning", "HigherEducation", "Teaching"}},
+	{[]string{"retail", "e-commerce", "ecommerce", "consumer"}, []string{"Retail", "Ecommerce", "CustomerExperience", "Omnichannel", "CPG"}},
+	{[]string{"startup", "venture", "founder"}, []string{"Startups", "Entrepreneurship", "VentureCapital", "Founders", "Innovation"}},
+	{[]string{"design", "ux", "product"}, []string{"ProductManagement", "UXDesign", "Design", "ProductDesign", "UserResearch"}},
+	{[]string{"security", "cyber"}, []string{"Cybersecurity", "InfoSec", "SecurityAwareness", "ZeroTrust", "Privacy"}},
+	{[]string{"manufacturing", "logistics", "supply chain", "automotive"}, []string{"Manufacturing", "SupplyChain", "Logistics", "Industry40", "Operations"}},
+	{[]string{"energy", "climate", "sustainab"}, []string{"Sustainability", "ClimateTech", "RenewableEnergy", "ESG", "NetZero"}},
+	{[]string{"consult"}, []string{"Consulting", "Strategy", "DigitalTransformation", "ChangeManagement", "Leadership"}},
+	{[]string{"legal", "law"}, []string{"Legal", "LegalTech", "Compliance", "Law", "Regulation"}},
+	{[]string{"real estate", "property", "construction"}, []string{"RealEstate", "PropTech", "Construction", "Architecture", "Housing"}},
+}
+
+// general tags suit anyone and fill in for unknown industries.
+var general = []string{"Leadership", "CareerGrowth", "Innovation"}
+
+// ForIndustry returns the curated hashtags for a free-text industry, most
+// specific first, followed by the general ones.
+func ForIndustry(industry string) []string {
+	padded := " " + strings.ToLower(industry) + " "
+	var tags []string
+	seen := map[string]bool{}
+	for _, ind := range industries {
+		for _, key := range ind.keys {
+			if !containsWord(padded, key) {
+				continue
+			}
+			for _, t := range ind.tags {
+				if !seen[t] {
+					seen[t] = true
+					tags = append(tags, t)
+				}
+			}
+			break
+		}
+	}
+	for _, t := range general {
+		if !seen[t] {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// containsWord matches key as a word of padded. Keys of five letters or
+// more also match the start of a word, so "sustainab" matches
+// "sustainability", while "ai" matches neither "retail" nor "airline".
+func containsWord(padded, key string) bool {
+	for i := 0; ; {
+		j := strings.Index(padded[i:], key)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(key)
+		if isWordBoundary(padded[start-1]) && (len(key) >= 5 || isWordBoundary(padded[end])) {
+			return true
+		}
+		i = start + 1
+	}
+}
+
+func isWordBoundary(c byte) bool {
+	return c == ' ' || c == '-' || c == '/' || c == ',' || c == '&'
+}
+
+// stopwords are English function words and the filler of LinkedIn posts,
+// which make poor hashtags.
+var stopwords = map[string]bool{}
+
+func init() {
+	for _, w := range strings.Fields(`
+		a about above after again against all also am an and any are as at be because been before being
+		below between both but by can could did do does doing down during each few for from further had
+		has have having he her here hers herself him himself his how i if in into is it its itself just
+		let me more most my myself no nor not now of off on once only or other our ours ourselves out
+		over own same she should so some such than that the their theirs them themselves then there these
+		they this those through to too under until up very was we were what when where which while who
+		whom why will with would you your yours yourself yourselves
+		really thing things lot lots get got getting make made making much many one two three today
+		yesterday tomorrow week weeks year years day days time times new just still even ever every
+		want wanted need needed know think thought see saw say said way ways well back going
+		excited thrilled proud happy share sharing announce announcing journey humbled grateful amazing
+		great incredible awesome team teams people work working worked`) {
+		stopwords[w] = true
+	}
+}
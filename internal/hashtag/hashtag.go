@@ -0,0 +1,284 @@
+// Package hashtag suggests hashtags and mentions for a post without asking
+// the AI provider: keywords are extracted from the text locally and ranked
+// together with the author's own hashtags and a curated list for their
+// industry.
+package hashtag
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Sources a suggestion can come from.
+const (
+	SourceText     = "text"
+	SourceHistory  = "history"
+	SourceIndustry = "industry"
+)
+
+// Score weights. Relevance to the text matters most; a tag the author
+// already uses beats an industry staple they have never used.
+const (
+	textWeight     = 3.0
+	historyWeight  = 2.0
+	industryWeight = 1.0
+)
+
+// Input is what suggestions are made from.
+type Input struct {
+	Text     string
+	Industry string
+	// History counts the hashtags in the author's earlier posts, keyed
+	// without '#'.
+	History map[string]int
+	Limit   int
+}
+
+// Suggestion is a ranked hashtag, without '#'.
+type Suggestion struct {
+	Tag     string   `json:"tag"`
+	Score   float64  `json:"score"`
+	Sources []string `json:"sources"`
+}
+
+// Keyword is a word or two-word phrase from the text with its weight, the
+// heaviest being 1.
+type Keyword struct {
+	Phrase string
+	Weight float64
+}
+
+// DefaultLimit is the number of suggestions made when Input.Limit is not
+// positive.
+const DefaultLimit = 5
+
+var wordPattern = regexp.MustCompile(`[\p{L}\p{N}][\p{L}\p{N}'+.-]*[\p{L}\p{N}+]|[\p{L}\p{N}]`)
+
+// Keywords returns up to n keywords of text, heaviest first. Words are
+// weighed by how often they occur, with a bonus for capitalized words
+// that do not start a sentence; stopwords, numbers and words shorter than
+// three letters other than acronyms are skipped. A pair of keywords that
+// occurs twice or more is a keyword too.
+func Keywords(text string, n int) []Keyword {
+	type counted struct {
+		display string
+		weight  float64
+		first   int
+	}
+	counts := map[string]*counted{}
+	add := func(key, display string, w float64, pos int) {
+		if c, ok := counts[key]; ok {
+			c.weight += w
+			return
+		}
+		counts[key] = &counted{display: display, weight: w, first: pos}
+	}
+
+	var prev string
+	for _, sentence := range splitSentences(text) {
+		prev = ""
+		for i, loc := range wordPattern.FindAllStringIndex(sentence.text, -1) {
+			word := strings.Trim(sentence.text[loc[0]:loc[1]], "'.-")
+			if word == "" {
+				continue
+			}
+			if loc[0] > 0 && sentence.text[loc[0]-1] == '#' {
+				prev = ""
+				continue // existing hashtags are the author's choice already
+			}
+			key := strings.ToLower(word)
+			if !isKeyword(word) {
+				prev = ""
+				continue
+			}
+			w, display := 1.0, word
+			if i > 0 && startsUpper(word) {
+				w += 0.5
+			} else if i == 0 && word[1:] == strings.ToLower(word[1:]) {
+				display = key // only capitalized for starting the sentence
+			}
+			add(key, display, w, sentence.offset+loc[0])
+			if prev != "" {
+				add(prev+" "+key, "", 0, sentence.offset+loc[0])
+				counts[prev+" "+key].weight++
+			}
+			prev = key
+		}
+	}
+
+	// A repeated phrase says more than its words, and takes over their
+	// occurrences so "machine learning" does not also yield "machine".
+	for key, c := range counts {
+		first, second, isPhrase := strings.Cut(key, " ")
+		if !isPhrase || c.weight < 2 {
+			continue
+		}
+		counts[first].weight -= c.weight
+		counts[second].weight -= c.weight
+		c.weight *= 1.5
+	}
+
+	var all []Keyword
+	var firsts = map[string]int{}
+	for key, c := range counts {
+		if c.weight <= 0 || strings.Contains(key, " ") && c.weight < 3 {
+			continue
+		}
+		phrase := c.display
+		if phrase == "" {
+			phrase = key
+		}
+		all = append(all, Keyword{Phrase: phrase, Weight: c.weight})
+		firsts[phrase] = c.first
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Weight != all[j].Weight {
+			return all[i].Weight > all[j].Weight
+		}
+		return firsts[all[i].Phrase] < firsts[all[j].Phrase]
+	})
+	if n > 0 && len(all) > n {
+		all = all[:n]
+	}
+	if len(all) > 0 {
+		top := all[0].Weight
+		for i := range all {
+			all[i].Weight /= top
+		}
+	}
+	return all
+}
+
+type sentence struct {
+	text   string
+	offset int
+}
+
+var sentenceBreak = regexp.MustCompile(`[.!?]+(?:\s+|$)|\n+`)
+
+func splitSentences(text string) []sentence {
+	var out []sentence
+	last := 0
+	for _, loc := range sentenceBreak.FindAllStringIndex(text, -1) {
+		out = append(out, sentence{text[last:loc[0]], last})
+		last = loc[1]
+	}
+	return append(out, sentence{text[last:], last})
+}
+
+// isKeyword rejects stopwords, numbers, lowercase past tenses and short
+// words, except for acronyms such as "AI" or "HR".
+func isKeyword(word string) bool {
+	lower := strings.ToLower(word)
+	if stopwords[lower] || !strings.ContainsFunc(word, unicode.IsLetter) {
+		return false
+	}
+	n := utf8.RuneCountInString(word)
+	if n < 3 {
+		return n == 2 && word == strings.ToUpper(word)
+	}
+	// Past tenses ("shipped", "moved") tell what happened, not what about.
+	pastTense := n > 4 && strings.HasSuffix(word, "ed") && !strings.HasSuffix(word, "eed")
+	return !pastTense
+}
+
+func startsUpper(word string) bool {
+	r, _ := utf8.DecodeRuneInString(word)
+	return unicode.IsUpper(r)
+}
+
+// Tag turns a phrase into a hashtag without '#': words are capitalized and
+// joined, and anything LinkedIn would not link is dropped, so "machine
+// learning" becomes "MachineLearning" and "Node.js" becomes "NodeJs".
+func Tag(phrase string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(phrase, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		first, size := utf8.DecodeRuneInString(word)
+		if word == strings.ToLower(word) {
+			b.WriteRune(unicode.ToUpper(first))
+			b.WriteString(word[size:])
+		} else {
+			b.WriteString(word) // keep "OpenAI" and "SaaS" as written
+		}
+	}
+	return b.String()
+}
+
+// Suggest ranks hashtags for in.Text. Every keyword, history tag and
+// industry tag is a candidate; its score adds the keyword weight, how
+// often the author has used it relative to their favourite, and whether it
+// is an industry staple, weighted in that order. History and industry tags
+// the text has nothing to do with still rank, below relevant ones.
+func Suggest(in Input) []Suggestion {
+	limit := in.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	type candidate struct {
+		tag     string
+		score   float64
+		sources []string
+	}
+	candidates := map[string]*candidate{}
+	var order []string
+	add := func(tag, source string, score float64) {
+		key := strings.ToLower(tag)
+		if utf8.RuneCountInString(key) < 2 {
+			return
+		}
+		c, ok := candidates[key]
+		if !ok {
+			c = &candidate{tag: tag}
+			candidates[key] = c
+			order = append(order, key)
+		}
+		// The author's own spelling wins, then the curated one.
+		if source == SourceHistory || source == SourceIndustry && !slices.Contains(c.sources, SourceHistory) {
+			c.tag = tag
+		}
+		c.score += score
+		if len(c.sources) == 0 || c.sources[len(c.sources)-1] != source {
+			c.sources = append(c.sources, source)
+		}
+	}
+
+	for _, k := range Keywords(in.Text, 3*limit) {
+		add(Tag(k.Phrase), SourceText, textWeight*k.Weight)
+	}
+	top := 0
+	for _, n := range in.History {
+		top = max(top, n)
+	}
+	historyTags := make([]string, 0, len(in.History))
+	for tag := range in.History {
+		historyTags = append(historyTags, tag)
+	}
+	sort.Strings(historyTags) // map order must not decide ties
+	for _, tag := range historyTags {
+		add(tag, SourceHistory, historyWeight*float64(in.History[tag])/float64(top))
+	}
+	for _, tag := range ForIndustry(in.Industry) {
+		add(tag, SourceIndustry, industryWeight)
+	}
+
+	suggestions := make([]Suggestion, 0, len(order))
+	for _, key := range order {
+		c := candidates[key]
+		suggestions = append(suggestions, Suggestion{Tag: c.tag, Score: round(c.score), Sources: c.sources})
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool { return suggestions[i].Score > suggestions[j].Score })
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+func round(f float64) float64 {
+	return float64(int(f*100+0.5)) / 100
+}
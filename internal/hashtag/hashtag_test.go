@@ -0,0 +1,63 @@
+package hashtag_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/you/linkedinify/internal/hashtag"
+)
+
+const note = "We moved our billing service to Kubernetes. Kubernetes made deploys boring, in a good way. " +
+	"Machine learning forecasts now size the cluster, and machine learning saved us 30% on AI inference. #Cloud"
+
+func TestKeywords(t *testing.T) {
+	var phrases []string
+	for _, k := range hashtag.Keywords(note, 4) {
+		phrases = append(phrases, k.Phrase)
+	}
+	assert.Equal(t, []string{"machine learning", "Kubernetes", "AI", "billing"}, phrases)
+}
+
+func TestTag(t *testing.T) {
+	assert.Equal(t, "MachineLearning", hashtag.Tag("machine learning"))
+	assert.Equal(t, "OpenAI", hashtag.Tag("OpenAI"))
+	assert.Equal(t, "NodeJs", hashtag.Tag("Node.js"))
+}
+
+func TestSuggest_RanksTextHistoryAndIndustry(t *testing.T) {
+	got := hashtag.Suggest(hashtag.Input{
+		Text:     note,
+		Industry: "Fintech startup",
+		History:  map[string]int{"kubernetes": 4, "Payments": 2, "DevLife": 1},
+		Limit:    6,
+	})
+
+	assert.Equal(t, []hashtag.Suggestion{
+		{Tag: "kubernetes", Score: 4.5, Sources: []string{hashtag.SourceText, hashtag.SourceHistory}},
+		{Tag: "MachineLearning", Score: 3, Sources: []string{hashtag.SourceText}},
+	}, got[:2], "the author's spelling wins")
+	var tags []string
+	for _, s := range got {
+		tags = append(tags, s.Tag)
+	}
+	assert.Equal(t, []string{"kubernetes", "MachineLearning", "Payments", "AI", "Billing", "Service"}, tags)
+}
+
+func TestForIndustry(t *testing.T) {
+	assert.Equal(t, []string{"Leadership", "CareerGrowth", "Innovation"}, hashtag.ForIndustry("Airline"), `"ai" is not a prefix match`)
+	assert.Equal(t, []string{"Sustainability", "ClimateTech", "RenewableEnergy", "ESG", "NetZero", "Leadership", "CareerGrowth", "Innovation"}, hashtag.ForIndustry("sustainability"))
+	assert.Contains(t, hashtag.ForIndustry("Fintech / Payments"), "Fintech")
+}
+
+func TestMentions(t *testing.T) {
+	text := "Thanks to Jane Doe and the folks at Bank of America for the pilot. " +
+		"Huge thanks @acme-labs! Acme shipped it on Monday. We used AI and OpenAI models."
+
+	assert.Equal(t, []hashtag.Mention{
+		{Name: "acme-labs", Placeholder: "@[acme-labs]"},
+		{Name: "Jane Doe", Placeholder: "@[Jane Doe]"},
+		{Name: "Bank of America", Placeholder: "@[Bank of America]"},
+		{Name: "OpenAI", Placeholder: "@[OpenAI]"},
+	}, hashtag.Mentions(text))
+}
@@ -0,0 +1,86 @@
+package hashtag
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Mention is a person or organization named in the text. LinkedIn only
+// links a mention picked from its own search, so the post gets a
+// placeholder the author replaces there.
+type Mention struct {
+	Name        string `json:"name"`
+	Placeholder string `json:"placeholder"`
+}
+
+var (
+	// namePattern finds runs of capitalized words, allowing "of", "de" and
+	// the like between them ("Bank of America") and inner capitals
+	// ("OpenAI").
+	namePattern   = regexp.MustCompile(`\p{Lu}[\p{L}\p{N}&'.-]*(?:\s+(?:(?:of|de|van|von|der|la|du|&)\s+)?\p{Lu}[\p{L}\p{N}&'.-]*)*`)
+	handlePattern = regexp.MustCompile(`(?:^|\s)@([\p{L}\p{N}_.-]+)`)
+)
+
+// notNames are capitalized words that start sentences or name days and
+// months rather than anyone to mention.
+var notNames = map[string]bool{}
+
+func init() {
+	for _, w := range strings.Fields(`I I'm I've We Our Us You Your They Their It Its This That These Those
+		The A An And But Or So If When While After Before Today Tomorrow Yesterday Here There What Why How
+		Monday Tuesday Wednesday Thursday Friday Saturday Sunday January February March April May June July
+		August September October November December Thanks Thank Congrats Congratulations Excited Proud Happy
+		Just Big Huge New Great Last Next First Every Let Let's Hello Hi Hey`) {
+		notNames[w] = true
+	}
+}
+
+// Mentions returns the people and organizations text names, in order:
+// existing @handles, and capitalized names that do not start a sentence
+// or are longer than one word. Only the first five are returned.
+func Mentions(text string) []Mention {
+	var mentions []Mention
+	seen := map[string]bool{}
+	add := func(name string) {
+		key := strings.ToLower(name)
+		if name == "" || seen[key] || len(mentions) == 5 {
+			return
+		}
+		seen[key] = true
+		mentions = append(mentions, Mention{Name: name, Placeholder: "@[" + name + "]"})
+	}
+
+	for _, m := range handlePattern.FindAllStringSubmatch(text, -1) {
+		add(strings.TrimRight(m[1], ".-"))
+	}
+	for _, s := range splitSentences(text) {
+		for _, loc := range namePattern.FindAllStringIndex(s.text, -1) {
+			if loc[0] > 0 && strings.ContainsRune("#@", rune(s.text[loc[0]-1])) {
+				continue // hashtags and handles
+			}
+			words := strings.Fields(strings.TrimRight(s.text[loc[0]:loc[1]], ".'-"))
+			// Leading sentence words such as "Thanks" are not part of a name.
+			for len(words) > 0 && notNames[words[0]] {
+				words = words[1:]
+			}
+			for len(words) > 0 && notNames[words[len(words)-1]] {
+				words = words[:len(words)-1]
+			}
+			if len(words) == 0 {
+				continue
+			}
+			startsSentence := strings.TrimSpace(s.text[:loc[0]]) == "" && len(words) == len(strings.Fields(s.text[loc[0]:loc[1]]))
+			if len(words) == 1 && (startsSentence || !isKeyword(words[0]) || isAcronym(words[0])) {
+				continue
+			}
+			add(strings.Join(words, " "))
+		}
+	}
+	return mentions
+}
+
+// isAcronym reports whether word is a short all-caps word such as "AI" or
+// "CEO", which names a topic or role more often than anyone to mention.
+func isAcronym(word string) bool {
+	return len(word) <= 3 && word == strings.ToUpper(word)
+}
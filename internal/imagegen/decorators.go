@@ -0,0 +1,91 @@
+package imagegen
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/you/linkedinify/internal/ai"
+)
+
+// resilientGenerator retries, times out and circuit-breaks Generate calls.
+type resilientGenerator struct {
+	ImageGenerator
+	r *ai.Resilience
+}
+
+// WithResilience decorates g with the per-attempt timeouts, retries and
+// circuit breaker of ai.WithResilience, as configured by cfg. The breaker
+// is g's own.
+func WithResilience(g ImageGenerator, cfg ai.ResilienceConfig) ImageGenerator {
+	return &resilientGenerator{ImageGenerator: g, r: ai.NewResilience(cfg)}
+}
+
+func (g *resilientGenerator) Generate(ctx context.Context, req Request) (*Image, error) {
+	var img *Image
+	err := g.r.Do(ctx, func(ctx context.Context) error {
+		var err error
+		img, err = g.ImageGenerator.Generate(ctx, req)
+		return err
+	})
+	return img, err
+}
+
+// measuredGenerator reports latency and errors of every Generate call.
+type measuredGenerator struct {
+	ImageGenerator
+	model string
+	rec   ai.MetricsRecorder
+}
+
+// WithMetrics decorates g so each image generation is reported to rec as
+// an AI call under g's name and model.
+func WithMetrics(g ImageGenerator, model string, rec ai.MetricsRecorder) ImageGenerator {
+	return &measuredGenerator{ImageGenerator: g, model: model, rec: rec}
+}
+
+func (g *measuredGenerator) Generate(ctx context.Context, req Request) (*Image, error) {
+	start := time.Now()
+	img, err := g.ImageGenerator.Generate(ctx, req)
+	g.rec.ObserveAICall(g.Name(), g.model, "generate_image", time.Since(start), err)
+	return img, err
+}
+
+// tracedGenerator records a span around every Generate call.
+type tracedGenerator struct {
+	ImageGenerator
+	tracer trace.Tracer
+	attrs  []attribute.KeyValue
+}
+
+// WithTracing decorates g so each image generation is recorded as a client
+// span tagged with g's name and model.
+func WithTracing(g ImageGenerator, model string) ImageGenerator {
+	return &tracedGenerator{
+		ImageGenerator: g,
+		tracer:         otel.Tracer("github.com/you/linkedinify/internal/imagegen"),
+		attrs: []attribute.KeyValue{
+			attribute.String("gen_ai.system", g.Name()),
+			attribute.String("gen_ai.request.model", model),
+		},
+	}
+}
+
+func (g *tracedGenerator) Generate(ctx context.Context, req Request) (*Image, error) {
+	ctx, span := g.tracer.Start(ctx, "imagegen.Generate",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(g.attrs...),
+		trace.WithAttributes(attribute.Int("imagegen.topics", len(req.Topics))),
+	)
+	img, err := g.ImageGenerator.Generate(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+	return img, err
+}
@@ -0,0 +1,155 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package imagegen
+
+import (
+	"context"
+	"sync"
+)
+
+// Ensure, that ImageGeneratorMock does implement ImageGenerator.
+// If this is not the case, regenerate this file with moq.
+var _ ImageGenerator = &ImageGeneratorMock{}
+
+// ImageGeneratorMock is a mock implementation of ImageGenerator.
+//
+//	func TestSomethingThatUsesImageGenerator(t *testing.T) {
+//
+//		// make and configure a mocked ImageGenerator
+//		mockedImageGenerator := &ImageGeneratorMock{
+//			GenerateFunc: func(ctx context.Context, req Request) (*Image, error) {
+//				panic("mock out the Generate method")
+//			},
+//			LocalFunc: func() bool {
+//				panic("mock out the Local method")
+//			},
+//			NameFunc: func() string {
+//				panic("mock out the Name method")
+//			},
+//		}
+//
+//		// use mockedImageGenerator in code that requires ImageGenerator
+//		// and then make assertions.
+//
+//	}
+type ImageGeneratorMock struct {
+	// GenerateFunc mocks the Generate method.
+	GenerateFunc func(ctx context.Context, req Request) (*Image, error)
+
+	// LocalFunc mocks the Local method.
+	LocalFunc func() bool
+
+	// NameFunc mocks the Name method.
+	NameFunc func() string
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Generate holds details about calls to the Generate method.
+		Generate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req Request
+		}
+		// Local holds details about calls to the Local method.
+		Local []struct {
+		}
+		// Name holds details about calls to the Name method.
+		Name []struct {
+		}
+	}
+	lockGenerate sync.RWMutex
+	lockLocal    sync.RWMutex
+	lockName     sync.RWMutex
+}
+
+// Generate calls GenerateFunc.
+func (mock *ImageGeneratorMock) Generate(ctx context.Context, req Request) (*Image, error) {
+	if mock.GenerateFunc == nil {
+		panic("ImageGeneratorMock.GenerateFunc: method is nil but ImageGenerator.Generate was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req Request
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockGenerate.Lock()
+	mock.calls.Generate = append(mock.calls.Generate, callInfo)
+	mock.lockGenerate.Unlock()
+	return mock.GenerateFunc(ctx, req)
+}
+
+// GenerateCalls gets all the calls that were made to Generate.
+// Check the length with:
+//
+//	len(mockedImageGenerator.GenerateCalls())
+func (mock *ImageGeneratorMock) GenerateCalls() []struct {
+	Ctx context.Context
+	Req Request
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req Request
+	}
+	mock.lockGenerate.RLock()
+	calls = mock.calls.Generate
+	mock.lockGenerate.RUnlock()
+	return calls
+}
+
+// Local calls LocalFunc.
+func (mock *ImageGeneratorMock) Local() bool {
+	if mock.LocalFunc == nil {
+		panic("ImageGeneratorMock.LocalFunc: method is nil but ImageGenerator.Local was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockLocal.Lock()
+	mock.calls.Local = append(mock.calls.Local, callInfo)
+	mock.lockLocal.Unlock()
+	return mock.LocalFunc()
+}
+
+// LocalCalls gets all the calls that were made to Local.
+// Check the length with:
+//
+//	len(mockedImageGenerator.LocalCalls())
+func (mock *ImageGeneratorMock) LocalCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockLocal.RLock()
+	calls = mock.calls.Local
+	mock.lockLocal.RUnlock()
+	return calls
+}
+
+// Name calls NameFunc.
+func (mock *ImageGeneratorMock) Name() string {
+	if mock.NameFunc == nil {
+		panic("ImageGeneratorMock.NameFunc: method is nil but ImageGenerator.Name was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockName.Lock()
+	mock.calls.Name = append(mock.calls.Name, callInfo)
+	mock.lockName.Unlock()
+	return mock.NameFunc()
+}
+
+// NameCalls gets all the calls that were made to Name.
+// Check the length with:
+//
+//	len(mockedImageGenerator.NameCalls())
+func (mock *ImageGeneratorMock) NameCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockName.RLock()
+	calls = mock.calls.Name
+	mock.lockName.RUnlock()
+	return calls
+}
@@ -0,0 +1,48 @@
+// Package imagegen makes images to go with posts: a placeholder renderer
+// that works offline, and an OpenAI image model.
+package imagegen
+
+import "context"
+
+// Default size, LinkedIn's recommended size for images shared in the feed.
+const (
+	DefaultWidth  = 1200
+	DefaultHeight = 627
+)
+
+// Request describes the image wanted for a post.
+type Request struct {
+	// Headline is the text the image is about, usually the post's first
+	// line.
+	Headline string
+	// Topics are keywords of the post, to illustrate.
+	Topics []string
+	// Width and Height default to DefaultWidth and DefaultHeight.
+	Width, Height int
+}
+
+func (r Request) size() (int, int) {
+	w, h := r.Width, r.Height
+	if w <= 0 || h <= 0 {
+		return DefaultWidth, DefaultHeight
+	}
+	return w, h
+}
+
+// Image is a generated image.
+type Image struct {
+	Data        []byte
+	ContentType string
+	// Extension is the file extension for ContentType, with the dot.
+	Extension string
+}
+
+// ImageGenerator makes an image for a post.
+type ImageGenerator interface {
+	Generate(ctx context.Context, req Request) (*Image, error)
+	// Name identifies the generator in stored image records.
+	Name() string
+	// Local reports whether images are made without sending the request
+	// to another service, so that it may carry personal data.
+	Local() bool
+}
@@ -0,0 +1,138 @@
+package imagegen_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/imagegen"
+)
+
+// svgText returns the text of every <text> element, checking on the way
+// that the SVG is well-formed XML.
+func svgText(t *testing.T, data []byte) []string {
+	t.Helper()
+	var texts []string
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	inText := false
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return texts
+		}
+		require.NoError(t, err)
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			inText = tok.Name.Local == "text"
+		case xml.CharData:
+			if inText {
+				texts = append(texts, string(tok))
+			}
+		case xml.EndElement:
+			inText = false
+		}
+	}
+}
+
+func TestPlaceholder_RendersHeadline(t *testing.T) {
+	gen := imagegen.NewPlaceholder()
+
+	img, err := gen.Generate(context.Background(), imagegen.Request{
+		Headline: `We cut deploys from 2 hours to 9 minutes <script>alert("x")</script> & kept our sanity`,
+		Topics:   []string{"DevOps"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "image/svg+xml", img.ContentType)
+	assert.Equal(t, "placeholder", gen.Name())
+	assert.Contains(t, string(img.Data), `width="1200" height="627"`)
+	assert.NotContains(t, string(img.Data), "<script>")
+	texts := svgText(t, img.Data)
+	require.Greater(t, len(texts), 2, "the headline is wrapped")
+	assert.Equal(t, "#DevOps", texts[len(texts)-1])
+	assert.Contains(t, strings.Join(texts, " "), `<script>alert("x")</script> & kept`)
+}
+
+func TestPlaceholder_IsDeterministicAndTruncates(t *testing.T) {
+	gen := imagegen.NewPlaceholder()
+	req := imagegen.Request{Headline: strings.Repeat("word ", 200), Width: 1080, Height: 1080}
+
+	a, err := gen.Generate(context.Background(), req)
+	require.NoError(t, err)
+	b, err := gen.Generate(context.Background(), req)
+	require.NoError(t, err)
+
+	assert.Equal(t, a.Data, b.Data)
+	texts := svgText(t, a.Data)
+	assert.Len(t, texts, 5)
+	assert.True(t, strings.HasSuffix(texts[4], "…"))
+}
+
+// flakyImageAPI serves OpenAI's image endpoint, failing with status the
+// first failures times.
+func flakyImageAPI(t *testing.T, failures, status int) (*httptest.Server, *int) {
+	t.Helper()
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if calls <= failures {
+			w.WriteHeader(status)
+			fmt.Fprint(w, `{"error":{"message":"try again"}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"created":1,"data":[{"b64_json":%q}]}`, base64.StdEncoding.EncodeToString([]byte("png")))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestWithResilience_RetriesUnavailableGenerator(t *testing.T) {
+	server, calls := flakyImageAPI(t, 1, http.StatusServiceUnavailable)
+	g := imagegen.WithResilience(imagegen.NewOpenAI("token", server.URL+"/v1"), ai.ResilienceConfig{MaxRetries: 2})
+
+	img, err := g.Generate(context.Background(), imagegen.Request{Headline: "Shipped"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte("png"), img.Data)
+	assert.Equal(t, 2, *calls)
+	assert.Equal(t, "openai", g.Name())
+	assert.False(t, g.Local())
+}
+
+func TestWithResilience_BreakerStopsCallingGenerator(t *testing.T) {
+	server, calls := flakyImageAPI(t, 10, http.StatusInternalServerError)
+	g := imagegen.WithResilience(imagegen.NewOpenAI("token", server.URL+"/v1"),
+		ai.ResilienceConfig{BreakerThreshold: 1, BreakerCooldown: time.Minute})
+
+	_, err := g.Generate(context.Background(), imagegen.Request{})
+	assert.ErrorIs(t, err, ai.ErrUnavailable)
+	_, err = g.Generate(context.Background(), imagegen.Request{})
+	assert.ErrorIs(t, err, ai.ErrUnavailable)
+	assert.Equal(t, 1, *calls, "the open breaker fails fast")
+}
+
+func TestWithResilience_TimesOutGenerator(t *testing.T) {
+	slow := &imagegen.ImageGeneratorMock{GenerateFunc: func(ctx context.Context, req imagegen.Request) (*imagegen.Image, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}}
+	g := imagegen.WithResilience(slow, ai.ResilienceConfig{Timeout: 10 * time.Millisecond})
+
+	_, err := g.Generate(context.Background(), imagegen.Request{})
+
+	assert.ErrorIs(t, err, ai.ErrUnavailable)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
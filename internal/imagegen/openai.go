@@ -0,0 +1,74 @@
+package imagegen
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sashabaranov/go-openai"
+
+	"github.com/you/linkedinify/internal/ai"
+)
+
+// OpenAIModel is the image model OpenAI generates with.
+const OpenAIModel = openai.CreateImageModelDallE3
+
+// OpenAI generates illustrations with OpenAI's image model. Image models
+// render text poorly, so the prompt asks for an image without any.
+type OpenAI struct {
+	cl *openai.Client
+}
+
+// NewOpenAI creates an OpenAI image generator; baseURL may be empty for
+// OpenAI itself.
+func NewOpenAI(token, baseURL string) ImageGenerator {
+	cfg := openai.DefaultConfig(token)
+	if baseURL != "" {
+		cfg.BaseURL = baseURL
+	}
+	return &OpenAI{cl: openai.NewClientWithConfig(cfg)}
+}
+
+func (o *OpenAI) Name() string { return "openai" }
+func (o *OpenAI) Local() bool  { return false }
+
+func (o *OpenAI) Generate(ctx context.Context, req Request) (*Image, error) {
+	w, h := req.size()
+	size := openai.CreateImageSize1024x1024
+	if w > h {
+		size = openai.CreateImageSize1792x1024
+	} else if h > w {
+		size = openai.CreateImageSize1024x1792
+	}
+	resp, err := o.cl.CreateImage(ctx, openai.ImageRequest{
+		Prompt:         prompt(req),
+		Model:          OpenAIModel,
+		Size:           size,
+		ResponseFormat: openai.CreateImageResponseFormatB64JSON,
+	})
+	if err != nil {
+		return nil, ai.ClassifyOpenAIError(ctx, err)
+	}
+	if len(resp.Data) == 0 {
+		return nil, &ai.ProviderError{Kind: ai.ErrUnavailable, Err: errors.New("openai: no image returned")}
+	}
+	data, err := base64.StdEncoding.DecodeString(resp.Data[0].B64JSON)
+	if err != nil {
+		return nil, fmt.Errorf("openai: decoding image: %w", err)
+	}
+	return &Image{Data: data, ContentType: "image/png", Extension: ".png"}, nil
+}
+
+func prompt(req Request) string {
+	var b strings.Builder
+	b.WriteString("A clean, professional illustration for a LinkedIn post. Do not include any text, letters or logos.")
+	if req.Headline != "" {
+		fmt.Fprintf(&b, " The post opens with: %q.", req.Headline)
+	}
+	if len(req.Topics) > 0 {
+		fmt.Fprintf(&b, " Topics: %s.", strings.Join(req.Topics, ", "))
+	}
+	return b.String()
+}
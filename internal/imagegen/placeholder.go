@@ -0,0 +1,98 @@
+package imagegen
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"unicode/utf8"
+)
+
+// palettes are background gradients; the headline picks one, so the same
+// post always gets the same image.
+var palettes = [][2]string{
+	{"#0a66c2", "#004182"},
+	{"#1d976c", "#0f5f44"},
+	{"#c2410c", "#7c2d12"},
+	{"#6d28d9", "#3b0f8c"},
+	{"#0f766e", "#134e4a"},
+	{"#334155", "#0f172a"},
+}
+
+// Placeholder renders the headline in large type on a coloured gradient as
+// SVG. It needs no network and is the default generator.
+type Placeholder struct{}
+
+// NewPlaceholder creates a Placeholder generator.
+func NewPlaceholder() ImageGenerator { return Placeholder{} }
+
+func (Placeholder) Name() string { return "placeholder" }
+func (Placeholder) Local() bool  { return true }
+
+// Placeholder layout, relative to the image height.
+const (
+	headlineScale = 0.09 // font size
+	maxLines      = 5
+)
+
+func (Placeholder) Generate(ctx context.Context, req Request) (*Image, error) {
+	w, h := req.size()
+	h32 := fnv.New32a()
+	h32.Write([]byte(req.Headline))
+	palette := palettes[h32.Sum32()%uint32(len(palettes))]
+
+	fontSize := int(float64(h) * headlineScale)
+	margin := w / 12
+	// Sans-serif letters average a little over half the font size.
+	perLine := max(8, (w-2*margin)*100/(fontSize*55))
+	lines := wrap(req.Headline, perLine, maxLines)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`, w, h, w, h)
+	fmt.Fprintf(&b, `<defs><linearGradient id="bg" x1="0" y1="0" x2="1" y2="1"><stop offset="0" stop-color="%s"/><stop offset="1" stop-color="%s"/></linearGradient></defs>`, palette[0], palette[1])
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="url(#bg)"/>`, w, h)
+	lineHeight := fontSize * 5 / 4
+	y := (h-lineHeight*len(lines))/2 + fontSize
+	for _, line := range lines {
+		fmt.Fprintf(&b, `<text x="%d" y="%d" font-family="Helvetica, Arial, sans-serif" font-size="%d" font-weight="700" fill="#ffffff">`, margin, y, fontSize)
+		xml.EscapeText(&b, []byte(line))
+		b.WriteString(`</text>`)
+		y += lineHeight
+	}
+	if len(req.Topics) > 0 {
+		topics := "#" + strings.Join(req.Topics, "  #")
+		fmt.Fprintf(&b, `<text x="%d" y="%d" font-family="Helvetica, Arial, sans-serif" font-size="%d" fill="#ffffff" fill-opacity="0.75">`, margin, h-margin/2, fontSize/2)
+		xml.EscapeText(&b, []byte(topics))
+		b.WriteString(`</text>`)
+	}
+	b.WriteString(`</svg>`)
+	return &Image{Data: b.Bytes(), ContentType: "image/svg+xml", Extension: ".svg"}, nil
+}
+
+// wrap breaks text into at most maxLines lines of about width characters,
+// ending with "…" when it does not fit.
+func wrap(text string, width, maxLines int) []string {
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(text) {
+		switch {
+		case line == "":
+			line = word
+		case utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		lines[maxLines-1] += "…"
+	}
+	return lines
+}
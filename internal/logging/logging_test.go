@@ -0,0 +1,65 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/logging"
+)
+
+func TestNew_RedactsSensitiveValues(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, "info", "json")
+	require.NoError(t, err)
+
+	logger.Info("login failed for jane.doe@example.com",
+		"email", "jane.doe@example.com",
+		"password", "hunter2",
+		"api_token", "abc",
+		"header", "Bearer eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.sig",
+		"err", errors.New("no user bob@example.org"),
+	)
+
+	var line map[string]string
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "login failed for j***@example.com", line["msg"])
+	assert.Equal(t, "j***@example.com", line["email"])
+	assert.Equal(t, "[REDACTED]", line["password"])
+	assert.Equal(t, "[REDACTED]", line["api_token"])
+	assert.Equal(t, "Bearer [REDACTED]", line["header"])
+	assert.Equal(t, "no user b***@example.org", line["err"])
+	assert.NotContains(t, buf.String(), "hunter2")
+}
+
+func TestNew_LevelAndFormat(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, "warn", "text")
+	require.NoError(t, err)
+	logger.Info("hidden")
+	logger.Warn("shown")
+	assert.NotContains(t, buf.String(), "hidden")
+	assert.Contains(t, buf.String(), "msg=shown")
+
+	_, err = logging.New(&buf, "loud", "json")
+	assert.Error(t, err)
+	_, err = logging.New(&buf, "info", "xml")
+	assert.Error(t, err)
+}
+
+func TestWith_AddsAttributesToContextLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, "info", "json")
+	require.NoError(t, err)
+
+	ctx := logging.WithContext(context.Background(), logger)
+	ctx = logging.With(ctx, "trace_id", "t-1")
+	logging.FromContext(ctx).Info("hello")
+
+	assert.Contains(t, buf.String(), `"trace_id":"t-1"`)
+}
@@ -0,0 +1,146 @@
+// Package metrics defines the Prometheus metrics exposed on /metrics.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "linkedinify"
+
+// Metrics owns a private registry and every collector the application
+// reports to. It implements ai.MetricsRecorder, service.CacheMetrics and
+// service.LoginMetrics.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	aiCalls    *prometheus.CounterVec
+	aiDuration *prometheus.HistogramVec
+	aiTokens   *prometheus.CounterVec
+
+	cacheHits   prometheus.Counter
+	cacheMisses prometheus.Counter
+	cacheSize   prometheus.Gauge
+
+	logins *prometheus.CounterVec
+}
+
+// New creates and registers all collectors, including the Go runtime and
+// process collectors.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "http_requests_total",
+			Help: "HTTP requests by method, route pattern and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace, Name: "http_request_duration_seconds",
+			Help:    "HTTP request latency by method, route pattern and status code.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		aiCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "ai_requests_total",
+			Help: "AI provider calls by provider, model, operation and outcome (ok or error).",
+		}, []string{"provider", "model", "operation", "outcome"}),
+		aiDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace, Name: "ai_request_duration_seconds",
+			Help:    "AI provider call latency by provider, model and operation.",
+			Buckets: []float64{.25, .5, 1, 2, 4, 8, 16, 32},
+		}, []string{"provider", "model", "operation"}),
+		aiTokens: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "ai_tokens_total",
+			Help: "Tokens consumed by provider, model and type (prompt or completion).",
+		}, []string{"provider", "model", "type"}),
+		cacheHits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace, Name: "transform_cache_hits_total",
+			Help: "Transforms served from the in-memory cache.",
+		}),
+		cacheMisses: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace, Name: "transform_cache_misses_total",
+			Help: "Transforms that had to call the AI provider.",
+		}),
+		cacheSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace, Name: "transform_cache_entries",
+			Help: "Outputs currently held in the transform cache.",
+		}),
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "logins_total",
+			Help: "Login attempts by outcome (success or failure).",
+		}, []string{"outcome"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests, m.httpDuration,
+		m.aiCalls, m.aiDuration, m.aiTokens,
+		m.cacheHits, m.cacheMisses, m.cacheSize,
+		m.logins,
+	)
+	return m
+}
+
+// RegisterDB exposes connection pool statistics of db.
+func (m *Metrics) RegisterDB(db *sql.DB, name string) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// Middleware records request count and latency labelled by the matched chi
+// route pattern, keeping label cardinality bounded regardless of IDs in URLs.
+func (m *Metrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		route := "unmatched"
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			route = rctx.RoutePattern()
+		}
+		labels := prometheus.Labels{"method": r.Method, "route": route, "status": strconv.Itoa(status)}
+		m.httpRequests.With(labels).Inc()
+		m.httpDuration.With(labels).Observe(time.Since(start).Seconds())
+	})
+}
+
+func (m *Metrics) ObserveAICall(provider, model, operation string, duration time.Duration, err error) {
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+	}
+	m.aiCalls.WithLabelValues(provider, model, operation, outcome).Inc()
+	m.aiDuration.WithLabelValues(provider, model, operation).Observe(duration.Seconds())
+}
+
+func (m *Metrics) AddAITokens(provider, model string, promptTokens, completionTokens int) {
+	m.aiTokens.WithLabelValues(provider, model, "prompt").Add(float64(promptTokens))
+	m.aiTokens.WithLabelValues(provider, model, "completion").Add(float64(completionTokens))
+}
+
+func (m *Metrics) CacheHit()             { m.cacheHits.Inc() }
+func (m *Metrics) CacheMiss()            { m.cacheMisses.Inc() }
+func (m *Metrics) CacheSize(entries int) { m.cacheSize.Set(float64(entries)) }
+
+func (m *Metrics) LoginSucceeded() { m.logins.WithLabelValues("success").Inc() }
+func (m *Metrics) LoginFailed()    { m.logins.WithLabelValues("failure").Inc() }
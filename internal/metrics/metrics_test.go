@@ -0,0 +1,106 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/metrics"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/service"
+)
+
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	body, err := io.ReadAll(rr.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestMiddleware_LabelsByRoutePattern(t *testing.T) {
+	m := metrics.New()
+	r := chi.NewRouter()
+	r.Use(m.Middleware)
+	r.Get("/posts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	for _, id := range []string{"1", "2"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/posts/"+id, nil))
+	}
+
+	out := scrape(t, m)
+	assert.Contains(t, out, `linkedinify_http_requests_total{method="GET",route="/posts/{id}",status="404"} 2`)
+	assert.Contains(t, out, `linkedinify_http_request_duration_seconds_count{method="GET",route="/posts/{id}",status="404"} 2`)
+}
+
+func TestMetrics_AICallsAndTokens(t *testing.T) {
+	m := metrics.New()
+	calls := 0
+	client := ai.WithMetrics(&ai.ClientMock{
+		TransformFunc: func(ctx context.Context, text string, opts ai.Options) (string, error) {
+			calls++
+			if calls > 1 {
+				return "", errors.New("rate limited")
+			}
+			return "post", nil
+		},
+	}, "openai", "gpt-4o-mini", m)
+
+	_, _ = client.Transform(context.Background(), "a", ai.Options{})
+	_, _ = client.Transform(context.Background(), "b", ai.Options{})
+	m.AddAITokens("openai", "gpt-4o-mini", 120, 80)
+
+	out := scrape(t, m)
+	assert.Contains(t, out, `linkedinify_ai_requests_total{model="gpt-4o-mini",operation="transform",outcome="ok",provider="openai"} 1`)
+	assert.Contains(t, out, `linkedinify_ai_requests_total{model="gpt-4o-mini",operation="transform",outcome="error",provider="openai"} 1`)
+	assert.Contains(t, out, `linkedinify_ai_tokens_total{model="gpt-4o-mini",provider="openai",type="prompt"} 120`)
+	assert.Contains(t, out, `linkedinify_ai_tokens_total{model="gpt-4o-mini",provider="openai",type="completion"} 80`)
+}
+
+func TestMetrics_TransformCacheAndLogins(t *testing.T) {
+	m := metrics.New()
+	svc := service.NewLinkedIn(
+		&ai.ClientMock{TransformFunc: func(ctx context.Context, text string, opts ai.Options) (string, error) {
+			return "post", nil
+		}},
+		&repository.PostRepositoryMock{SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil }},
+		service.WithCacheMetrics(m),
+	)
+	userID := uuid.New()
+	for i := 0; i < 3; i++ {
+		_, err := svc.Transform(context.Background(), userID, "same input", service.TransformOptions{})
+		require.NoError(t, err)
+	}
+
+	m.LoginSucceeded()
+	m.LoginFailed()
+	m.LoginFailed()
+
+	out := scrape(t, m)
+	assert.Contains(t, out, "linkedinify_transform_cache_misses_total 1")
+	assert.Contains(t, out, "linkedinify_transform_cache_hits_total 2")
+	assert.Contains(t, out, "linkedinify_transform_cache_entries 1")
+	assert.Contains(t, out, `linkedinify_logins_total{outcome="success"} 1`)
+	assert.Contains(t, out, `linkedinify_logins_total{outcome="failure"} 2`)
+}
+
+func TestMetrics_ObservesLatency(t *testing.T) {
+	m := metrics.New()
+	m.ObserveAICall("openai", "gpt-4o-mini", "translate", 3*time.Second, nil)
+	assert.Contains(t, scrape(t, m), `linkedinify_ai_request_duration_seconds_bucket{model="gpt-4o-mini",operation="translate",provider="openai",le="4"} 1`)
+}
@@ -8,17 +8,29 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/logging"
 )
 
 type ctxKey string
 
-const userKey ctxKey = "userID"
+const (
+	userKey ctxKey = "userID"
+	orgKey  ctxKey = "organization"
+)
 
 func UserID(ctx context.Context) uuid.UUID {
 	id, _ := ctx.Value(userKey).(uuid.UUID)
 	return id
 }
 
+// Organization returns the organization from the token's "org" claim, or
+// an empty string for tokens issued without one.
+func Organization(ctx context.Context) string {
+	org, _ := ctx.Value(orgKey).(string)
+	return org
+}
+
 func Auth(secret []byte) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -42,7 +54,10 @@ func Auth(secret []byte) func(http.Handler) http.Handler {
 				return
 			}
 			uid, _ := uuid.Parse(sub)
+			org, _ := claims["org"].(string)
 			ctx := context.WithValue(r.Context(), userKey, uid)
+			ctx = context.WithValue(ctx, orgKey, org)
+			ctx = logging.With(ctx, "user_id", uid.String())
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -2,6 +2,7 @@
 package middleware_test
 
 import (
+	"bytes"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -12,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/you/linkedinify/internal/logging"
 	"github.com/you/linkedinify/internal/middleware"
 )
 
@@ -185,3 +187,42 @@ func TestAuthMiddleware_InvalidToken_MissingSubClaim(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, rr.Code, "Expected Unauthorized for missing sub claim")
 	assert.False(t, nextHandler.called, "Next handler should not be called with missing sub claim")
 }
+
+func TestAuthMiddleware_AddsUserIDToRequestLogger(t *testing.T) {
+	testUserID := uuid.New()
+	token := generateTestToken(t, testUserID, testAuthSecret, time.Hour)
+
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, "info", "json")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req = req.WithContext(logging.WithContext(req.Context(), logger))
+	req.Header.Set("Authorization", "Bearer "+token)
+	rr := httptest.NewRecorder()
+
+	nextHandler := &mockHandler{
+		handlerFunc: func(w http.ResponseWriter, r *http.Request) {
+			logging.FromContext(r.Context()).Info("inside handler")
+			w.WriteHeader(http.StatusOK)
+		},
+	}
+	middleware.Auth(testAuthSecret)(nextHandler).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, buf.String(), `"user_id":"`+testUserID.String()+`"`)
+}
+
+func TestAuthMiddleware_OrganizationClaim(t *testing.T) {
+	token := generateTestToken(t, uuid.New(), testAuthSecret, time.Hour, map[string]interface{}{"org": "acme.com"})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	var org string
+	next := &mockHandler{handlerFunc: func(w http.ResponseWriter, r *http.Request) {
+		org = middleware.Organization(r.Context())
+	}}
+	middleware.Auth(testAuthSecret)(next).ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, "acme.com", org)
+}
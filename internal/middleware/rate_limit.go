@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RateLimit allows each authenticated user limit() requests per minute,
+// answering 429 with a Retry-After header beyond that. limit is consulted
+// on every request so the limit can change at runtime; 0 disables limiting.
+// It must run after Auth.
+func RateLimit(limit func() int) func(http.Handler) http.Handler {
+	l := &fixedWindow{size: time.Minute, windows: map[uuid.UUID]*window{}, now: time.Now}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			max := limit()
+			if max <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if retry, ok := l.allow(UserID(r.Context()), max); !ok {
+				w.Header().Set("Retry-After", strconv.Itoa(int(retry.Seconds()+0.999)))
+				http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+type window struct {
+	start time.Time
+	count int
+}
+
+// fixedWindow counts requests per key in consecutive windows of size.
+type fixedWindow struct {
+	mu        sync.Mutex
+	size      time.Duration
+	windows   map[uuid.UUID]*window
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// allow records a request for key and reports whether it is within max,
+// or else how long until the window resets.
+func (f *fixedWindow) allow(key uuid.UUID, max int) (time.Duration, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+	if now.Sub(f.lastSweep) > f.size {
+		// Drop expired windows so idle users do not accumulate.
+		for k, w := range f.windows {
+			if now.Sub(w.start) >= f.size {
+				delete(f.windows, k)
+			}
+		}
+		f.lastSweep = now
+	}
+
+	w, ok := f.windows[key]
+	if !ok || now.Sub(w.start) >= f.size {
+		w = &window{start: now}
+		f.windows[key] = w
+	}
+	if w.count >= max {
+		return w.start.Add(f.size).Sub(now), false
+	}
+	w.count++
+	return 0, true
+}
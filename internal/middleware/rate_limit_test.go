@@ -0,0 +1,49 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/you/linkedinify/internal/middleware"
+)
+
+func TestRateLimit_PerUserAndAdjustable(t *testing.T) {
+	limit := 2
+	h := middleware.Auth(testAuthSecret)(middleware.RateLimit(func() int { return limit })(&mockHandler{}))
+	alice := generateTestToken(t, uuid.New(), testAuthSecret, time.Hour)
+	bob := generateTestToken(t, uuid.New(), testAuthSecret, time.Hour)
+
+	do := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		return rr
+	}
+
+	assert.Equal(t, http.StatusOK, do(alice).Code)
+	assert.Equal(t, http.StatusOK, do(alice).Code)
+	rr := do(alice)
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.NotEmpty(t, rr.Header().Get("Retry-After"))
+	assert.Equal(t, http.StatusOK, do(bob).Code, "limits are per user")
+
+	limit = 0
+	assert.Equal(t, http.StatusOK, do(alice).Code, "a limit of 0 disables limiting")
+}
+
+func TestAdminToken(t *testing.T) {
+	h := middleware.AdminToken("s3cret")(&mockHandler{})
+	for token, want := range map[string]int{"s3cret": http.StatusOK, "wrong": http.StatusUnauthorized, "": http.StatusUnauthorized} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		assert.Equal(t, want, rr.Code, token)
+	}
+}
@@ -0,0 +1,26 @@
+// internal/model/audit.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// Audit actions recorded against a user account.
+const (
+	AuditDeletionRequested = "account.deletion_requested"
+	AuditDeletionCancelled = "account.deletion_cancelled"
+	AuditDataExported      = "account.data_exported"
+	AuditProfileUpdated    = "account.profile_updated"
+)
+
+type AuditEvent struct {
+	bun.BaseModel `bun:"table:audit_events"`
+	ID            uuid.UUID `bun:"type:uuid,pk"`
+	UserID        uuid.UUID `bun:"type:uuid,notnull"`
+	Action        string    `bun:",notnull"`
+	Detail        string    `bun:",notnull"`
+	CreatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,34 @@
+// internal/model/flag.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// FeatureFlag decides which variant of a feature each user gets. Boolean
+// flags have the variants "off" and "on".
+type FeatureFlag struct {
+	bun.BaseModel  `bun:"table:feature_flags"`
+	Key            string     `bun:",pk"`
+	Description    string     `bun:",notnull"`
+	Enabled        bool       `bun:",notnull"`
+	Variants       []string   `bun:",array,notnull"`
+	DefaultVariant string     `bun:",notnull"`
+	Rules          []FlagRule `bun:"type:jsonb,notnull"`
+	UpdatedAt      time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+// FlagRule serves Variant to the users it matches. Empty UserIDs or
+// Organizations match everyone; Percentage then limits the rule to that
+// share of users, chosen by a stable hash of the user ID. A zero
+// Percentage means all matching users when UserIDs or Organizations are
+// set, and no one otherwise.
+type FlagRule struct {
+	UserIDs       []uuid.UUID `json:"user_ids,omitempty"`
+	Organizations []string    `json:"organizations,omitempty"`
+	Percentage    int         `json:"percentage"`
+	Variant       string      `json:"variant"`
+}
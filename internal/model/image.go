@@ -0,0 +1,28 @@
+// internal/model/image.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// ImageSourceUpload is the PostImage.Source of images users uploaded.
+const ImageSourceUpload = "upload"
+
+// PostImage is an image attached to a post. The image itself is kept in
+// the blob store under BlobKey.
+type PostImage struct {
+	bun.BaseModel `bun:"table:post_images"`
+	ID            uuid.UUID `bun:"type:uuid,pk"`
+	PostID        uuid.UUID `bun:"type:uuid,notnull"`
+	UserID        uuid.UUID `bun:"type:uuid,notnull"`
+	BlobKey       string    `bun:",notnull"`
+	ContentType   string    `bun:",notnull"`
+	Size          int64     `bun:",notnull"`
+	// Source is ImageSourceUpload or the name of the generator that made
+	// the image.
+	Source    string    `bun:",notnull"`
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
@@ -14,5 +14,102 @@ type LinkedInPost struct {
 	UserID        uuid.UUID `bun:"type:uuid,notnull"`
 	InputText     string    `bun:",notnull"`
 	OutputText    string    `bun:",notnull"`
-	CreatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	// Language is the BCP-47 tag the post was written in; empty when unspecified.
+	Language string `bun:",notnull"`
+	// SourcePostID links a translation to the post it was translated from.
+	SourcePostID uuid.UUID `bun:"type:uuid,nullzero"`
+	// Provider and Model identify the AI backend that generated the post;
+	// empty for posts that predate provider routing.
+	Provider string `bun:",notnull"`
+	Model    string `bun:",notnull"`
+	// ModerationFlags are the moderation findings that did not block the
+	// post, on its input or its output.
+	ModerationFlags []ModerationFlag `bun:"type:jsonb,notnull"`
+	// Quality is how the post scored when it was saved; nil for posts
+	// that predate scoring.
+	Quality   *PostQuality `bun:"type:jsonb,nullzero"`
+	CreatedAt time.Time    `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+// PostRecord is the on-the-wire shape of a post in exports, imports and the
+// account archive. Images are not part of it; they are in the archive.
+type PostRecord struct {
+	ID              uuid.UUID        `json:"id"`
+	Input           string           `json:"input"`
+	Post            string           `json:"post"`
+	Language        string           `json:"language,omitempty"`
+	SourcePostID    *uuid.UUID       `json:"source_post_id,omitempty"`
+	Provider        string           `json:"provider,omitempty"`
+	Model           string           `json:"model,omitempty"`
+	ModerationFlags []ModerationFlag `json:"moderation_flags,omitempty"`
+	Quality         *PostQuality     `json:"quality,omitempty"`
+	CreatedAt       time.Time        `json:"created_at"`
+}
+
+// NewPostRecord describes p for export.
+func NewPostRecord(p *LinkedInPost) PostRecord {
+	rec := PostRecord{
+		ID:        p.ID,
+		Input:     p.InputText,
+		Post:      p.OutputText,
+		Language:  p.Language,
+		Provider:  p.Provider,
+		Model:     p.Model,
+		Quality:   p.Quality,
+		CreatedAt: p.CreatedAt,
+	}
+	if len(p.ModerationFlags) > 0 {
+		rec.ModerationFlags = p.ModerationFlags
+	}
+	if p.SourcePostID != uuid.Nil {
+		rec.SourcePostID = &p.SourcePostID
+	}
+	return rec
+}
+
+// LinkedInPost converts an imported record back into a post. The
+// translation link is not restored since the source post may not exist on
+// this instance, and the quality score is left for the service to
+// recompute.
+func (r PostRecord) LinkedInPost() LinkedInPost {
+	return LinkedInPost{
+		ID:         r.ID,
+		InputText:  r.Input,
+		OutputText: r.Post,
+		Language:   r.Language,
+		Provider:   r.Provider,
+		Model:      r.Model,
+		CreatedAt:  r.CreatedAt,
+		// Imported flags and scores are kept; the service fills in
+		// missing ones.
+		ModerationFlags: r.ModerationFlags,
+		Quality:         r.Quality,
+	}
+}
+
+// ModerationFlag records why moderation flagged a post.
+type ModerationFlag struct {
+	Stage    string `json:"stage"`
+	Category string `json:"category"`
+	Detail   string `json:"detail,omitempty"`
+}
+
+// PostQuality is how a post scored on the heuristics of package quality.
+// Densities are per 100 words.
+type PostQuality struct {
+	// Score is the overall grade, from 0 to 100.
+	Score int `json:"score"`
+	// Readability is the Flesch reading ease, from about 0 (hard) to 100
+	// (easy); nil for posts not in English, which the formula is not for.
+	Readability  *float64 `json:"readability,omitempty"`
+	EmojiDensity float64  `json:"emoji_density"`
+	Hashtags     int      `json:"hashtags"`
+	// Hook rates the first line, which is all LinkedIn shows before "see
+	// more", from 0 to 1.
+	Hook            float64 `json:"hook"`
+	BuzzwordDensity float64 `json:"buzzword_density"`
+	Length          int     `json:"length"`
+	Words           int     `json:"words"`
+	// Tips say how the post could score higher.
+	Tips []string `json:"tips,omitempty"`
 }
@@ -0,0 +1,42 @@
+// internal/model/settings.go
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// RuntimeSettings is one version of the settings that can be changed while
+// the service is running. Version 0 is the built-in defaults.
+type RuntimeSettings struct {
+	bun.BaseModel `bun:"table:runtime_settings"`
+	Version       int64         `bun:",pk"`
+	Values        RuntimeValues `bun:"settings,type:jsonb,notnull"`
+	ChangedBy     string        `bun:",notnull"`
+	Comment       string        `bun:",notnull"`
+	CreatedAt     time.Time     `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+// RuntimeValues are the hot-reloadable settings. Zero values mean the
+// built-in behaviour.
+type RuntimeValues struct {
+	// SystemPrompt replaces the system message sent with every transform.
+	SystemPrompt string `json:"system_prompt"`
+	// TransformsPerMinute caps transforms per user; 0 means unlimited.
+	TransformsPerMinute int `json:"transforms_per_minute"`
+	// PIIPolicy is what happens to personal data in text sent to the AI
+	// provider: PIIRedact (the default when empty), PIIBlock or PIIAllow.
+	PIIPolicy string `json:"pii_policy"`
+}
+
+// Policies for RuntimeValues.PIIPolicy.
+const (
+	// PIIRedact replaces personal data with placeholders before the AI
+	// call and restores it in the generated text.
+	PIIRedact = "redact"
+	// PIIBlock rejects text containing personal data.
+	PIIBlock = "block"
+	// PIIAllow sends text unchanged.
+	PIIAllow = "allow"
+)
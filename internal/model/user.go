@@ -15,4 +15,24 @@ type User struct {
 	PasswordHash  string    `bun:",notnull"`
 	APIToken      string    `bun:",notnull,unique"`
 	CreatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+
+	// Profile and per-user generation defaults.
+	DisplayName       string   `bun:",notnull"`
+	JobTitle          string   `bun:",notnull"`
+	Industry          string   `bun:",notnull"`
+	PreferredLanguage string   `bun:",notnull"`
+	DefaultStyle      string   `bun:",notnull"`
+	DefaultHashtags   []string `bun:",array,notnull"`
+	Signature         string   `bun:",notnull"`
+	MaxLength         int      `bun:",notnull"`
+
+	// Set while an account deletion is pending; the purger hard-deletes the
+	// user once DeleteAfter has passed.
+	DeletionRequestedAt time.Time `bun:",nullzero"`
+	DeleteAfter         time.Time `bun:",nullzero"`
+}
+
+// PendingDeletion reports whether the account is scheduled for deletion.
+func (u *User) PendingDeletion() bool {
+	return !u.DeleteAfter.IsZero()
 }
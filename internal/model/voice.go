@@ -0,0 +1,21 @@
+// internal/model/voice.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// VoiceProfile captures an author's writing voice from sample posts.
+// Guidance is the model-written style summary of Examples.
+type VoiceProfile struct {
+	bun.BaseModel `bun:"table:voice_profiles"`
+	ID            uuid.UUID `bun:"type:uuid,pk"`
+	UserID        uuid.UUID `bun:"type:uuid,notnull"`
+	Name          string    `bun:",notnull"`
+	Examples      []string  `bun:",array,notnull"`
+	Guidance      string    `bun:",notnull"`
+	CreatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,46 @@
+package moderation
+
+import (
+	"regexp"
+	"slices"
+)
+
+// Techniques FindInjection reports.
+const (
+	InjectionOverride  = "override"
+	InjectionRole      = "role"
+	InjectionLeak      = "prompt_leak"
+	InjectionMarker    = "role_marker"
+	InjectionDelimiter = "delimiter"
+)
+
+var injectionPatterns = []struct {
+	technique string
+	pattern   *regexp.Regexp
+}{
+	// "Ignore all previous instructions", "disregard the rules above".
+	{InjectionOverride, regexp.MustCompile(`(?i)\b(?:ignore|disregard|forget|override|skip)\b(?:\s+\w+){0,3}?\s+(?:previous|prior|above|earlier|preceding|original|system|your)\s+(?:\w+\s+)?(?:instructions?|prompts?|rules|directions|guidelines|context)\b`)},
+	{InjectionOverride, regexp.MustCompile(`(?i)\b(?:new|updated|real)\s+instructions?\s*:`)},
+	// "You are now DAN", "pretend to be an unfiltered AI".
+	{InjectionRole, regexp.MustCompile(`(?i)\b(?:(?:you\s+are\s+now|from\s+now\s+on,?\s+you\s+are|act\s+as)\s+(?:an?\s+)?(?:DAN|AI|assistant|chatbot|bot|model|unfiltered|unrestricted|jailbroken|evil)|you\s+are\s+no\s+longer\s+(?:bound|restricted|an?\s+(?:AI|assistant))|pretend\s+(?:to\s+be|you\s+are)|developer\s+mode|jailbreak)\b`)},
+	// "Reveal your system prompt", "print the instructions above".
+	{InjectionLeak, regexp.MustCompile(`(?i)\b(?:reveal|print|show|repeat|output|tell\s+me)\b(?:\s+\w+){0,3}?\s+(?:system\s+prompt|(?:initial|hidden|previous|above|original)\s+(?:instructions|prompt))\b`)},
+	// Chat-format markers and role prefixes starting a line.
+	{InjectionMarker, regexp.MustCompile(`(?im)<\|im_(?:start|end)\|>|<\|(?:system|assistant|user|endoftext)\|>|\[/?INST\]|<</?SYS>>|^\s*#{0,3}\s*(?:system|assistant|instruction)\s*:`)},
+	// The tags the AI client delimits user text with.
+	{InjectionDelimiter, regexp.MustCompile(`(?i)<\s*/?\s*(?:statement|post|profile|voice|example)\s*>`)},
+}
+
+// FindInjection returns the prompt-injection techniques text appears to
+// use, each at most once. It is a heuristic: the AI client already sends
+// user text delimited and apart from its instructions, and a finding only
+// says the text tries to talk to the model rather than about something.
+func FindInjection(text string) []string {
+	var techniques []string
+	for _, p := range injectionPatterns {
+		if !slices.Contains(techniques, p.technique) && p.pattern.MatchString(text) {
+			techniques = append(techniques, p.technique)
+		}
+	}
+	return techniques
+}
@@ -0,0 +1,227 @@
+// Package moderation screens the text users submit and the posts generated
+// from it.
+//
+// A Moderator reports Findings for a piece of text. Each finding carries the
+// Action it calls for: blocking findings reject the transform, flagging
+// findings are recorded on the post.
+package moderation
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/logging"
+)
+
+// Stage is which side of the AI call text was moderated on.
+type Stage string
+
+const (
+	Input  Stage = "input"
+	Output Stage = "output"
+)
+
+// Action is what a finding calls for.
+type Action string
+
+const (
+	Allow Action = "allow"
+	Flag  Action = "flag"
+	Block Action = "block"
+)
+
+// Categories of findings. The LLM moderator reports the provider's own
+// policy categories, such as "hate" or "violence".
+const (
+	CategoryBlocklist = "blocklist"
+	CategoryProfanity = "profanity"
+	CategoryPII       = "pii"
+	CategoryInjection = "injection"
+)
+
+// Finding is one reason text was flagged or rejected.
+type Finding struct {
+	Stage    Stage  `json:"stage"`
+	Category string `json:"category"`
+	// Detail narrows the category, such as the blocklisted term or the
+	// kind of personal data. It never contains personal data itself.
+	Detail string `json:"detail,omitempty"`
+	Action Action `json:"action"`
+}
+
+// Moderator screens text at a stage.
+type Moderator interface {
+	Moderate(ctx context.Context, stage Stage, text string) ([]Finding, error)
+}
+
+// Blocking returns the findings that reject the content.
+func Blocking(findings []Finding) []Finding {
+	var blocking []Finding
+	for _, f := range findings {
+		if f.Action == Block {
+			blocking = append(blocking, f)
+		}
+	}
+	return blocking
+}
+
+type chain []Moderator
+
+// Chain runs the moderators in order and combines their findings. It stops
+// at the first error, and after the first moderator whose findings block,
+// so that local rules can keep text from reaching remote moderators.
+func Chain(ms ...Moderator) Moderator {
+	return chain(ms)
+}
+
+func (c chain) Moderate(ctx context.Context, stage Stage, text string) ([]Finding, error) {
+	var all []Finding
+	for _, m := range c {
+		findings, err := m.Moderate(ctx, stage, text)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, findings...)
+		if len(Blocking(findings)) > 0 {
+			break
+		}
+	}
+	return all, nil
+}
+
+// Rules configures the rule-based moderator. Findings whose action is
+// Allow are not reported.
+type Rules struct {
+	// Blocklist terms and phrases always block, matched as whole words
+	// without regard to case.
+	Blocklist []string
+	// Profanity replaces DefaultProfanity when not nil.
+	Profanity       []string
+	ProfanityAction Action
+	PIIAction       Action
+	// InjectionAction applies to input that looks like a prompt-injection
+	// attempt, see FindInjection. Output is not checked.
+	InjectionAction Action
+}
+
+type ruleModerator struct {
+	blocklist       []term
+	profanity       []term
+	profanityAction Action
+	piiAction       Action
+	injectionAction Action
+}
+
+type term struct {
+	text    string
+	pattern *regexp.Regexp
+}
+
+func compileTerms(words []string) []term {
+	var terms []term
+	for _, w := range words {
+		w = strings.TrimSpace(w)
+		if w == "" {
+			continue
+		}
+		terms = append(terms, term{text: w, pattern: regexp.MustCompile(`(?i)(^|[^\p{L}\p{N}])` + regexp.QuoteMeta(w) + `($|[^\p{L}\p{N}])`)})
+	}
+	return terms
+}
+
+// NewRules creates the local moderator: blocklisted terms, profanity,
+// personal data (email addresses, phone numbers and IBANs) and, in input,
+// prompt-injection attempts.
+func NewRules(r Rules) Moderator {
+	profanity := r.Profanity
+	if profanity == nil {
+		profanity = DefaultProfanity
+	}
+	return &ruleModerator{
+		blocklist:       compileTerms(r.Blocklist),
+		profanity:       compileTerms(profanity),
+		profanityAction: r.ProfanityAction,
+		piiAction:       r.PIIAction,
+		injectionAction: r.InjectionAction,
+	}
+}
+
+func (m *ruleModerator) Moderate(_ context.Context, stage Stage, text string) ([]Finding, error) {
+	var findings []Finding
+	for _, t := range m.blocklist {
+		if t.pattern.MatchString(text) {
+			findings = append(findings, Finding{Stage: stage, Category: CategoryBlocklist, Detail: t.text, Action: Block})
+		}
+	}
+	if m.profanityAction != Allow {
+		for _, t := range m.profanity {
+			if t.pattern.MatchString(text) {
+				findings = append(findings, Finding{Stage: stage, Category: CategoryProfanity, Action: m.profanityAction})
+				break
+			}
+		}
+	}
+	if m.piiAction != Allow {
+		var kinds []string
+		for _, match := range FindPII(text) {
+			if !slices.Contains(kinds, match.Kind) {
+				kinds = append(kinds, match.Kind)
+				findings = append(findings, Finding{Stage: stage, Category: CategoryPII, Detail: match.Kind, Action: m.piiAction})
+			}
+		}
+	}
+	if stage == Input && m.injectionAction != Allow {
+		for _, technique := range FindInjection(text) {
+			findings = append(findings, Finding{Stage: stage, Category: CategoryInjection, Detail: technique, Action: m.injectionAction})
+		}
+	}
+	return findings, nil
+}
+
+// DefaultProfanity is a deliberately short list of unambiguous English
+// profanity; deployments with stricter needs configure their own.
+var DefaultProfanity = []string{
+	"fuck", "fucking", "fucked", "motherfucker", "shit", "bullshit", "bitch",
+	"asshole", "bastard", "cunt", "dickhead", "wanker", "twat",
+}
+
+type llmModerator struct {
+	classifier ai.Classifier
+	action     Action
+}
+
+// NewLLM creates a moderator backed by the AI provider's moderation model.
+// Every policy category it flags is reported with action. Personal data is
+// masked before text is sent, see Mask and WithMask. The provider being unavailable is
+// logged and does not hold up transforms; the local rules still apply.
+func NewLLM(c ai.Classifier, action Action) Moderator {
+	return &llmModerator{classifier: c, action: action}
+}
+
+type maskKey struct{}
+
+// WithMask returns a context under which the LLM moderator applies mask
+// before Mask, for callers that know of personal data Mask cannot find,
+// such as the values they redacted elsewhere.
+func WithMask(ctx context.Context, mask func(string) string) context.Context {
+	return context.WithValue(ctx, maskKey{}, mask)
+}
+
+func (m *llmModerator) Moderate(ctx context.Context, stage Stage, text string) ([]Finding, error) {
+	if mask, ok := ctx.Value(maskKey{}).(func(string) string); ok {
+		text = mask(text)
+	}
+	categories, err := m.classifier.Classify(ctx, Mask(text))
+	if err != nil {
+		logging.FromContext(ctx).Warn("LLM moderation unavailable, skipping", "stage", stage, "err", err)
+		return nil, nil
+	}
+	findings := make([]Finding, 0, len(categories))
+	for _, c := range categories {
+		findings = append(findings, Finding{Stage: stage, Category: c, Action: m.action})
+	}
+	return findings, nil
+}
@@ -0,0 +1,158 @@
+package moderation
+
+import (
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Kinds of personal data FindPII detects.
+const (
+	PIIEmail = "email"
+	PIIPhone = "phone"
+	PIIIBAN  = "iban"
+	PIIName  = "name"
+)
+
+// PIIMatch is personal data found at text[Start:End].
+type PIIMatch struct {
+	Kind  string
+	Start int
+	End   int
+}
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`)
+	// phonePattern is loose on purpose; candidates are then held to a
+	// plausible digit count.
+	phonePattern = regexp.MustCompile(`\+?\(?\d[\d\s().-]{6,}\d`)
+	ibanPattern  = regexp.MustCompile(`\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]{4}){2,7}(?: ?[A-Z0-9]{1,3})?\b`)
+)
+
+// FindPII returns the email addresses, phone numbers and IBANs in text, in
+// order and without overlaps. IBANs must pass their checksum.
+func FindPII(text string) []PIIMatch {
+	var matches []PIIMatch
+	for _, loc := range emailPattern.FindAllStringIndex(text, -1) {
+		matches = append(matches, PIIMatch{Kind: PIIEmail, Start: loc[0], End: loc[1]})
+	}
+	for _, loc := range ibanPattern.FindAllStringIndex(text, -1) {
+		if validIBAN(text[loc[0]:loc[1]]) {
+			matches = append(matches, PIIMatch{Kind: PIIIBAN, Start: loc[0], End: loc[1]})
+		}
+	}
+	for _, loc := range phonePattern.FindAllStringIndex(text, -1) {
+		if plausiblePhone(text[loc[0]:loc[1]]) {
+			matches = append(matches, PIIMatch{Kind: PIIPhone, Start: loc[0], End: loc[1]})
+		}
+	}
+	return dropOverlaps(matches)
+}
+
+// dropOverlaps drops matches overlapping an earlier one in the slice and
+// sorts the rest by position. Earlier kinds win, such as the digits of an
+// IBAN also looking like a phone number.
+func dropOverlaps(matches []PIIMatch) []PIIMatch {
+	var kept []PIIMatch
+	for _, m := range matches {
+		overlaps := false
+		for _, k := range kept {
+			if m.Start < k.End && k.Start < m.End {
+				overlaps = true
+				break
+			}
+		}
+		if !overlaps {
+			kept = append(kept, m)
+		}
+	}
+	sort.Slice(kept, func(i, j int) bool { return kept[i].Start < kept[j].Start })
+	return kept
+}
+
+var (
+	titledNamePattern = regexp.MustCompile(`\b(?:Mr|Mrs|Ms|Miss|Mx|Dr|Prof|Herr|Frau)\.?\s+\p{Lu}[\p{L}'-]+(?:\s+\p{Lu}[\p{L}'-]+)?`)
+	// roleNamePattern finds names introduced by who the person is, as in
+	// "our customer Jane Doe".
+	roleNamePattern = regexp.MustCompile(`\b(?:[Cc]ustomer|[Cc]lient|[Cc]olleague|[Cc]ontact|[Pp]atient|[Cc]andidate)s?\s+(\p{Lu}[\p{L}'-]+(?:\s+\p{Lu}[\p{L}'-]+)?)`)
+)
+
+// FindNames returns people's names in text that are marked as such by a
+// title ("Dr. Jane Doe") or a role ("customer Jane Doe"). It misses most
+// names and is meant for redaction, where a placeholder is restored
+// afterwards, not for moderation findings.
+func FindNames(text string) []PIIMatch {
+	var matches []PIIMatch
+	for _, loc := range titledNamePattern.FindAllStringIndex(text, -1) {
+		matches = append(matches, PIIMatch{Kind: PIIName, Start: loc[0], End: loc[1]})
+	}
+	for _, loc := range roleNamePattern.FindAllStringSubmatchIndex(text, -1) {
+		matches = append(matches, PIIMatch{Kind: PIIName, Start: loc[2], End: loc[3]})
+	}
+	return dropOverlaps(matches)
+}
+
+// FindRedactable returns the matches of FindPII and FindNames together,
+// without overlaps.
+func FindRedactable(text string) []PIIMatch {
+	return dropOverlaps(append(FindPII(text), FindNames(text)...))
+}
+
+// Mask replaces the personal data FindRedactable finds in text with its
+// kind, as in "write [EMAIL]", for text sent where personal data is not
+// needed. Like redaction, it replaces every occurrence of a found value,
+// so a name recognized once is also hidden where it appears on its own.
+func Mask(text string) string {
+	kinds := map[string]string{}
+	var values []string
+	for _, m := range FindRedactable(text) {
+		value := text[m.Start:m.End]
+		if _, ok := kinds[value]; !ok {
+			kinds[value] = "[" + strings.ToUpper(m.Kind) + "]"
+			values = append(values, value)
+		}
+	}
+	// Longer values first, so "Dr. Jane Doe" wins over "Jane Doe".
+	sort.SliceStable(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	pairs := make([]string, 0, 2*len(values))
+	for _, v := range values {
+		pairs = append(pairs, v, kinds[v])
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+// plausiblePhone accepts 9 to 15 digits (the E.164 maximum), which rules
+// out years, dates and most amounts.
+func plausiblePhone(s string) bool {
+	digits := 0
+	for _, r := range s {
+		if unicode.IsDigit(r) {
+			digits++
+		}
+	}
+	return digits >= 9 && digits <= 15
+}
+
+// validIBAN checks the ISO 13616 mod-97 checksum.
+func validIBAN(s string) bool {
+	s = strings.ReplaceAll(s, " ", "")
+	if len(s) < 15 || len(s) > 34 {
+		return false
+	}
+	var digits strings.Builder
+	for _, r := range s[4:] + s[:4] {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r >= 'A' && r <= 'Z':
+			digits.WriteString(strconv.Itoa(int(r-'A') + 10))
+		default:
+			return false
+		}
+	}
+	n, ok := new(big.Int).SetString(digits.String(), 10)
+	return ok && new(big.Int).Mod(n, big.NewInt(97)).Int64() == 1
+}
@@ -0,0 +1,269 @@
+// Package normalize cleans up the text users submit for transforms while
+// keeping what they meant: "<3" and "a < b" survive, "&amp;" becomes "&",
+// Markdown emphasis loses its markers but not its words.
+//
+// Every kind of change made is reported, so clients can tell users why the
+// text that was transformed differs from what they typed.
+package normalize
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// DefaultMaxLength is the input length allowed when none is configured.
+const DefaultMaxLength = 5000
+
+// Kinds of change a Normalizer reports.
+const (
+	// Unicode means the text was converted to NFC, so that "é" typed as
+	// "e" plus a combining accent is one character.
+	Unicode = "unicode"
+	// Invisible counts removed zero-width, bidirectional and soft-hyphen
+	// characters. Joiners inside emoji sequences, and between letters as
+	// in Persian and Indic scripts, are kept.
+	Invisible = "invisible"
+	// Control counts removed control characters other than newlines and
+	// tabs.
+	Control = "control"
+	// HTMLTags counts removed HTML tags and comments. Script and style
+	// elements are removed with their content.
+	HTMLTags = "html_tags"
+	// HTMLEntities counts decoded entities such as "&amp;".
+	HTMLEntities = "html_entities"
+	// Markdown counts Markdown syntax converted to plain text.
+	Markdown = "markdown"
+)
+
+// Change is one kind of change made to the input.
+type Change struct {
+	Kind  string `json:"kind"`
+	Count int    `json:"count"`
+}
+
+// TooLongError is returned for input longer than the maximum length once
+// normalized.
+type TooLongError struct {
+	Length int
+	Max    int
+}
+
+func (e *TooLongError) Error() string {
+	return fmt.Sprintf("text is %d characters long, the maximum is %d", e.Length, e.Max)
+}
+
+// Normalizer prepares transform input.
+type Normalizer struct {
+	maxLength int
+}
+
+// New creates a Normalizer that rejects input over maxLength characters;
+// maxLength <= 0 uses DefaultMaxLength.
+func New(maxLength int) *Normalizer {
+	if maxLength <= 0 {
+		maxLength = DefaultMaxLength
+	}
+	return &Normalizer{maxLength: maxLength}
+}
+
+// MaxLength is the longest input n accepts, in characters.
+func (n *Normalizer) MaxLength() int {
+	return n.maxLength
+}
+
+// Normalize returns text cleaned up and the changes made, in the order they
+// were applied. Whitespace is tidied without being reported. Text that is
+// still too long afterwards yields a *TooLongError.
+func (n *Normalizer) Normalize(text string) (string, []Change, error) {
+	var changes []Change
+	note := func(kind string, count int) {
+		if count > 0 {
+			changes = append(changes, Change{Kind: kind, Count: count})
+		}
+	}
+
+	text = strings.ToValidUTF8(text, "\ufffd")
+	text = strings.NewReplacer("\r\n", "\n", "\r", "\n", "\u2028", "\n", "\u2029", "\n\n").Replace(text)
+
+	var count int
+	text, count = stripTags(text)
+	note(HTMLTags, count)
+	text, count = decodeEntities(text)
+	note(HTMLEntities, count)
+	text, count = plainMarkdown(text)
+	note(Markdown, count)
+
+	if nfc := norm.NFC.String(text); nfc != text {
+		text = nfc
+		note(Unicode, 1)
+	}
+	var invisible, control int
+	text, invisible, control = removeHidden(text)
+	note(Invisible, invisible)
+	note(Control, control)
+
+	text = tidyWhitespace(text)
+	if length := utf8.RuneCountInString(text); length > n.maxLength {
+		return "", nil, &TooLongError{Length: length, Max: n.maxLength}
+	}
+	return text, changes, nil
+}
+
+var (
+	// Only what looks like real markup counts as a tag: a known HTML
+	// element, with attributes that all have values. "List<String>" and
+	// "a<b and c>d" are left alone.
+	tagPattern = regexp.MustCompile(`(?s)<!--.*?-->|(?i:</?(?:` + strings.Join(htmlElements, "|") + `)` +
+		`(?:\s+[a-z_:][-a-z0-9_:.]*\s*=\s*(?:"[^"]*"|'[^']*'|[^\s"'=<>` + "`" + `]+))*\s*/?>)`)
+	elementPattern = regexp.MustCompile(`(?is)<(script|style)\b[^>]*>.*?</(?:script|style)\s*>`)
+	blockTag       = regexp.MustCompile(`(?i)^</?(?:p|div|br|li|ul|ol|h[1-6]|blockquote|tr|table|section|article)\b`)
+)
+
+// htmlElements are the element names stripTags recognizes: those found in
+// text pasted from web pages, editors and email.
+var htmlElements = []string{
+	"a", "abbr", "address", "article", "aside", "b", "bdi", "bdo", "big", "blockquote", "body", "br",
+	"button", "caption", "center", "cite", "code", "col", "colgroup", "dd", "del", "details", "dfn",
+	"div", "dl", "dt", "em", "figcaption", "figure", "font", "footer", "form", "h[1-6]", "head",
+	"header", "hr", "html", "i", "iframe", "img", "input", "ins", "kbd", "label", "li", "link", "main",
+	"mark", "meta", "nav", "noscript", "ol", "option", "p", "picture", "pre", "q", "s", "samp",
+	"section", "select", "small", "source", "span", "strike", "strong", "sub", "summary", "sup",
+	"table", "tbody", "td", "textarea", "tfoot", "th", "thead", "time", "title", "tr", "tt", "u",
+	"ul", "var", "video", "wbr",
+}
+
+// stripTags removes HTML markup. Block-level tags become line breaks so
+// paragraphs stay apart.
+func stripTags(text string) (string, int) {
+	count := 0
+	text = elementPattern.ReplaceAllStringFunc(text, func(string) string {
+		count++
+		return ""
+	})
+	text = tagPattern.ReplaceAllStringFunc(text, func(tag string) string {
+		count++
+		if blockTag.MatchString(tag) {
+			return "\n"
+		}
+		return ""
+	})
+	return text, count
+}
+
+var entityPattern = regexp.MustCompile(`&(?:[A-Za-z][A-Za-z0-9]{1,31}|#[0-9]{1,7}|#[xX][0-9A-Fa-f]{1,6});`)
+
+// decodeEntities decodes the HTML entities in text. Anything that is not a
+// known entity, such as "R&D;", is left as typed.
+func decodeEntities(text string) (string, int) {
+	count := 0
+	text = entityPattern.ReplaceAllStringFunc(text, func(e string) string {
+		decoded := html.UnescapeString(e)
+		if decoded != e {
+			count++
+		}
+		return decoded
+	})
+	return text, count
+}
+
+var markdownRules = []struct {
+	pattern *regexp.Regexp
+	repl    string
+}{
+	// Fenced code blocks keep their code.
+	{regexp.MustCompile("(?m)^[ \t]*```[^\n]*\n?"), ""},
+	// Images become their alt text and links "text (url)".
+	{regexp.MustCompile(`!\[([^\]\n]*)\]\([^)\s]+\)`), "$1"},
+	{regexp.MustCompile(`\[([^\]\n]+)\]\(([^)\s]+)\)`), "$1 ($2)"},
+	// "# Heading" loses its marks; "#hashtag" has no space and is kept.
+	{regexp.MustCompile(`(?m)^[ \t]{0,3}#{1,6}[ \t]+(.+?)[ \t]*#*[ \t]*$`), "$1"},
+	{regexp.MustCompile(`(?m)^[ \t]{0,3}(?:[-*_][ \t]*){3,}$`), ""},
+	// Bullets are kept as "- ", which reads the same in a post.
+	{regexp.MustCompile(`(?m)^([ \t]*)[*+][ \t]+`), "$1- "},
+	{regexp.MustCompile(`(\*\*|__)([^\s*_](?:[^\n]*?[^\s*_])?)(\*\*|__)`), "$2"},
+	{regexp.MustCompile(`(^|[^\p{L}\p{N}*_])[*_]([^\s*_](?:[^\n*_]*?[^\s*_])?)[*_]($|[^\p{L}\p{N}*_])`), "$1$2$3"},
+	{regexp.MustCompile(`~~([^\s~](?:[^\n~]*?[^\s~])?)~~`), "$1"},
+	{regexp.MustCompile("`([^`\n]+)`"), "$1"},
+}
+
+// plainMarkdown turns Markdown into the plain text LinkedIn shows, and
+// counts the rules that changed something.
+func plainMarkdown(text string) (string, int) {
+	count := 0
+	for _, r := range markdownRules {
+		matches := len(r.pattern.FindAllStringIndex(text, -1))
+		if matches == 0 {
+			continue
+		}
+		if replaced := r.pattern.ReplaceAllString(text, r.repl); replaced != text {
+			text = replaced
+			count += matches
+		}
+	}
+	return text, count
+}
+
+// removeHidden drops invisible and control characters and counts each.
+func removeHidden(text string) (string, int, int) {
+	var b strings.Builder
+	invisible, control := 0, 0
+	runes := []rune(text)
+	for i, r := range runes {
+		switch {
+		case r == '\u200c' || r == '\u200d':
+			// Joiners shape letters in scripts such as Persian and
+			// Devanagari, and a zero-width joiner also glues emoji such as
+			// 👩‍💻 together.
+			if i > 0 && i < len(runes)-1 && (joinsLetters(runes[i-1], runes[i+1]) ||
+				r == '\u200d' && emojiPart(runes[i-1]) && emojiPart(runes[i+1])) {
+				b.WriteRune(r)
+			} else {
+				invisible++
+			}
+		case isInvisible(r):
+			invisible++
+		case r != '\n' && r != '\t' && unicode.IsControl(r):
+			control++
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String(), invisible, control
+}
+
+func isInvisible(r rune) bool {
+	switch {
+	case r == '\u200b', r == '\u2060', r == '\ufeff', r == '\u00ad', r == '\u180e':
+		return true
+	case r >= '\u202a' && r <= '\u202e', r >= '\u2066' && r <= '\u2069', r == '\u200e', r == '\u200f':
+		return true
+	}
+	return false
+}
+
+// joinsLetters reports whether a joiner between prev and next is part of a
+// word. A virama or other mark may come before it.
+func joinsLetters(prev, next rune) bool {
+	return (unicode.IsLetter(prev) || unicode.IsMark(prev)) && unicode.IsLetter(next)
+}
+
+func emojiPart(r rune) bool {
+	return unicode.In(r, unicode.So, unicode.Sk) || r == '\ufe0f'
+}
+
+var (
+	trailingSpace = regexp.MustCompile(`[ \t]+\n`)
+	blankLines    = regexp.MustCompile(`\n{3,}`)
+)
+
+func tidyWhitespace(text string) string {
+	text = trailingSpace.ReplaceAllString(text, "\n")
+	text = blankLines.ReplaceAllString(text, "\n\n")
+	return strings.TrimSpace(text)
+}
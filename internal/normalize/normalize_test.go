@@ -0,0 +1,83 @@
+package normalize_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/normalize"
+)
+
+func TestNormalize_KeepsIntent(t *testing.T) {
+	for _, text := range []string{
+		"I <3 my team",
+		"Latency went from a < b to a > b, and 2 < 3.",
+		"R&D spent 5 * 3 * 2 hours on snake_case_names and C#.",
+		"#OpenToWork #1 priority",
+		"Our 👩‍💻 team ❤️ shipping",
+		"if a<b and c>d",
+		"List<String> and Map<K, V>",
+		"می\u200cخواهم",           // Persian: ZWNJ between letters
+		"क्\u200dष and ক্\u200cষ", // Devanagari and Bengali: joiners after a virama
+	} {
+		got, changes, err := normalize.New(0).Normalize(text)
+		require.NoError(t, err)
+		assert.Equal(t, text, got)
+		assert.Empty(t, changes, text)
+	}
+}
+
+func TestNormalize_ReportsChanges(t *testing.T) {
+	text := "<p>Caf\u0065\u0301 &amp; <b>code</b></p><script>alert(1)</script>\n" +
+		"## Big **news**\n* shipped [v2](https://example.com)\u200b\x07\r\n"
+
+	got, changes, err := normalize.New(0).Normalize(text)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Café & code\n\nBig news\n- shipped v2 (https://example.com)", got)
+	assert.Equal(t, []normalize.Change{
+		{Kind: normalize.HTMLTags, Count: 5},
+		{Kind: normalize.HTMLEntities, Count: 1},
+		{Kind: normalize.Markdown, Count: 4},
+		{Kind: normalize.Unicode, Count: 1},
+		{Kind: normalize.Invisible, Count: 1},
+		{Kind: normalize.Control, Count: 1},
+	}, changes)
+}
+
+func TestNormalize_StripsOnlyHTMLTags(t *testing.T) {
+	got, changes, err := normalize.New(0).Normalize(`<a href="https://example.com" target=_blank>Docs</a> for Set<Item><br/>`)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Docs for Set<Item>", got)
+	assert.Equal(t, []normalize.Change{{Kind: normalize.HTMLTags, Count: 3}}, changes)
+}
+
+func TestNormalize_RemovesStrayJoiners(t *testing.T) {
+	got, changes, err := normalize.New(0).Normalize("\u200cHi\u200d \u200cthere")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Hi there", got)
+	assert.Equal(t, []normalize.Change{{Kind: normalize.Invisible, Count: 3}}, changes)
+}
+
+func TestNormalize_EntitiesDecodeToText(t *testing.T) {
+	got, _, err := normalize.New(0).Normalize("Use &lt;b&gt; for bold &#128640; R&D; &nbsp;")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Use <b> for bold 🚀 R&D;", got, "decoded markup is text, not a tag")
+}
+
+func TestNormalize_MaxLength(t *testing.T) {
+	n := normalize.New(10)
+
+	_, _, err := n.Normalize("<b>0123456789</b>")
+	assert.NoError(t, err, "the limit applies after normalization")
+
+	_, _, err = n.Normalize("0123456789a")
+	var tooLong *normalize.TooLongError
+	require.ErrorAs(t, err, &tooLong)
+	assert.Equal(t, 11, tooLong.Length)
+	assert.Equal(t, 10, tooLong.Max)
+}
@@ -0,0 +1,172 @@
+package postformat
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+type lineKind int
+
+const (
+	textLine lineKind = iota
+	blankLine
+	bulletLine
+	numberedLine
+	headingLine
+)
+
+// line is one line of a post. Lines are never merged, so line breaks come
+// out where they went in.
+type line struct {
+	kind lineKind
+	// number is the item number of a numbered line, level the heading
+	// level.
+	number string
+	level  int
+	spans  []span
+}
+
+type span struct {
+	text   string
+	bold   bool
+	italic bool
+	code   bool
+	href   string
+}
+
+var (
+	bulletPattern   = regexp.MustCompile(`^\s*[-*+\x{2022}\x{25AA}\x{25E6}\x{2023}]\s+(.*)$`)
+	numberedPattern = regexp.MustCompile(`^\s*(\d{1,3})[.)]\s+(.*)$`)
+	headingPattern  = regexp.MustCompile(`^\s{0,3}(#{1,6})\s+(.*?)\s*#*\s*$`)
+)
+
+func parse(post string) []line {
+	post = strings.ReplaceAll(strings.TrimSpace(post), "\r\n", "\n")
+	var lines []line
+	for _, raw := range strings.Split(post, "\n") {
+		switch {
+		case strings.TrimSpace(raw) == "":
+			lines = append(lines, line{kind: blankLine})
+		case headingPattern.MatchString(raw):
+			m := headingPattern.FindStringSubmatch(raw)
+			lines = append(lines, line{kind: headingLine, level: len(m[1]), spans: parseInline(m[2])})
+		case bulletPattern.MatchString(raw):
+			m := bulletPattern.FindStringSubmatch(raw)
+			lines = append(lines, line{kind: bulletLine, spans: parseInline(m[1])})
+		case numberedPattern.MatchString(raw):
+			m := numberedPattern.FindStringSubmatch(raw)
+			lines = append(lines, line{kind: numberedLine, number: m[1], spans: parseInline(m[2])})
+		default:
+			lines = append(lines, line{kind: textLine, spans: parseInline(raw)})
+		}
+	}
+	return lines
+}
+
+// inlinePattern finds the Markdown inline syntax posts use. Emphasis must
+// hug its text, so "5 * 3" and snake_case stay as they are.
+var inlinePattern = regexp.MustCompile(
+	"`([^`\n]+)`" +
+		`|\[([^\]\n]+)\]\(([^)\s]+)\)` +
+		`|\*\*\*([^\s*](?:[^*\n]*?[^\s*])?)\*\*\*` +
+		`|(?:\*\*|__)([^\s*_](?:[^\n]*?[^\s*_])?)(?:\*\*|__)` +
+		`|(?:^|(?P<pre>[^\p{L}\p{N}*_]))[*_]([^\s*_](?:[^\n*_]*?[^\s*_])?)[*_](?:$|(?P<post>[^\p{L}\p{N}*_]))`)
+
+func parseInline(text string) []span {
+	var spans []span
+	last, pos := 0, 0
+	for pos < len(text) {
+		m := inlinePattern.FindStringSubmatchIndex(text[pos:])
+		if m == nil {
+			break
+		}
+		for i := range m {
+			if m[i] >= 0 {
+				m[i] += pos
+			}
+		}
+		group := func(i int) string {
+			if m[2*i] < 0 {
+				return ""
+			}
+			return text[m[2*i]:m[2*i+1]]
+		}
+		// The italic alternative matches a character on either side, which
+		// belongs to the surrounding text.
+		start, end := m[0], m[1]
+		if m[12] >= 0 {
+			start = m[13]
+		}
+		if m[16] >= 0 {
+			end = m[16]
+		}
+		spans = append(spans, styled(text[last:start], span{})...)
+		switch {
+		case m[2] >= 0:
+			spans = append(spans, span{text: group(1), code: true})
+		case m[4] >= 0:
+			spans = append(spans, span{text: group(2), href: group(3)})
+		case m[8] >= 0:
+			spans = append(spans, styled(group(4), span{bold: true, italic: true})...)
+		case m[10] >= 0:
+			spans = append(spans, styled(group(5), span{bold: true})...)
+		default:
+			spans = append(spans, styled(group(7), span{italic: true})...)
+		}
+		last, pos = end, max(end, pos+1)
+	}
+	return append(spans, styled(text[last:], span{})...)
+}
+
+// styled splits text into spans by the Unicode styling of its letters,
+// adding to the style of base. Spaces and punctuation between letters of
+// one style stay with them, so "𝗵𝗲𝗹𝗹𝗼, 𝘄𝗼𝗿𝗹𝗱" is one span; brackets and
+// quotes do not, so they are not left unbalanced.
+func styled(text string, base span) []span {
+	if text == "" {
+		return nil
+	}
+	var spans []span
+	cur := base
+	var b, pending strings.Builder
+	emit := func(sb *strings.Builder, s span) {
+		if sb.Len() > 0 {
+			s.text = sb.String()
+			spans = append(spans, s)
+			sb.Reset()
+		}
+	}
+	for _, r := range text {
+		if unicode.IsSpace(r) || unicode.IsPunct(r) && !unicode.In(r, unicode.Ps, unicode.Pe, unicode.Pi, unicode.Pf) {
+			pending.WriteRune(r)
+			continue
+		}
+		plain, bold, italic := unstyle(r)
+		next := span{bold: base.bold || bold, italic: base.italic || italic}
+		if b.Len() == 0 || next.bold != cur.bold || next.italic != cur.italic {
+			emit(&b, cur)
+			emit(&pending, base)
+		}
+		b.WriteString(pending.String())
+		pending.Reset()
+		cur.bold, cur.italic = next.bold, next.italic
+		b.WriteRune(plain)
+	}
+	emit(&b, cur)
+	emit(&pending, base)
+	return merge(spans)
+}
+
+// merge joins neighbouring spans of the same style.
+func merge(spans []span) []span {
+	var merged []span
+	for _, s := range spans {
+		if n := len(merged); n > 0 && merged[n-1].bold == s.bold && merged[n-1].italic == s.italic && !merged[n-1].code && !s.code && merged[n-1].href == "" && s.href == "" {
+			merged[n-1].text += s.text
+			continue
+		}
+		merged = append(merged, s)
+	}
+	return merged
+}
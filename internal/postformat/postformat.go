@@ -0,0 +1,101 @@
+// Package postformat renders generated posts for where they are going.
+//
+// Posts are written in a Markdown flavour: **bold**, *italic*, "- " bullets,
+// [links](https://example.com) and the occasional heading. LinkedIn shows
+// none of that, so the LinkedIn format fakes emphasis with Unicode
+// mathematical letters, the way people do by hand. Letters already styled
+// that way are recognised, so every format can be produced from any post.
+package postformat
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Format is an output format for posts.
+type Format string
+
+const (
+	// LinkedIn is text ready to paste into LinkedIn: Unicode bold and
+	// italic, "•" bullets and links spelled out.
+	LinkedIn Format = "linkedin"
+	// Plain is text without any styling.
+	Plain Format = "plain"
+	// HTML is an HTML fragment of paragraphs and lists.
+	HTML Format = "html"
+	// Markdown is the post as Markdown, with Unicode styling turned back
+	// into emphasis markers.
+	Markdown Format = "markdown"
+)
+
+// ErrUnknownFormat is returned by Parse when no requested format is
+// supported.
+var ErrUnknownFormat = errors.New("format must be linkedin, plain, html or markdown")
+
+var formatNames = map[string]Format{
+	"linkedin":      LinkedIn,
+	"text/linkedin": LinkedIn,
+	"*/*":           LinkedIn,
+	"plain":         Plain,
+	"text":          Plain,
+	"text/plain":    Plain,
+	"html":          HTML,
+	"text/html":     HTML,
+	"markdown":      Markdown,
+	"md":            Markdown,
+	"text/markdown": Markdown,
+}
+
+// Parse picks a format from an Accept-style list such as
+// "html, text/plain;q=0.5": the supported entry with the highest quality
+// wins, ties going to the earlier one. Names and media types are accepted.
+func Parse(accept string) (Format, error) {
+	type choice struct {
+		format Format
+		q      float64
+	}
+	var choices []choice
+	for _, entry := range strings.Split(accept, ",") {
+		name, params, _ := strings.Cut(entry, ";")
+		f, ok := formatNames[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			continue
+		}
+		q := 1.0
+		for _, p := range strings.Split(params, ";") {
+			if v, ok := strings.CutPrefix(strings.TrimSpace(p), "q="); ok {
+				if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q > 0 {
+			choices = append(choices, choice{f, q})
+		}
+	}
+	if len(choices) == 0 {
+		return "", ErrUnknownFormat
+	}
+	sort.SliceStable(choices, func(i, j int) bool { return choices[i].q > choices[j].q })
+	return choices[0].format, nil
+}
+
+// Render converts post into f.
+func Render(post string, f Format) (string, error) {
+	lines := parse(post)
+	switch f {
+	case LinkedIn:
+		return renderText(lines, true), nil
+	case Plain:
+		return renderText(lines, false), nil
+	case HTML:
+		return renderHTML(lines), nil
+	case Markdown:
+		return renderMarkdown(lines), nil
+	default:
+		return "", fmt.Errorf("%w, got %q", ErrUnknownFormat, f)
+	}
+}
@@ -0,0 +1,108 @@
+package postformat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/postformat"
+)
+
+const post = "# Big news\n" +
+	"We **shipped v2** today, *finally*.\n" +
+	"Thanks to the team!\n" +
+	"\n" +
+	"- 5 * 3 faster\n" +
+	"* fewer snake_case bugs\n" +
+	"\n" +
+	"Read more: [our blog](https://example.com/v2) #Launch"
+
+func render(t *testing.T, f postformat.Format) string {
+	t.Helper()
+	out, err := postformat.Render(post, f)
+	require.NoError(t, err)
+	return out
+}
+
+func TestRender_LinkedIn(t *testing.T) {
+	assert.Equal(t, "𝗕𝗶𝗴 𝗻𝗲𝘄𝘀\n"+
+		"We 𝘀𝗵𝗶𝗽𝗽𝗲𝗱 𝘃𝟮 today, 𝘧𝘪𝘯𝘢𝘭𝘭𝘺.\n"+
+		"Thanks to the team!\n"+
+		"\n"+
+		"• 5 * 3 faster\n"+
+		"• fewer snake_case bugs\n"+
+		"\n"+
+		"Read more: our blog (https://example.com/v2) #Launch", render(t, postformat.LinkedIn))
+}
+
+func TestRender_Plain(t *testing.T) {
+	assert.Equal(t, "Big news\n"+
+		"We shipped v2 today, finally.\n"+
+		"Thanks to the team!\n"+
+		"\n"+
+		"- 5 * 3 faster\n"+
+		"- fewer snake_case bugs\n"+
+		"\n"+
+		"Read more: our blog (https://example.com/v2) #Launch", render(t, postformat.Plain))
+}
+
+func TestRender_HTML(t *testing.T) {
+	assert.Equal(t, "<h1>Big news</h1>\n"+
+		"<p>We <strong>shipped v2</strong> today, <em>finally</em>.<br>\n"+
+		"Thanks to the team!</p>\n"+
+		"<ul>\n<li>5 * 3 faster</li>\n<li>fewer snake_case bugs</li>\n</ul>\n"+
+		`<p>Read more: <a href="https://example.com/v2">our blog</a> #Launch</p>`, render(t, postformat.HTML))
+}
+
+func TestRender_HTMLEscapes(t *testing.T) {
+	out, err := postformat.Render("<script>x</script> & [click](javascript:alert(1))", postformat.HTML)
+
+	require.NoError(t, err)
+	assert.Equal(t, "<p>&lt;script&gt;x&lt;/script&gt; &amp; click (javascript:alert(1))</p>", out)
+}
+
+func TestRender_Markdown(t *testing.T) {
+	assert.Equal(t, "# Big news\n"+
+		"We **shipped v2** today, *finally*.\n"+
+		"Thanks to the team!\n"+
+		"\n"+
+		"- 5 * 3 faster\n"+
+		"- fewer snake_case bugs\n"+
+		"\n"+
+		"Read more: [our blog](https://example.com/v2) #Launch", render(t, postformat.Markdown))
+}
+
+func TestRender_RecognisesUnicodeStyling(t *testing.T) {
+	styled := "𝗛𝗲𝗹𝗹𝗼, 𝘄𝗼𝗿𝗹𝗱! It's 𝘳𝘦𝘢𝘭𝘭𝘺 𝐝𝐨𝐧𝐞 (𝟮𝟬𝟮𝟰)"
+
+	md, err := postformat.Render(styled, postformat.Markdown)
+	require.NoError(t, err)
+	assert.Equal(t, "**Hello, world**! It's *really* **done** (**2024**)", md)
+
+	plain, err := postformat.Render(styled, postformat.Plain)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, world! It's really done (2024)", plain)
+
+	linkedIn, err := postformat.Render(md, postformat.LinkedIn)
+	require.NoError(t, err)
+	assert.Equal(t, "𝗛𝗲𝗹𝗹𝗼, 𝘄𝗼𝗿𝗹𝗱! It's 𝘳𝘦𝘢𝘭𝘭𝘺 𝗱𝗼𝗻𝗲 (𝟮𝟬𝟮𝟰)", linkedIn, "serif bold comes out sans")
+}
+
+func TestParse(t *testing.T) {
+	for accept, want := range map[string]postformat.Format{
+		"html":                            postformat.HTML,
+		"text/plain":                      postformat.Plain,
+		"md":                              postformat.Markdown,
+		"image/png, text/html;q=0.5, md":  postformat.Markdown,
+		"text/html;q=0.5, linkedin;q=0.8": postformat.LinkedIn,
+		"*/*":                             postformat.LinkedIn,
+	} {
+		got, err := postformat.Parse(accept)
+		require.NoError(t, err, accept)
+		assert.Equal(t, want, got, accept)
+	}
+
+	_, err := postformat.Parse("image/png, html;q=0")
+	assert.ErrorIs(t, err, postformat.ErrUnknownFormat)
+}
@@ -0,0 +1,159 @@
+package postformat
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// bulletGlyph replaces list markers in LinkedIn text.
+const bulletGlyph = "•"
+
+// renderText renders lines as text, styled for LinkedIn or plain.
+func renderText(lines []line, linkedIn bool) string {
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		var b strings.Builder
+		switch l.kind {
+		case bulletLine:
+			if linkedIn {
+				b.WriteString(bulletGlyph + " ")
+			} else {
+				b.WriteString("- ")
+			}
+		case numberedLine:
+			b.WriteString(l.number + ". ")
+		}
+		for _, s := range l.spans {
+			text := s.text
+			if s.href != "" && s.href != s.text {
+				text = fmt.Sprintf("%s (%s)", s.text, s.href)
+			}
+			if linkedIn {
+				// Headings have nothing but weight to stand out with.
+				text = stylize(text, s.bold || l.kind == headingLine, s.italic)
+			}
+			b.WriteString(text)
+		}
+		out[i] = b.String()
+	}
+	return strings.Join(out, "\n")
+}
+
+func renderMarkdown(lines []line) string {
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		var b strings.Builder
+		switch l.kind {
+		case bulletLine:
+			b.WriteString("- ")
+		case numberedLine:
+			b.WriteString(l.number + ". ")
+		case headingLine:
+			b.WriteString(strings.Repeat("#", l.level) + " ")
+		}
+		for _, s := range l.spans {
+			b.WriteString(markdownSpan(s))
+		}
+		out[i] = b.String()
+	}
+	return strings.Join(out, "\n")
+}
+
+// markdownSpan wraps a span in its markers. Surrounding spaces go outside
+// them, as "** bold**" is not emphasis.
+func markdownSpan(s span) string {
+	inner := strings.TrimSpace(s.text)
+	if inner == "" {
+		return s.text
+	}
+	lead := s.text[:strings.Index(s.text, inner)]
+	trail := s.text[len(lead)+len(inner):]
+	switch {
+	case s.code:
+		inner = "`" + inner + "`"
+	case s.href != "":
+		inner = fmt.Sprintf("[%s](%s)", inner, s.href)
+	case s.bold && s.italic:
+		inner = "***" + inner + "***"
+	case s.bold:
+		inner = "**" + inner + "**"
+	case s.italic:
+		inner = "*" + inner + "*"
+	}
+	return lead + inner + trail
+}
+
+// renderHTML renders lines as paragraphs, lists and headings. Lines of a
+// paragraph are kept apart with <br>.
+func renderHTML(lines []line) string {
+	var b strings.Builder
+	var open string // "p", "ul" or "ol"
+	closeBlock := func() {
+		if open != "" {
+			fmt.Fprintf(&b, "</%s>\n", open)
+			open = ""
+		}
+	}
+	for _, l := range lines {
+		switch l.kind {
+		case blankLine:
+			closeBlock()
+		case headingLine:
+			closeBlock()
+			fmt.Fprintf(&b, "<h%d>%s</h%d>\n", l.level, htmlSpans(l.spans), l.level)
+		case bulletLine, numberedLine:
+			list := "ul"
+			if l.kind == numberedLine {
+				list = "ol"
+			}
+			if open != list {
+				closeBlock()
+				fmt.Fprintf(&b, "<%s>\n", list)
+				open = list
+			}
+			fmt.Fprintf(&b, "<li>%s</li>\n", htmlSpans(l.spans))
+		default:
+			if open == "p" {
+				b.WriteString("<br>\n")
+			} else {
+				closeBlock()
+				b.WriteString("<p>")
+				open = "p"
+			}
+			b.WriteString(htmlSpans(l.spans))
+		}
+	}
+	closeBlock()
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// safeHref reports whether href may be linked; anything but web and mail
+// links, such as "javascript:", is shown as text.
+func safeHref(href string) bool {
+	lower := strings.ToLower(href)
+	return strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "mailto:")
+}
+
+func htmlSpans(spans []span) string {
+	var b strings.Builder
+	for _, s := range spans {
+		text := html.EscapeString(s.text)
+		switch {
+		case s.code:
+			text = "<code>" + text + "</code>"
+		case safeHref(s.href):
+			text = fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(s.href), text)
+		case s.href != "":
+			text += " (" + html.EscapeString(s.href) + ")"
+		}
+		if s.italic {
+			text = "<em>" + text + "</em>"
+		}
+		if s.bold {
+			text = "<strong>" + text + "</strong>"
+		}
+		b.WriteString(text)
+	}
+	return b.String()
+}
@@ -0,0 +1,79 @@
+package postformat
+
+import "strings"
+
+// alphabet is a run of styled letters or digits in the Unicode
+// Mathematical Alphanumeric Symbols block.
+type alphabet struct {
+	first  rune // styled form of from
+	from   rune // 'A', 'a' or '0'
+	size   rune
+	bold   bool
+	italic bool
+}
+
+// alphabets are the styles people fake emphasis with. Rendering uses the
+// sans-serif ones, which read best on LinkedIn; the serif ones are only
+// recognised.
+var alphabets = []alphabet{
+	{0x1D5D4, 'A', 26, true, false},
+	{0x1D5EE, 'a', 26, true, false},
+	{0x1D608, 'A', 26, false, true},
+	{0x1D622, 'a', 26, false, true},
+	{0x1D63C, 'A', 26, true, true},
+	{0x1D656, 'a', 26, true, true},
+	{0x1D7EC, '0', 10, true, false},
+	{0x1D400, 'A', 26, true, false},
+	{0x1D41A, 'a', 26, true, false},
+	{0x1D434, 'A', 26, false, true},
+	{0x1D44E, 'a', 26, false, true},
+	{0x1D468, 'A', 26, true, true},
+	{0x1D482, 'a', 26, true, true},
+	{0x1D7CE, '0', 10, true, false},
+}
+
+// italicSmallH stands in for the serif italic h, which Unicode had already
+// encoded as the Planck constant.
+const italicSmallH = 0x210E
+
+// unstyle returns the plain letter behind a styled one and its style.
+func unstyle(r rune) (plain rune, bold, italic bool) {
+	if r == italicSmallH {
+		return 'h', false, true
+	}
+	if r < 0x1D400 || r > 0x1D7FF {
+		return r, false, false
+	}
+	for _, a := range alphabets {
+		if r >= a.first && r < a.first+a.size {
+			return a.from + (r - a.first), a.bold, a.italic
+		}
+	}
+	return r, false, false
+}
+
+// stylize writes ASCII letters and digits in text in the sans-serif style
+// for bold and italic. Digits have no italic form and stay plain then, as
+// does everything outside ASCII.
+func stylize(text string, bold, italic bool) string {
+	if !bold && !italic {
+		return text
+	}
+	var b strings.Builder
+	for _, r := range text {
+		b.WriteRune(styleRune(r, bold, italic))
+	}
+	return b.String()
+}
+
+func styleRune(r rune, bold, italic bool) rune {
+	for _, a := range alphabets[:7] {
+		if a.bold == bold && a.italic == italic && r >= a.from && r < a.from+a.size {
+			return a.first + (r - a.from)
+		}
+	}
+	if bold && italic && r >= '0' && r <= '9' {
+		return styleRune(r, true, false)
+	}
+	return r
+}
@@ -0,0 +1,50 @@
+package quality
+
+import (
+	"slices"
+	"strings"
+)
+
+// buzzwords are phrases that make a post sound like every other one. They
+// are matched as whole words, with hyphens read as spaces.
+var buzzwords = [][]string{}
+
+// cliches are openers that announce the author's feelings rather than
+// give anyone a reason to read on.
+var cliches = []string{
+	"excited to announce", "thrilled to announce", "happy to announce", "pleased to announce",
+	"proud to announce", "excited to share", "thrilled to share", "happy to share", "humbled",
+	"i'm excited", "i am excited", "in today's fast-paced", "in today's world",
+}
+
+func init() {
+	for _, phrase := range []string{
+		"synergy", "synergies", "leverage", "leveraged", "leveraging", "game changer", "game changing",
+		"disrupt", "disruptive", "paradigm shift", "thought leader", "thought leadership",
+		"move the needle", "circle back", "low hanging fruit", "best in class", "world class",
+		"cutting edge", "next level", "ninja", "rockstar", "guru", "growth hacking",
+		"value add", "deep dive", "bandwidth", "holistic", "seamless", "seamlessly",
+		"revolutionary", "revolutionize", "empower", "empowering", "results driven",
+		"visionary", "crushing it", "10x", "unlock", "unlocking", "synergize", "ecosystem",
+	} {
+		buzzwords = append(buzzwords, strings.Fields(phrase))
+	}
+}
+
+// findBuzzwords returns the buzzwords among words, in order.
+func findBuzzwords(words []string) []string {
+	lower := make([]string, 0, len(words))
+	for _, w := range words {
+		lower = append(lower, strings.ToLower(w))
+	}
+	var found []string
+	for i := range lower {
+		for _, b := range buzzwords {
+			if i+len(b) <= len(lower) && slices.Equal(lower[i:i+len(b)], b) {
+				found = append(found, strings.Join(b, " "))
+				break
+			}
+		}
+	}
+	return found
+}
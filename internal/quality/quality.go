@@ -0,0 +1,211 @@
+// Package quality scores how well a post is written for LinkedIn with
+// heuristics that need no AI call: readability, emoji and buzzword
+// density, hashtag count, the strength of the hook and the length.
+package quality
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/you/linkedinify/internal/model"
+)
+
+// previewLength is roughly how much of the first line LinkedIn shows
+// before "see more".
+const previewLength = 210
+
+// metric is one graded aspect of a post. Grades run from 0 to 1 and are
+// averaged by weight into the score.
+type metric struct {
+	weight float64
+	grade  float64
+	tip    string // set when the grade is poor
+}
+
+var (
+	hashtagPattern = regexp.MustCompile(`(?:^|\s)#[\p{L}\p{N}_]+`)
+	urlPattern     = regexp.MustCompile(`https?://\S+`)
+	wordPattern    = regexp.MustCompile(`[\p{L}\p{N}]+(?:['\x{2019}][\p{L}]+)*`)
+)
+
+// Analyze scores post, a generated post in the Markdown flavour posts are
+// written in. language is the post's BCP-47 tag; readability is only
+// graded for English, or when the language is not known.
+func Analyze(post, language string) model.PostQuality {
+	// Letters styled with Unicode for emphasis are read as plain ones.
+	text := norm.NFKC.String(post)
+	prose := urlPattern.ReplaceAllString(hashtagPattern.ReplaceAllString(text, " "), " ")
+	words := wordPattern.FindAllString(prose, -1)
+
+	q := model.PostQuality{
+		Hashtags: len(hashtagPattern.FindAllString(text, -1)),
+		Length:   utf8.RuneCountInString(strings.TrimSpace(post)),
+		Words:    len(words),
+	}
+	per100 := func(n int) float64 {
+		if q.Words == 0 {
+			return 0
+		}
+		return round(float64(n) * 100 / float64(q.Words))
+	}
+	q.EmojiDensity = per100(countEmojis(text))
+	buzzwords := findBuzzwords(words)
+	q.BuzzwordDensity = per100(len(buzzwords))
+	q.Hook = round(hookStrength(text))
+
+	metrics := []metric{
+		{weight: 25, grade: q.Hook, tip: "Open with a short first line that makes people read on, such as a question, a number or a bold claim."},
+		lengthMetric(q.Length),
+		hashtagMetric(q.Hashtags),
+		{weight: 10, grade: emojiGrade(q.EmojiDensity), tip: "Use fewer emojis."},
+	}
+	buzz := metric{weight: 15, grade: clamp(1 - q.BuzzwordDensity/5)}
+	if len(buzzwords) > 0 {
+		buzz.tip = fmt.Sprintf("Replace buzzwords such as %q with plain words.", buzzwords[0])
+	}
+	metrics = append(metrics, buzz)
+	if isEnglish(language) && q.Words > 0 {
+		ease := round(fleschReadingEase(prose, words))
+		q.Readability = &ease
+		metrics = append([]metric{{weight: 25, grade: clamp((ease - 20) / 40), tip: "Use shorter sentences and simpler words."}}, metrics...)
+	}
+
+	var total, weights float64
+	for _, m := range metrics {
+		total += m.weight * m.grade
+		weights += m.weight
+		if m.grade < 0.6 && m.tip != "" {
+			q.Tips = append(q.Tips, m.tip)
+		}
+	}
+	q.Score = int(math.Round(100 * total / weights))
+	return q
+}
+
+// lengthMetric grades length in characters: very short posts say too
+// little to be shown to many, and long ones lose readers before the end.
+// LinkedIn cuts posts off at 3000 characters.
+func lengthMetric(n int) metric {
+	switch {
+	case n < 100:
+		return metric{weight: 15, grade: float64(n) / 100, tip: "Add some detail; very short posts reach few people."}
+	case n > 1300:
+		return metric{weight: 15, grade: clamp(1 - float64(n-1300)/1700), tip: "Cut it down; long posts lose readers before the end."}
+	}
+	return metric{weight: 15, grade: 1}
+}
+
+// hashtagMetric grades the hashtag count: a few help a post be found,
+// many look like spam.
+func hashtagMetric(n int) metric {
+	switch {
+	case n == 0:
+		return metric{weight: 10, grade: 0.5, tip: "Add a few hashtags so the post can be found."}
+	case n > 5:
+		return metric{weight: 10, grade: clamp(1 - float64(n-5)/4), tip: "Use at most 5 hashtags."}
+	}
+	return metric{weight: 10, grade: 1}
+}
+
+// emojiGrade grades emojis per 100 words: a few add warmth, a post
+// without any is fine, and more than a handful is noise.
+func emojiGrade(density float64) float64 {
+	switch {
+	case density == 0:
+		return 0.8
+	case density <= 5:
+		return 1
+	}
+	return clamp(1 - (density-5)/10)
+}
+
+// hookStrength rates the first line. Short lines that ask, count or speak
+// to the reader pull people in; announcements of the author's excitement
+// and lines cut off by "see more" do not.
+func hookStrength(text string) float64 {
+	var hook string
+	for _, line := range strings.Split(text, "\n") {
+		if hook = strings.TrimSpace(line); hook != "" {
+			break
+		}
+	}
+	words := wordPattern.FindAllString(hook, -1)
+	if len(words) == 0 {
+		return 0
+	}
+	s := 0.4
+	switch {
+	case len(words) < 3:
+		s -= 0.2 // too little to make anyone curious
+	case len(words) <= 12:
+		s += 0.2
+	case len(words) > 25:
+		s -= 0.2
+	}
+	if utf8.RuneCountInString(hook) > previewLength {
+		s -= 0.2
+	}
+	if strings.Contains(hook, "?") {
+		s += 0.15
+	}
+	if strings.ContainsFunc(hook, unicode.IsDigit) {
+		s += 0.15
+	}
+	lower := strings.ToLower(hook)
+	for _, w := range words {
+		if w = strings.ToLower(w); w == "you" || w == "your" {
+			s += 0.1
+			break
+		}
+	}
+	for _, c := range cliches {
+		if strings.Contains(lower, c) {
+			s -= 0.4
+			break
+		}
+	}
+	return clamp(s)
+}
+
+// countEmojis counts emojis, taking a sequence joined with zero-width
+// joiners, or a pair of regional indicators that makes a flag, as one.
+func countEmojis(text string) int {
+	n := 0
+	var prev rune
+	flagHalf := false
+	for _, r := range text {
+		switch {
+		case r >= 0x1F1E6 && r <= 0x1F1FF:
+			if !flagHalf {
+				n++
+			}
+			flagHalf = !flagHalf
+		case unicode.Is(unicode.So, r) && r >= 0x2300 && prev != '\u200d':
+			n++
+			flagHalf = false
+		default:
+			flagHalf = false
+		}
+		prev = r
+	}
+	return n
+}
+
+func isEnglish(language string) bool {
+	lang := strings.ToLower(language)
+	return lang == "" || lang == "en" || strings.HasPrefix(lang, "en-")
+}
+
+func clamp(f float64) float64 {
+	return math.Max(0, math.Min(1, f))
+}
+
+func round(f float64) float64 {
+	return math.Round(f*100) / 100
+}
@@ -0,0 +1,100 @@
+package quality_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/quality"
+)
+
+const goodPost = "What does it take to ship 3 releases a week?\n\n" +
+	"We cut our deploy time from 2 hours to 9 minutes. The trick was boring: smaller changes, fewer approvals, better tests.\n\n" +
+	"What slows your team down? \U0001F680\n\n" +
+	"#DevOps #Engineering #ContinuousDelivery"
+
+const buzzwordPost = "I'm thrilled to announce that our team has leveraged cutting-edge synergies to unlock a paradigm shift " +
+	"in holistic, best-in-class, results-driven thought leadership across the ecosystem, empowering stakeholders to move the needle seamlessly."
+
+func TestAnalyze_GoodPost(t *testing.T) {
+	q := quality.Analyze(goodPost, "en-US")
+
+	assert.GreaterOrEqual(t, q.Score, 90)
+	require.NotNil(t, q.Readability)
+	assert.Greater(t, *q.Readability, 60.0)
+	assert.Equal(t, 3, q.Hashtags)
+	assert.Equal(t, 36, q.Words)
+	assert.Equal(t, 2.78, q.EmojiDensity)
+	assert.Zero(t, q.BuzzwordDensity)
+	assert.Greater(t, q.Hook, 0.8)
+	assert.Empty(t, q.Tips)
+}
+
+func TestAnalyze_BuzzwordsAndCliches(t *testing.T) {
+	q := quality.Analyze(buzzwordPost, "")
+
+	assert.Less(t, q.Score, 40)
+	assert.Zero(t, q.Hook, "a long opener announcing excitement")
+	assert.Greater(t, q.BuzzwordDensity, 25.0)
+	assert.Contains(t, q.Tips, `Replace buzzwords such as "leveraged" with plain words.`)
+	assert.Contains(t, q.Tips, "Use shorter sentences and simpler words.")
+	assert.Less(t, q.Score, quality.Analyze(goodPost, "").Score)
+}
+
+func TestAnalyze_ReadabilityOnlyForEnglish(t *testing.T) {
+	post := "Wir haben heute unser neues Produkt veröffentlicht. #Launch"
+
+	assert.NotNil(t, quality.Analyze(post, "").Readability)
+	assert.Nil(t, quality.Analyze(post, "de").Readability)
+}
+
+func TestAnalyze_StyledLettersReadAsPlain(t *testing.T) {
+	styled := quality.Analyze("\U0001D5E6\U0001D5F5\U0001D5F6\U0001D5FD\U0001D5F6\U0001D5FB\U0001D5F4 today. Our team grew to 12 people.", "")
+	plain := quality.Analyze("Shipping today. Our team grew to 12 people.", "")
+
+	assert.Equal(t, plain.Words, styled.Words)
+	assert.Equal(t, plain.Readability, styled.Readability)
+}
+
+func TestAnalyze_Emojis(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		text string
+		want float64
+	}{
+		{"none", "one two three four", 0},
+		{"single", "one two three four \U0001F680", 25},
+		{"zwj family", "one two three four \U0001F468\u200d\U0001F469\u200d\U0001F467", 25},
+		{"flag", "one two three four \U0001F1E9\U0001F1EA", 25},
+		{"skin tone", "one two three four \U0001F44D\U0001F3FD", 25},
+		{"not emoji", "one two three four → © •", 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, quality.Analyze(tc.text, "").EmojiDensity)
+		})
+	}
+}
+
+func TestAnalyze_HashtagsAndURLs(t *testing.T) {
+	q := quality.Analyze("Read it at https://example.com/a#section, then tell me.\n\n#One #Two", "")
+
+	assert.Equal(t, 2, q.Hashtags)
+	assert.Equal(t, 6, q.Words, "URLs and hashtags are not words")
+}
+
+func TestAnalyze_TooManyHashtagsAndTooShort(t *testing.T) {
+	q := quality.Analyze("ok #a #b #c #d #e #f #g", "")
+
+	assert.Contains(t, q.Tips, "Use at most 5 hashtags.")
+	assert.Contains(t, q.Tips, "Add some detail; very short posts reach few people.")
+	assert.Less(t, q.Score, quality.Analyze(goodPost, "").Score)
+}
+
+func TestAnalyze_Empty(t *testing.T) {
+	q := quality.Analyze("", "")
+
+	assert.Nil(t, q.Readability)
+	assert.Zero(t, q.Words)
+	assert.Zero(t, q.Hook)
+}
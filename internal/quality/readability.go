@@ -0,0 +1,42 @@
+package quality
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	sentenceBreak = regexp.MustCompile(`[.!?]+|\n`)
+	vowelGroups   = regexp.MustCompile(`[aeiouy]+`)
+)
+
+// fleschReadingEase computes the Flesch reading ease of English text from
+// its words: 206.835 - 1.015 × words per sentence - 84.6 × syllables per
+// word. Line breaks end sentences too, since posts often break lines
+// where prose would use a full stop.
+func fleschReadingEase(text string, words []string) float64 {
+	sentences := 0
+	for _, s := range sentenceBreak.Split(text, -1) {
+		if wordPattern.MatchString(s) {
+			sentences++
+		}
+	}
+	syllables := 0
+	for _, w := range words {
+		syllables += countSyllables(w)
+	}
+	n := float64(len(words))
+	return 206.835 - 1.015*n/float64(max(sentences, 1)) - 84.6*float64(syllables)/n
+}
+
+// countSyllables estimates the syllables of an English word by its vowel
+// groups, not counting a silent final "e". Numbers and words in other
+// scripts count as one syllable.
+func countSyllables(word string) int {
+	w := strings.ToLower(word)
+	n := len(vowelGroups.FindAllString(w, -1))
+	if n > 1 && strings.HasSuffix(w, "e") && !strings.HasSuffix(w, "le") && !strings.HasSuffix(w, "ee") {
+		n--
+	}
+	return max(n, 1)
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/you/linkedinify/internal/model"
+)
+
+type AuditRepository interface {
+	Record(ctx context.Context, e *model.AuditEvent) error
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]model.AuditEvent, error)
+}
+
+type auditRepo struct{ db *bun.DB }
+
+func NewAuditRepo(db *bun.DB) AuditRepository { return &auditRepo{db} }
+
+func (r *auditRepo) Record(ctx context.Context, e *model.AuditEvent) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	_, err := r.db.NewInsert().Model(e).Exec(ctx)
+	return err
+}
+
+func (r *auditRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]model.AuditEvent, error) {
+	var events []model.AuditEvent
+	err := r.db.NewSelect().
+		Model(&events).
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Scan(ctx)
+	return events, err
+}
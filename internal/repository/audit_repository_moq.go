@@ -0,0 +1,133 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/you/linkedinify/internal/model"
+	"sync"
+)
+
+// Ensure, that AuditRepositoryMock does implement AuditRepository.
+// If this is not the case, regenerate this file with moq.
+var _ AuditRepository = &AuditRepositoryMock{}
+
+// AuditRepositoryMock is a mock implementation of AuditRepository.
+//
+//	func TestSomethingThatUsesAuditRepository(t *testing.T) {
+//
+//		// make and configure a mocked AuditRepository
+//		mockedAuditRepository := &AuditRepositoryMock{
+//			ListByUserFunc: func(ctx context.Context, userID uuid.UUID) ([]model.AuditEvent, error) {
+//				panic("mock out the ListByUser method")
+//			},
+//			RecordFunc: func(ctx context.Context, e *model.AuditEvent) error {
+//				panic("mock out the Record method")
+//			},
+//		}
+//
+//		// use mockedAuditRepository in code that requires AuditRepository
+//		// and then make assertions.
+//
+//	}
+type AuditRepositoryMock struct {
+	// ListByUserFunc mocks the ListByUser method.
+	ListByUserFunc func(ctx context.Context, userID uuid.UUID) ([]model.AuditEvent, error)
+
+	// RecordFunc mocks the Record method.
+	RecordFunc func(ctx context.Context, e *model.AuditEvent) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListByUser holds details about calls to the ListByUser method.
+		ListByUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// Record holds details about calls to the Record method.
+		Record []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// E is the e argument value.
+			E *model.AuditEvent
+		}
+	}
+	lockListByUser sync.RWMutex
+	lockRecord     sync.RWMutex
+}
+
+// ListByUser calls ListByUserFunc.
+func (mock *AuditRepositoryMock) ListByUser(ctx context.Context, userID uuid.UUID) ([]model.AuditEvent, error) {
+	if mock.ListByUserFunc == nil {
+		panic("AuditRepositoryMock.ListByUserFunc: method is nil but AuditRepository.ListByUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockListByUser.Lock()
+	mock.calls.ListByUser = append(mock.calls.ListByUser, callInfo)
+	mock.lockListByUser.Unlock()
+	return mock.ListByUserFunc(ctx, userID)
+}
+
+// ListByUserCalls gets all the calls that were made to ListByUser.
+// Check the length with:
+//
+//	len(mockedAuditRepository.ListByUserCalls())
+func (mock *AuditRepositoryMock) ListByUserCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockListByUser.RLock()
+	calls = mock.calls.ListByUser
+	mock.lockListByUser.RUnlock()
+	return calls
+}
+
+// Record calls RecordFunc.
+func (mock *AuditRepositoryMock) Record(ctx context.Context, e *model.AuditEvent) error {
+	if mock.RecordFunc == nil {
+		panic("AuditRepositoryMock.RecordFunc: method is nil but AuditRepository.Record was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		E   *model.AuditEvent
+	}{
+		Ctx: ctx,
+		E:   e,
+	}
+	mock.lockRecord.Lock()
+	mock.calls.Record = append(mock.calls.Record, callInfo)
+	mock.lockRecord.Unlock()
+	return mock.RecordFunc(ctx, e)
+}
+
+// RecordCalls gets all the calls that were made to Record.
+// Check the length with:
+//
+//	len(mockedAuditRepository.RecordCalls())
+func (mock *AuditRepositoryMock) RecordCalls() []struct {
+	Ctx context.Context
+	E   *model.AuditEvent
+} {
+	var calls []struct {
+		Ctx context.Context
+		E   *model.AuditEvent
+	}
+	mock.lockRecord.RLock()
+	calls = mock.calls.Record
+	mock.lockRecord.RUnlock()
+	return calls
+}
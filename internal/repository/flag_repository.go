@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+
+	"github.com/you/linkedinify/internal/model"
+)
+
+type FlagRepository interface {
+	List(ctx context.Context) ([]model.FeatureFlag, error)
+	FindByKey(ctx context.Context, key string) (*model.FeatureFlag, error)
+	// Upsert creates the flag or replaces the one with the same key.
+	Upsert(ctx context.Context, f *model.FeatureFlag) error
+	// Delete removes a flag and reports whether it existed.
+	Delete(ctx context.Context, key string) (bool, error)
+}
+
+type flagRepo struct{ db *bun.DB }
+
+func NewFlagRepo(db *bun.DB) FlagRepository { return &flagRepo{db} }
+
+func (r *flagRepo) List(ctx context.Context) ([]model.FeatureFlag, error) {
+	var flags []model.FeatureFlag
+	err := r.db.NewSelect().Model(&flags).Order("key").Scan(ctx)
+	return flags, err
+}
+
+func (r *flagRepo) FindByKey(ctx context.Context, key string) (*model.FeatureFlag, error) {
+	f := new(model.FeatureFlag)
+	if err := r.db.NewSelect().Model(f).Where("key = ?", key).Scan(ctx); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (r *flagRepo) Upsert(ctx context.Context, f *model.FeatureFlag) error {
+	_, err := r.db.NewInsert().
+		Model(f).
+		On("CONFLICT (key) DO UPDATE").
+		Set("description = EXCLUDED.description").
+		Set("enabled = EXCLUDED.enabled").
+		Set("variants = EXCLUDED.variants").
+		Set("default_variant = EXCLUDED.default_variant").
+		Set("rules = EXCLUDED.rules").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+	return err
+}
+
+func (r *flagRepo) Delete(ctx context.Context, key string) (bool, error) {
+	res, err := r.db.NewDelete().Model((*model.FeatureFlag)(nil)).Where("key = ?", key).Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
@@ -0,0 +1,226 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package repository
+
+import (
+	"context"
+	"github.com/you/linkedinify/internal/model"
+	"sync"
+)
+
+// Ensure, that FlagRepositoryMock does implement FlagRepository.
+// If this is not the case, regenerate this file with moq.
+var _ FlagRepository = &FlagRepositoryMock{}
+
+// FlagRepositoryMock is a mock implementation of FlagRepository.
+//
+//	func TestSomethingThatUsesFlagRepository(t *testing.T) {
+//
+//		// make and configure a mocked FlagRepository
+//		mockedFlagRepository := &FlagRepositoryMock{
+//			DeleteFunc: func(ctx context.Context, key string) (bool, error) {
+//				panic("mock out the Delete method")
+//			},
+//			FindByKeyFunc: func(ctx context.Context, key string) (*model.FeatureFlag, error) {
+//				panic("mock out the FindByKey method")
+//			},
+//			ListFunc: func(ctx context.Context) ([]model.FeatureFlag, error) {
+//				panic("mock out the List method")
+//			},
+//			UpsertFunc: func(ctx context.Context, f *model.FeatureFlag) error {
+//				panic("mock out the Upsert method")
+//			},
+//		}
+//
+//		// use mockedFlagRepository in code that requires FlagRepository
+//		// and then make assertions.
+//
+//	}
+type FlagRepositoryMock struct {
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, key string) (bool, error)
+
+	// FindByKeyFunc mocks the FindByKey method.
+	FindByKeyFunc func(ctx context.Context, key string) (*model.FeatureFlag, error)
+
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context) ([]model.FeatureFlag, error)
+
+	// UpsertFunc mocks the Upsert method.
+	UpsertFunc func(ctx context.Context, f *model.FeatureFlag) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// FindByKey holds details about calls to the FindByKey method.
+		FindByKey []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Upsert holds details about calls to the Upsert method.
+		Upsert []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// F is the f argument value.
+			F *model.FeatureFlag
+		}
+	}
+	lockDelete    sync.RWMutex
+	lockFindByKey sync.RWMutex
+	lockList      sync.RWMutex
+	lockUpsert    sync.RWMutex
+}
+
+// Delete calls DeleteFunc.
+func (mock *FlagRepositoryMock) Delete(ctx context.Context, key string) (bool, error) {
+	if mock.DeleteFunc == nil {
+		panic("FlagRepositoryMock.DeleteFunc: method is nil but FlagRepository.Delete was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(ctx, key)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedFlagRepository.DeleteCalls())
+func (mock *FlagRepositoryMock) DeleteCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// FindByKey calls FindByKeyFunc.
+func (mock *FlagRepositoryMock) FindByKey(ctx context.Context, key string) (*model.FeatureFlag, error) {
+	if mock.FindByKeyFunc == nil {
+		panic("FlagRepositoryMock.FindByKeyFunc: method is nil but FlagRepository.FindByKey was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockFindByKey.Lock()
+	mock.calls.FindByKey = append(mock.calls.FindByKey, callInfo)
+	mock.lockFindByKey.Unlock()
+	return mock.FindByKeyFunc(ctx, key)
+}
+
+// FindByKeyCalls gets all the calls that were made to FindByKey.
+// Check the length with:
+//
+//	len(mockedFlagRepository.FindByKeyCalls())
+func (mock *FlagRepositoryMock) FindByKeyCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockFindByKey.RLock()
+	calls = mock.calls.FindByKey
+	mock.lockFindByKey.RUnlock()
+	return calls
+}
+
+// List calls ListFunc.
+func (mock *FlagRepositoryMock) List(ctx context.Context) ([]model.FeatureFlag, error) {
+	if mock.ListFunc == nil {
+		panic("FlagRepositoryMock.ListFunc: method is nil but FlagRepository.List was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(ctx)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedFlagRepository.ListCalls())
+func (mock *FlagRepositoryMock) ListCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// Upsert calls UpsertFunc.
+func (mock *FlagRepositoryMock) Upsert(ctx context.Context, f *model.FeatureFlag) error {
+	if mock.UpsertFunc == nil {
+		panic("FlagRepositoryMock.UpsertFunc: method is nil but FlagRepository.Upsert was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		F   *model.FeatureFlag
+	}{
+		Ctx: ctx,
+		F:   f,
+	}
+	mock.lockUpsert.Lock()
+	mock.calls.Upsert = append(mock.calls.Upsert, callInfo)
+	mock.lockUpsert.Unlock()
+	return mock.UpsertFunc(ctx, f)
+}
+
+// UpsertCalls gets all the calls that were made to Upsert.
+// Check the length with:
+//
+//	len(mockedFlagRepository.UpsertCalls())
+func (mock *FlagRepositoryMock) UpsertCalls() []struct {
+	Ctx context.Context
+	F   *model.FeatureFlag
+} {
+	var calls []struct {
+		Ctx context.Context
+		F   *model.FeatureFlag
+	}
+	mock.lockUpsert.RLock()
+	calls = mock.calls.Upsert
+	mock.lockUpsert.RUnlock()
+	return calls
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/you/linkedinify/internal/model"
+)
+
+// ImageRepository stores the records of images attached to posts. Lookups
+// are scoped to the owning user.
+type ImageRepository interface {
+	Create(ctx context.Context, img *model.PostImage) error
+	FindByID(ctx context.Context, userID, postID, id uuid.UUID) (*model.PostImage, error)
+	// ListByPost returns a post's images, oldest first.
+	ListByPost(ctx context.Context, userID, postID uuid.UUID) ([]model.PostImage, error)
+	// ListByUser returns all of a user's images, oldest first.
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]model.PostImage, error)
+}
+
+type imageRepo struct{ db *bun.DB }
+
+func NewImageRepo(db *bun.DB) ImageRepository { return &imageRepo{db} }
+
+func (r *imageRepo) Create(ctx context.Context, img *model.PostImage) error {
+	_, err := r.db.NewInsert().Model(img).Exec(ctx)
+	return err
+}
+
+func (r *imageRepo) FindByID(ctx context.Context, userID, postID, id uuid.UUID) (*model.PostImage, error) {
+	img := new(model.PostImage)
+	err := r.db.NewSelect().
+		Model(img).
+		Where("id = ?", id).
+		Where("post_id = ?", postID).
+		Where("user_id = ?", userID).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+func (r *imageRepo) ListByPost(ctx context.Context, userID, postID uuid.UUID) ([]model.PostImage, error) {
+	var images []model.PostImage
+	err := r.db.NewSelect().
+		Model(&images).
+		Where("post_id = ?", postID).
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Scan(ctx)
+	return images, err
+}
+
+func (r *imageRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]model.PostImage, error) {
+	var images []model.PostImage
+	err := r.db.NewSelect().
+		Model(&images).
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Scan(ctx)
+	return images, err
+}
@@ -0,0 +1,251 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/you/linkedinify/internal/model"
+	"sync"
+)
+
+// Ensure, that ImageRepositoryMock does implement ImageRepository.
+// If this is not the case, regenerate this file with moq.
+var _ ImageRepository = &ImageRepositoryMock{}
+
+// ImageRepositoryMock is a mock implementation of ImageRepository.
+//
+//	func TestSomethingThatUsesImageRepository(t *testing.T) {
+//
+//		// make and configure a mocked ImageRepository
+//		mockedImageRepository := &ImageRepositoryMock{
+//			CreateFunc: func(ctx context.Context, img *model.PostImage) error {
+//				panic("mock out the Create method")
+//			},
+//			FindByIDFunc: func(ctx context.Context, userID uuid.UUID, postID uuid.UUID, id uuid.UUID) (*model.PostImage, error) {
+//				panic("mock out the FindByID method")
+//			},
+//			ListByPostFunc: func(ctx context.Context, userID uuid.UUID, postID uuid.UUID) ([]model.PostImage, error) {
+//				panic("mock out the ListByPost method")
+//			},
+//			ListByUserFunc: func(ctx context.Context, userID uuid.UUID) ([]model.PostImage, error) {
+//				panic("mock out the ListByUser method")
+//			},
+//		}
+//
+//		// use mockedImageRepository in code that requires ImageRepository
+//		// and then make assertions.
+//
+//	}
+type ImageRepositoryMock struct {
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, img *model.PostImage) error
+
+	// FindByIDFunc mocks the FindByID method.
+	FindByIDFunc func(ctx context.Context, userID uuid.UUID, postID uuid.UUID, id uuid.UUID) (*model.PostImage, error)
+
+	// ListByPostFunc mocks the ListByPost method.
+	ListByPostFunc func(ctx context.Context, userID uuid.UUID, postID uuid.UUID) ([]model.PostImage, error)
+
+	// ListByUserFunc mocks the ListByUser method.
+	ListByUserFunc func(ctx context.Context, userID uuid.UUID) ([]model.PostImage, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Img is the img argument value.
+			Img *model.PostImage
+		}
+		// FindByID holds details about calls to the FindByID method.
+		FindByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// PostID is the postID argument value.
+			PostID uuid.UUID
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// ListByPost holds details about calls to the ListByPost method.
+		ListByPost []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// PostID is the postID argument value.
+			PostID uuid.UUID
+		}
+		// ListByUser holds details about calls to the ListByUser method.
+		ListByUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+	}
+	lockCreate     sync.RWMutex
+	lockFindByID   sync.RWMutex
+	lockListByPost sync.RWMutex
+	lockListByUser sync.RWMutex
+}
+
+// Create calls CreateFunc.
+func (mock *ImageRepositoryMock) Create(ctx context.Context, img *model.PostImage) error {
+	if mock.CreateFunc == nil {
+		panic("ImageRepositoryMock.CreateFunc: method is nil but ImageRepository.Create was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Img *model.PostImage
+	}{
+		Ctx: ctx,
+		Img: img,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	return mock.CreateFunc(ctx, img)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedImageRepository.CreateCalls())
+func (mock *ImageRepositoryMock) CreateCalls() []struct {
+	Ctx context.Context
+	Img *model.PostImage
+} {
+	var calls []struct {
+		Ctx context.Context
+		Img *model.PostImage
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// FindByID calls FindByIDFunc.
+func (mock *ImageRepositoryMock) FindByID(ctx context.Context, userID uuid.UUID, postID uuid.UUID, id uuid.UUID) (*model.PostImage, error) {
+	if mock.FindByIDFunc == nil {
+		panic("ImageRepositoryMock.FindByIDFunc: method is nil but ImageRepository.FindByID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+		ID     uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		PostID: postID,
+		ID:     id,
+	}
+	mock.lockFindByID.Lock()
+	mock.calls.FindByID = append(mock.calls.FindByID, callInfo)
+	mock.lockFindByID.Unlock()
+	return mock.FindByIDFunc(ctx, userID, postID, id)
+}
+
+// FindByIDCalls gets all the calls that were made to FindByID.
+// Check the length with:
+//
+//	len(mockedImageRepository.FindByIDCalls())
+func (mock *ImageRepositoryMock) FindByIDCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	PostID uuid.UUID
+	ID     uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+		ID     uuid.UUID
+	}
+	mock.lockFindByID.RLock()
+	calls = mock.calls.FindByID
+	mock.lockFindByID.RUnlock()
+	return calls
+}
+
+// ListByPost calls ListByPostFunc.
+func (mock *ImageRepositoryMock) ListByPost(ctx context.Context, userID uuid.UUID, postID uuid.UUID) ([]model.PostImage, error) {
+	if mock.ListByPostFunc == nil {
+		panic("ImageRepositoryMock.ListByPostFunc: method is nil but ImageRepository.ListByPost was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		PostID: postID,
+	}
+	mock.lockListByPost.Lock()
+	mock.calls.ListByPost = append(mock.calls.ListByPost, callInfo)
+	mock.lockListByPost.Unlock()
+	return mock.ListByPostFunc(ctx, userID, postID)
+}
+
+// ListByPostCalls gets all the calls that were made to ListByPost.
+// Check the length with:
+//
+//	len(mockedImageRepository.ListByPostCalls())
+func (mock *ImageRepositoryMock) ListByPostCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	PostID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+	}
+	mock.lockListByPost.RLock()
+	calls = mock.calls.ListByPost
+	mock.lockListByPost.RUnlock()
+	return calls
+}
+
+// ListByUser calls ListByUserFunc.
+func (mock *ImageRepositoryMock) ListByUser(ctx context.Context, userID uuid.UUID) ([]model.PostImage, error) {
+	if mock.ListByUserFunc == nil {
+		panic("ImageRepositoryMock.ListByUserFunc: method is nil but ImageRepository.ListByUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockListByUser.Lock()
+	mock.calls.ListByUser = append(mock.calls.ListByUser, callInfo)
+	mock.lockListByUser.Unlock()
+	return mock.ListByUserFunc(ctx, userID)
+}
+
+// ListByUserCalls gets all the calls that were made to ListByUser.
+// Check the length with:
+//
+//	len(mockedImageRepository.ListByUserCalls())
+func (mock *ImageRepositoryMock) ListByUserCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockListByUser.RLock()
+	calls = mock.calls.ListByUser
+	mock.lockListByUser.RUnlock()
+	return calls
+}
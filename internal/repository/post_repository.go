@@ -10,9 +10,36 @@ import (
 	"github.com/you/linkedinify/internal/model"
 )
 
+// PostFilter narrows and orders a history listing; zero fields do not
+// filter.
+type PostFilter struct {
+	Language string
+	// Sort is SortNewest, the default when empty, or SortScore.
+	Sort string
+}
+
+// Orders for PostFilter.Sort.
+const (
+	SortNewest = "newest"
+	// SortScore lists the best scored posts first, and posts without a
+	// quality score last.
+	SortScore = "score"
+)
+
 type PostRepository interface {
 	Save(ctx context.Context, p *model.LinkedInPost) error
-	ListByUser(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]model.LinkedInPost, error)
+	FindByID(ctx context.Context, userID, id uuid.UUID) (*model.LinkedInPost, error)
+	ListByUser(ctx context.Context, userID uuid.UUID, page, pageSize int, filter PostFilter) ([]model.LinkedInPost, error)
+	// ForEachByUser streams every post of a user, oldest first, without loading
+	// the whole history into memory.
+	ForEachByUser(ctx context.Context, userID uuid.UUID, fn func(*model.LinkedInPost) error) error
+	// Import inserts posts, skipping IDs that already exist, and returns how
+	// many rows were actually written.
+	Import(ctx context.Context, posts []model.LinkedInPost) (int, error)
+	// HashtagCounts counts the hashtags in the user's latest posts, at most
+	// recent of them, without regard to case. Tags are keyed without '#' in
+	// one of the spellings used. Translations are not counted.
+	HashtagCounts(ctx context.Context, userID uuid.UUID, recent int) (map[string]int, error)
 }
 
 type postRepo struct{ db *bun.DB }
@@ -24,15 +51,90 @@ func (p *postRepo) Save(ctx context.Context, post *model.LinkedInPost) error {
 	return err
 }
 
-func (p *postRepo) ListByUser(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]model.LinkedInPost, error) {
+func (p *postRepo) FindByID(ctx context.Context, userID, id uuid.UUID) (*model.LinkedInPost, error) {
+	post := new(model.LinkedInPost)
+	err := p.db.NewSelect().Model(post).Where("id = ?", id).Where("user_id = ?", userID).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+func (p *postRepo) ListByUser(ctx context.Context, userID uuid.UUID, page, pageSize int, filter PostFilter) ([]model.LinkedInPost, error) {
 	var posts []model.LinkedInPost
 	offset := (page - 1) * pageSize
-	err := p.db.NewSelect().
+	q := p.db.NewSelect().
 		Model(&posts).
-		Where("user_id = ?", userID).
+		Where("user_id = ?", userID)
+	if filter.Language != "" {
+		q = q.Where("language = ?", filter.Language)
+	}
+	if filter.Sort == SortScore {
+		q = q.OrderExpr("(quality->>'score')::int DESC NULLS LAST")
+	}
+	err := q.
 		Order("created_at DESC").
 		Limit(pageSize).
 		Offset(offset).
 		Scan(ctx)
 	return posts, err
 }
+
+func (p *postRepo) ForEachByUser(ctx context.Context, userID uuid.UUID, fn func(*model.LinkedInPost) error) error {
+	rows, err := p.db.NewSelect().
+		Model((*model.LinkedInPost)(nil)).
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Rows(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		post := new(model.LinkedInPost)
+		if err := p.db.ScanRow(ctx, rows, post); err != nil {
+			return err
+		}
+		if err := fn(post); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (p *postRepo) Import(ctx context.Context, posts []model.LinkedInPost) (int, error) {
+	if len(posts) == 0 {
+		return 0, nil
+	}
+	res, err := p.db.NewInsert().
+		Model(&posts).
+		On("CONFLICT (id) DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+func (p *postRepo) HashtagCounts(ctx context.Context, userID uuid.UUID, recent int) (map[string]int, error) {
+	var rows []struct {
+		Tag  string
+		Uses int
+	}
+	err := p.db.NewRaw(`SELECT min(m[1]) AS tag, count(*) AS uses
+		FROM (SELECT output_text FROM linkedin_posts
+			WHERE user_id = ? AND source_post_id IS NULL
+			ORDER BY created_at DESC LIMIT ?) AS recent,
+			regexp_matches(recent.output_text, '#([[:alnum:]_]+)', 'g') AS m
+		GROUP BY lower(m[1])`, userID, recent).Scan(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int, len(rows))
+	for _, r := range rows {
+		counts[r.Tag] = r.Uses
+	}
+	return counts, nil
+}
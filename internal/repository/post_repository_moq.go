@@ -20,7 +20,19 @@ var _ PostRepository = &PostRepositoryMock{}
 //
 //		// make and configure a mocked PostRepository
 //		mockedPostRepository := &PostRepositoryMock{
-//			ListByUserFunc: func(ctx context.Context, userID uuid.UUID, page int, pageSize int) ([]model.LinkedInPost, error) {
+//			FindByIDFunc: func(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*model.LinkedInPost, error) {
+//				panic("mock out the FindByID method")
+//			},
+//			ForEachByUserFunc: func(ctx context.Context, userID uuid.UUID, fn func(*model.LinkedInPost) error) error {
+//				panic("mock out the ForEachByUser method")
+//			},
+//			HashtagCountsFunc: func(ctx context.Context, userID uuid.UUID, recent int) (map[string]int, error) {
+//				panic("mock out the HashtagCounts method")
+//			},
+//			ImportFunc: func(ctx context.Context, posts []model.LinkedInPost) (int, error) {
+//				panic("mock out the Import method")
+//			},
+//			ListByUserFunc: func(ctx context.Context, userID uuid.UUID, page int, pageSize int, filter PostFilter) ([]model.LinkedInPost, error) {
 //				panic("mock out the ListByUser method")
 //			},
 //			SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error {
@@ -33,14 +45,60 @@ var _ PostRepository = &PostRepositoryMock{}
 //
 //	}
 type PostRepositoryMock struct {
+	// FindByIDFunc mocks the FindByID method.
+	FindByIDFunc func(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*model.LinkedInPost, error)
+
+	// ForEachByUserFunc mocks the ForEachByUser method.
+	ForEachByUserFunc func(ctx context.Context, userID uuid.UUID, fn func(*model.LinkedInPost) error) error
+
+	// HashtagCountsFunc mocks the HashtagCounts method.
+	HashtagCountsFunc func(ctx context.Context, userID uuid.UUID, recent int) (map[string]int, error)
+
+	// ImportFunc mocks the Import method.
+	ImportFunc func(ctx context.Context, posts []model.LinkedInPost) (int, error)
+
 	// ListByUserFunc mocks the ListByUser method.
-	ListByUserFunc func(ctx context.Context, userID uuid.UUID, page int, pageSize int) ([]model.LinkedInPost, error)
+	ListByUserFunc func(ctx context.Context, userID uuid.UUID, page int, pageSize int, filter PostFilter) ([]model.LinkedInPost, error)
 
 	// SaveFunc mocks the Save method.
 	SaveFunc func(ctx context.Context, p *model.LinkedInPost) error
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// FindByID holds details about calls to the FindByID method.
+		FindByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// ForEachByUser holds details about calls to the ForEachByUser method.
+		ForEachByUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Fn is the fn argument value.
+			Fn func(*model.LinkedInPost) error
+		}
+		// HashtagCounts holds details about calls to the HashtagCounts method.
+		HashtagCounts []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Recent is the recent argument value.
+			Recent int
+		}
+		// Import holds details about calls to the Import method.
+		Import []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Posts is the posts argument value.
+			Posts []model.LinkedInPost
+		}
 		// ListByUser holds details about calls to the ListByUser method.
 		ListByUser []struct {
 			// Ctx is the ctx argument value.
@@ -51,6 +109,8 @@ type PostRepositoryMock struct {
 			Page int
 			// PageSize is the pageSize argument value.
 			PageSize int
+			// Filter is the filter argument value.
+			Filter PostFilter
 		}
 		// Save holds details about calls to the Save method.
 		Save []struct {
@@ -60,12 +120,172 @@ type PostRepositoryMock struct {
 			P *model.LinkedInPost
 		}
 	}
-	lockListByUser sync.RWMutex
-	lockSave       sync.RWMutex
+	lockFindByID      sync.RWMutex
+	lockForEachByUser sync.RWMutex
+	lockHashtagCounts sync.RWMutex
+	lockImport        sync.RWMutex
+	lockListByUser    sync.RWMutex
+	lockSave          sync.RWMutex
+}
+
+// FindByID calls FindByIDFunc.
+func (mock *PostRepositoryMock) FindByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*model.LinkedInPost, error) {
+	if mock.FindByIDFunc == nil {
+		panic("PostRepositoryMock.FindByIDFunc: method is nil but PostRepository.FindByID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		ID:     id,
+	}
+	mock.lockFindByID.Lock()
+	mock.calls.FindByID = append(mock.calls.FindByID, callInfo)
+	mock.lockFindByID.Unlock()
+	return mock.FindByIDFunc(ctx, userID, id)
+}
+
+// FindByIDCalls gets all the calls that were made to FindByID.
+// Check the length with:
+//
+//	len(mockedPostRepository.FindByIDCalls())
+func (mock *PostRepositoryMock) FindByIDCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	ID     uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}
+	mock.lockFindByID.RLock()
+	calls = mock.calls.FindByID
+	mock.lockFindByID.RUnlock()
+	return calls
+}
+
+// ForEachByUser calls ForEachByUserFunc.
+func (mock *PostRepositoryMock) ForEachByUser(ctx context.Context, userID uuid.UUID, fn func(*model.LinkedInPost) error) error {
+	if mock.ForEachByUserFunc == nil {
+		panic("PostRepositoryMock.ForEachByUserFunc: method is nil but PostRepository.ForEachByUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Fn     func(*model.LinkedInPost) error
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Fn:     fn,
+	}
+	mock.lockForEachByUser.Lock()
+	mock.calls.ForEachByUser = append(mock.calls.ForEachByUser, callInfo)
+	mock.lockForEachByUser.Unlock()
+	return mock.ForEachByUserFunc(ctx, userID, fn)
+}
+
+// ForEachByUserCalls gets all the calls that were made to ForEachByUser.
+// Check the length with:
+//
+//	len(mockedPostRepository.ForEachByUserCalls())
+func (mock *PostRepositoryMock) ForEachByUserCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Fn     func(*model.LinkedInPost) error
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Fn     func(*model.LinkedInPost) error
+	}
+	mock.lockForEachByUser.RLock()
+	calls = mock.calls.ForEachByUser
+	mock.lockForEachByUser.RUnlock()
+	return calls
+}
+
+// HashtagCounts calls HashtagCountsFunc.
+func (mock *PostRepositoryMock) HashtagCounts(ctx context.Context, userID uuid.UUID, recent int) (map[string]int, error) {
+	if mock.HashtagCountsFunc == nil {
+		panic("PostRepositoryMock.HashtagCountsFunc: method is nil but PostRepository.HashtagCounts was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Recent int
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Recent: recent,
+	}
+	mock.lockHashtagCounts.Lock()
+	mock.calls.HashtagCounts = append(mock.calls.HashtagCounts, callInfo)
+	mock.lockHashtagCounts.Unlock()
+	return mock.HashtagCountsFunc(ctx, userID, recent)
+}
+
+// HashtagCountsCalls gets all the calls that were made to HashtagCounts.
+// Check the length with:
+//
+//	len(mockedPostRepository.HashtagCountsCalls())
+func (mock *PostRepositoryMock) HashtagCountsCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Recent int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Recent int
+	}
+	mock.lockHashtagCounts.RLock()
+	calls = mock.calls.HashtagCounts
+	mock.lockHashtagCounts.RUnlock()
+	return calls
+}
+
+// Import calls ImportFunc.
+func (mock *PostRepositoryMock) Import(ctx context.Context, posts []model.LinkedInPost) (int, error) {
+	if mock.ImportFunc == nil {
+		panic("PostRepositoryMock.ImportFunc: method is nil but PostRepository.Import was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Posts []model.LinkedInPost
+	}{
+		Ctx:   ctx,
+		Posts: posts,
+	}
+	mock.lockImport.Lock()
+	mock.calls.Import = append(mock.calls.Import, callInfo)
+	mock.lockImport.Unlock()
+	return mock.ImportFunc(ctx, posts)
+}
+
+// ImportCalls gets all the calls that were made to Import.
+// Check the length with:
+//
+//	len(mockedPostRepository.ImportCalls())
+func (mock *PostRepositoryMock) ImportCalls() []struct {
+	Ctx   context.Context
+	Posts []model.LinkedInPost
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Posts []model.LinkedInPost
+	}
+	mock.lockImport.RLock()
+	calls = mock.calls.Import
+	mock.lockImport.RUnlock()
+	return calls
 }
 
 // ListByUser calls ListByUserFunc.
-func (mock *PostRepositoryMock) ListByUser(ctx context.Context, userID uuid.UUID, page int, pageSize int) ([]model.LinkedInPost, error) {
+func (mock *PostRepositoryMock) ListByUser(ctx context.Context, userID uuid.UUID, page int, pageSize int, filter PostFilter) ([]model.LinkedInPost, error) {
 	if mock.ListByUserFunc == nil {
 		panic("PostRepositoryMock.ListByUserFunc: method is nil but PostRepository.ListByUser was just called")
 	}
@@ -74,16 +294,18 @@ func (mock *PostRepositoryMock) ListByUser(ctx context.Context, userID uuid.UUID
 		UserID   uuid.UUID
 		Page     int
 		PageSize int
+		Filter   PostFilter
 	}{
 		Ctx:      ctx,
 		UserID:   userID,
 		Page:     page,
 		PageSize: pageSize,
+		Filter:   filter,
 	}
 	mock.lockListByUser.Lock()
 	mock.calls.ListByUser = append(mock.calls.ListByUser, callInfo)
 	mock.lockListByUser.Unlock()
-	return mock.ListByUserFunc(ctx, userID, page, pageSize)
+	return mock.ListByUserFunc(ctx, userID, page, pageSize, filter)
 }
 
 // ListByUserCalls gets all the calls that were made to ListByUser.
@@ -95,12 +317,14 @@ func (mock *PostRepositoryMock) ListByUserCalls() []struct {
 	UserID   uuid.UUID
 	Page     int
 	PageSize int
+	Filter   PostFilter
 } {
 	var calls []struct {
 		Ctx      context.Context
 		UserID   uuid.UUID
 		Page     int
 		PageSize int
+		Filter   PostFilter
 	}
 	mock.lockListByUser.RLock()
 	calls = mock.calls.ListByUser
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+
+	"github.com/you/linkedinify/internal/model"
+)
+
+// SettingsRepository stores the versions of the runtime settings.
+type SettingsRepository interface {
+	// Latest returns the highest version, or sql.ErrNoRows if none was saved.
+	Latest(ctx context.Context) (*model.RuntimeSettings, error)
+	// Create inserts a new version and reports false if that version
+	// number is already taken.
+	Create(ctx context.Context, s *model.RuntimeSettings) (bool, error)
+	// List returns up to limit versions, newest first.
+	List(ctx context.Context, limit int) ([]model.RuntimeSettings, error)
+}
+
+type settingsRepo struct{ db *bun.DB }
+
+func NewSettingsRepo(db *bun.DB) SettingsRepository { return &settingsRepo{db} }
+
+func (r *settingsRepo) Latest(ctx context.Context) (*model.RuntimeSettings, error) {
+	s := new(model.RuntimeSettings)
+	err := r.db.NewSelect().Model(s).Order("version DESC").Limit(1).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (r *settingsRepo) Create(ctx context.Context, s *model.RuntimeSettings) (bool, error) {
+	res, err := r.db.NewInsert().Model(s).On("CONFLICT (version) DO NOTHING").Returning("created_at").Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (r *settingsRepo) List(ctx context.Context, limit int) ([]model.RuntimeSettings, error) {
+	var versions []model.RuntimeSettings
+	err := r.db.NewSelect().Model(&versions).Order("version DESC").Limit(limit).Scan(ctx)
+	return versions, err
+}
@@ -0,0 +1,176 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package repository
+
+import (
+	"context"
+	"github.com/you/linkedinify/internal/model"
+	"sync"
+)
+
+// Ensure, that SettingsRepositoryMock does implement SettingsRepository.
+// If this is not the case, regenerate this file with moq.
+var _ SettingsRepository = &SettingsRepositoryMock{}
+
+// SettingsRepositoryMock is a mock implementation of SettingsRepository.
+//
+//	func TestSomethingThatUsesSettingsRepository(t *testing.T) {
+//
+//		// make and configure a mocked SettingsRepository
+//		mockedSettingsRepository := &SettingsRepositoryMock{
+//			CreateFunc: func(ctx context.Context, s *model.RuntimeSettings) (bool, error) {
+//				panic("mock out the Create method")
+//			},
+//			LatestFunc: func(ctx context.Context) (*model.RuntimeSettings, error) {
+//				panic("mock out the Latest method")
+//			},
+//			ListFunc: func(ctx context.Context, limit int) ([]model.RuntimeSettings, error) {
+//				panic("mock out the List method")
+//			},
+//		}
+//
+//		// use mockedSettingsRepository in code that requires SettingsRepository
+//		// and then make assertions.
+//
+//	}
+type SettingsRepositoryMock struct {
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, s *model.RuntimeSettings) (bool, error)
+
+	// LatestFunc mocks the Latest method.
+	LatestFunc func(ctx context.Context) (*model.RuntimeSettings, error)
+
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context, limit int) ([]model.RuntimeSettings, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// S is the s argument value.
+			S *model.RuntimeSettings
+		}
+		// Latest holds details about calls to the Latest method.
+		Latest []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Limit is the limit argument value.
+			Limit int
+		}
+	}
+	lockCreate sync.RWMutex
+	lockLatest sync.RWMutex
+	lockList   sync.RWMutex
+}
+
+// Create calls CreateFunc.
+func (mock *SettingsRepositoryMock) Create(ctx context.Context, s *model.RuntimeSettings) (bool, error) {
+	if mock.CreateFunc == nil {
+		panic("SettingsRepositoryMock.CreateFunc: method is nil but SettingsRepository.Create was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		S   *model.RuntimeSettings
+	}{
+		Ctx: ctx,
+		S:   s,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	return mock.CreateFunc(ctx, s)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedSettingsRepository.CreateCalls())
+func (mock *SettingsRepositoryMock) CreateCalls() []struct {
+	Ctx context.Context
+	S   *model.RuntimeSettings
+} {
+	var calls []struct {
+		Ctx context.Context
+		S   *model.RuntimeSettings
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// Latest calls LatestFunc.
+func (mock *SettingsRepositoryMock) Latest(ctx context.Context) (*model.RuntimeSettings, error) {
+	if mock.LatestFunc == nil {
+		panic("SettingsRepositoryMock.LatestFunc: method is nil but SettingsRepository.Latest was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockLatest.Lock()
+	mock.calls.Latest = append(mock.calls.Latest, callInfo)
+	mock.lockLatest.Unlock()
+	return mock.LatestFunc(ctx)
+}
+
+// LatestCalls gets all the calls that were made to Latest.
+// Check the length with:
+//
+//	len(mockedSettingsRepository.LatestCalls())
+func (mock *SettingsRepositoryMock) LatestCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockLatest.RLock()
+	calls = mock.calls.Latest
+	mock.lockLatest.RUnlock()
+	return calls
+}
+
+// List calls ListFunc.
+func (mock *SettingsRepositoryMock) List(ctx context.Context, limit int) ([]model.RuntimeSettings, error) {
+	if mock.ListFunc == nil {
+		panic("SettingsRepositoryMock.ListFunc: method is nil but SettingsRepository.List was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Limit int
+	}{
+		Ctx:   ctx,
+		Limit: limit,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(ctx, limit)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedSettingsRepository.ListCalls())
+func (mock *SettingsRepositoryMock) ListCalls() []struct {
+	Ctx   context.Context
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Limit int
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
@@ -3,6 +3,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
@@ -14,6 +15,12 @@ type UserRepository interface {
 	FindByEmail(ctx context.Context, email string) (*model.User, error)
 	FindByID(ctx context.Context, id uuid.UUID) (*model.User, error)
 	Create(ctx context.Context, u *model.User) error
+	// Update writes the given columns of u; with no columns every column is written.
+	Update(ctx context.Context, u *model.User, columns ...string) error
+	ListDueForDeletion(ctx context.Context, now time.Time) ([]model.User, error)
+	// Existing returns those of ids that belong to a user.
+	Existing(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error)
+	Delete(ctx context.Context, id uuid.UUID) error
 }
 
 type userRepo struct{ db *bun.DB }
@@ -39,3 +46,42 @@ func (r *userRepo) Create(ctx context.Context, u *model.User) error {
 	_, err := r.db.NewInsert().Model(u).Exec(ctx)
 	return err
 }
+
+func (r *userRepo) Update(ctx context.Context, u *model.User, columns ...string) error {
+	q := r.db.NewUpdate().Model(u).WherePK()
+	if len(columns) > 0 {
+		q = q.Column(columns...)
+	}
+	_, err := q.Exec(ctx)
+	return err
+}
+
+func (r *userRepo) ListDueForDeletion(ctx context.Context, now time.Time) ([]model.User, error) {
+	var users []model.User
+	err := r.db.NewSelect().
+		Model(&users).
+		Where("delete_after IS NOT NULL").
+		Where("delete_after <= ?", now).
+		Scan(ctx)
+	return users, err
+}
+
+func (r *userRepo) Existing(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	var existing []uuid.UUID
+	if len(ids) == 0 {
+		return existing, nil
+	}
+	err := r.db.NewSelect().
+		Model((*model.User)(nil)).
+		Column("id").
+		Where("id IN (?)", bun.In(ids)).
+		Scan(ctx, &existing)
+	return existing, err
+}
+
+// Delete hard-deletes a user; posts and audit events go with it via
+// ON DELETE CASCADE.
+func (r *userRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.NewDelete().Model((*model.User)(nil)).Where("id = ?", id).Exec(ctx)
+	return err
+}
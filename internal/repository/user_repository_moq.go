@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/you/linkedinify/internal/model"
 	"sync"
+	"time"
 )
 
 // Ensure, that UserRepositoryMock does implement UserRepository.
@@ -23,12 +24,24 @@ var _ UserRepository = &UserRepositoryMock{}
 //			CreateFunc: func(ctx context.Context, u *model.User) error {
 //				panic("mock out the Create method")
 //			},
+//			DeleteFunc: func(ctx context.Context, id uuid.UUID) error {
+//				panic("mock out the Delete method")
+//			},
+//			ExistingFunc: func(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+//				panic("mock out the Existing method")
+//			},
 //			FindByEmailFunc: func(ctx context.Context, email string) (*model.User, error) {
 //				panic("mock out the FindByEmail method")
 //			},
 //			FindByIDFunc: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
 //				panic("mock out the FindByID method")
 //			},
+//			ListDueForDeletionFunc: func(ctx context.Context, now time.Time) ([]model.User, error) {
+//				panic("mock out the ListDueForDeletion method")
+//			},
+//			UpdateFunc: func(ctx context.Context, u *model.User, columns ...string) error {
+//				panic("mock out the Update method")
+//			},
 //		}
 //
 //		// use mockedUserRepository in code that requires UserRepository
@@ -39,12 +52,24 @@ type UserRepositoryMock struct {
 	// CreateFunc mocks the Create method.
 	CreateFunc func(ctx context.Context, u *model.User) error
 
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, id uuid.UUID) error
+
+	// ExistingFunc mocks the Existing method.
+	ExistingFunc func(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error)
+
 	// FindByEmailFunc mocks the FindByEmail method.
 	FindByEmailFunc func(ctx context.Context, email string) (*model.User, error)
 
 	// FindByIDFunc mocks the FindByID method.
 	FindByIDFunc func(ctx context.Context, id uuid.UUID) (*model.User, error)
 
+	// ListDueForDeletionFunc mocks the ListDueForDeletion method.
+	ListDueForDeletionFunc func(ctx context.Context, now time.Time) ([]model.User, error)
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, u *model.User, columns ...string) error
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// Create holds details about calls to the Create method.
@@ -54,6 +79,20 @@ type UserRepositoryMock struct {
 			// U is the u argument value.
 			U *model.User
 		}
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// Existing holds details about calls to the Existing method.
+		Existing []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Ids is the ids argument value.
+			Ids []uuid.UUID
+		}
 		// FindByEmail holds details about calls to the FindByEmail method.
 		FindByEmail []struct {
 			// Ctx is the ctx argument value.
@@ -68,10 +107,30 @@ type UserRepositoryMock struct {
 			// ID is the id argument value.
 			ID uuid.UUID
 		}
+		// ListDueForDeletion holds details about calls to the ListDueForDeletion method.
+		ListDueForDeletion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Now is the now argument value.
+			Now time.Time
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// U is the u argument value.
+			U *model.User
+			// Columns is the columns argument value.
+			Columns []string
+		}
 	}
-	lockCreate      sync.RWMutex
-	lockFindByEmail sync.RWMutex
-	lockFindByID    sync.RWMutex
+	lockCreate             sync.RWMutex
+	lockDelete             sync.RWMutex
+	lockExisting           sync.RWMutex
+	lockFindByEmail        sync.RWMutex
+	lockFindByID           sync.RWMutex
+	lockListDueForDeletion sync.RWMutex
+	lockUpdate             sync.RWMutex
 }
 
 // Create calls CreateFunc.
@@ -110,6 +169,78 @@ func (mock *UserRepositoryMock) CreateCalls() []struct {
 	return calls
 }
 
+// Delete calls DeleteFunc.
+func (mock *UserRepositoryMock) Delete(ctx context.Context, id uuid.UUID) error {
+	if mock.DeleteFunc == nil {
+		panic("UserRepositoryMock.DeleteFunc: method is nil but UserRepository.Delete was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(ctx, id)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedUserRepository.DeleteCalls())
+func (mock *UserRepositoryMock) DeleteCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// Existing calls ExistingFunc.
+func (mock *UserRepositoryMock) Existing(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	if mock.ExistingFunc == nil {
+		panic("UserRepositoryMock.ExistingFunc: method is nil but UserRepository.Existing was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Ids []uuid.UUID
+	}{
+		Ctx: ctx,
+		Ids: ids,
+	}
+	mock.lockExisting.Lock()
+	mock.calls.Existing = append(mock.calls.Existing, callInfo)
+	mock.lockExisting.Unlock()
+	return mock.ExistingFunc(ctx, ids)
+}
+
+// ExistingCalls gets all the calls that were made to Existing.
+// Check the length with:
+//
+//	len(mockedUserRepository.ExistingCalls())
+func (mock *UserRepositoryMock) ExistingCalls() []struct {
+	Ctx context.Context
+	Ids []uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Ids []uuid.UUID
+	}
+	mock.lockExisting.RLock()
+	calls = mock.calls.Existing
+	mock.lockExisting.RUnlock()
+	return calls
+}
+
 // FindByEmail calls FindByEmailFunc.
 func (mock *UserRepositoryMock) FindByEmail(ctx context.Context, email string) (*model.User, error) {
 	if mock.FindByEmailFunc == nil {
@@ -181,3 +312,79 @@ func (mock *UserRepositoryMock) FindByIDCalls() []struct {
 	mock.lockFindByID.RUnlock()
 	return calls
 }
+
+// ListDueForDeletion calls ListDueForDeletionFunc.
+func (mock *UserRepositoryMock) ListDueForDeletion(ctx context.Context, now time.Time) ([]model.User, error) {
+	if mock.ListDueForDeletionFunc == nil {
+		panic("UserRepositoryMock.ListDueForDeletionFunc: method is nil but UserRepository.ListDueForDeletion was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Now time.Time
+	}{
+		Ctx: ctx,
+		Now: now,
+	}
+	mock.lockListDueForDeletion.Lock()
+	mock.calls.ListDueForDeletion = append(mock.calls.ListDueForDeletion, callInfo)
+	mock.lockListDueForDeletion.Unlock()
+	return mock.ListDueForDeletionFunc(ctx, now)
+}
+
+// ListDueForDeletionCalls gets all the calls that were made to ListDueForDeletion.
+// Check the length with:
+//
+//	len(mockedUserRepository.ListDueForDeletionCalls())
+func (mock *UserRepositoryMock) ListDueForDeletionCalls() []struct {
+	Ctx context.Context
+	Now time.Time
+} {
+	var calls []struct {
+		Ctx context.Context
+		Now time.Time
+	}
+	mock.lockListDueForDeletion.RLock()
+	calls = mock.calls.ListDueForDeletion
+	mock.lockListDueForDeletion.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *UserRepositoryMock) Update(ctx context.Context, u *model.User, columns ...string) error {
+	if mock.UpdateFunc == nil {
+		panic("UserRepositoryMock.UpdateFunc: method is nil but UserRepository.Update was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		U       *model.User
+		Columns []string
+	}{
+		Ctx:     ctx,
+		U:       u,
+		Columns: columns,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	return mock.UpdateFunc(ctx, u, columns...)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedUserRepository.UpdateCalls())
+func (mock *UserRepositoryMock) UpdateCalls() []struct {
+	Ctx     context.Context
+	U       *model.User
+	Columns []string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		U       *model.User
+		Columns []string
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/you/linkedinify/internal/model"
+)
+
+// VoiceRepository stores voice profiles. Lookups are scoped to the owning
+// user so one user can never read another's samples.
+type VoiceRepository interface {
+	Create(ctx context.Context, v *model.VoiceProfile) error
+	FindByID(ctx context.Context, userID, id uuid.UUID) (*model.VoiceProfile, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]model.VoiceProfile, error)
+	Delete(ctx context.Context, userID, id uuid.UUID) (bool, error)
+}
+
+type voiceRepo struct{ db *bun.DB }
+
+func NewVoiceRepo(db *bun.DB) VoiceRepository { return &voiceRepo{db} }
+
+func (r *voiceRepo) Create(ctx context.Context, v *model.VoiceProfile) error {
+	_, err := r.db.NewInsert().Model(v).Exec(ctx)
+	return err
+}
+
+func (r *voiceRepo) FindByID(ctx context.Context, userID, id uuid.UUID) (*model.VoiceProfile, error) {
+	v := new(model.VoiceProfile)
+	err := r.db.NewSelect().Model(v).Where("id = ?", id).Where("user_id = ?", userID).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (r *voiceRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]model.VoiceProfile, error) {
+	var voices []model.VoiceProfile
+	err := r.db.NewSelect().
+		Model(&voices).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Scan(ctx)
+	return voices, err
+}
+
+// Delete removes a voice profile and reports whether it existed.
+func (r *voiceRepo) Delete(ctx context.Context, userID, id uuid.UUID) (bool, error) {
+	res, err := r.db.NewDelete().
+		Model((*model.VoiceProfile)(nil)).
+		Where("id = ?", id).
+		Where("user_id = ?", userID).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
@@ -0,0 +1,245 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/you/linkedinify/internal/model"
+	"sync"
+)
+
+// Ensure, that VoiceRepositoryMock does implement VoiceRepository.
+// If this is not the case, regenerate this file with moq.
+var _ VoiceRepository = &VoiceRepositoryMock{}
+
+// VoiceRepositoryMock is a mock implementation of VoiceRepository.
+//
+//	func TestSomethingThatUsesVoiceRepository(t *testing.T) {
+//
+//		// make and configure a mocked VoiceRepository
+//		mockedVoiceRepository := &VoiceRepositoryMock{
+//			CreateFunc: func(ctx context.Context, v *model.VoiceProfile) error {
+//				panic("mock out the Create method")
+//			},
+//			DeleteFunc: func(ctx context.Context, userID uuid.UUID, id uuid.UUID) (bool, error) {
+//				panic("mock out the Delete method")
+//			},
+//			FindByIDFunc: func(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*model.VoiceProfile, error) {
+//				panic("mock out the FindByID method")
+//			},
+//			ListByUserFunc: func(ctx context.Context, userID uuid.UUID) ([]model.VoiceProfile, error) {
+//				panic("mock out the ListByUser method")
+//			},
+//		}
+//
+//		// use mockedVoiceRepository in code that requires VoiceRepository
+//		// and then make assertions.
+//
+//	}
+type VoiceRepositoryMock struct {
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, v *model.VoiceProfile) error
+
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, userID uuid.UUID, id uuid.UUID) (bool, error)
+
+	// FindByIDFunc mocks the FindByID method.
+	FindByIDFunc func(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*model.VoiceProfile, error)
+
+	// ListByUserFunc mocks the ListByUser method.
+	ListByUserFunc func(ctx context.Context, userID uuid.UUID) ([]model.VoiceProfile, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// V is the v argument value.
+			V *model.VoiceProfile
+		}
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// FindByID holds details about calls to the FindByID method.
+		FindByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// ListByUser holds details about calls to the ListByUser method.
+		ListByUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+	}
+	lockCreate     sync.RWMutex
+	lockDelete     sync.RWMutex
+	lockFindByID   sync.RWMutex
+	lockListByUser sync.RWMutex
+}
+
+// Create calls CreateFunc.
+func (mock *VoiceRepositoryMock) Create(ctx context.Context, v *model.VoiceProfile) error {
+	if mock.CreateFunc == nil {
+		panic("VoiceRepositoryMock.CreateFunc: method is nil but VoiceRepository.Create was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		V   *model.VoiceProfile
+	}{
+		Ctx: ctx,
+		V:   v,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	return mock.CreateFunc(ctx, v)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedVoiceRepository.CreateCalls())
+func (mock *VoiceRepositoryMock) CreateCalls() []struct {
+	Ctx context.Context
+	V   *model.VoiceProfile
+} {
+	var calls []struct {
+		Ctx context.Context
+		V   *model.VoiceProfile
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// Delete calls DeleteFunc.
+func (mock *VoiceRepositoryMock) Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) (bool, error) {
+	if mock.DeleteFunc == nil {
+		panic("VoiceRepositoryMock.DeleteFunc: method is nil but VoiceRepository.Delete was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		ID:     id,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(ctx, userID, id)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedVoiceRepository.DeleteCalls())
+func (mock *VoiceRepositoryMock) DeleteCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	ID     uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// FindByID calls FindByIDFunc.
+func (mock *VoiceRepositoryMock) FindByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*model.VoiceProfile, error) {
+	if mock.FindByIDFunc == nil {
+		panic("VoiceRepositoryMock.FindByIDFunc: method is nil but VoiceRepository.FindByID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		ID:     id,
+	}
+	mock.lockFindByID.Lock()
+	mock.calls.FindByID = append(mock.calls.FindByID, callInfo)
+	mock.lockFindByID.Unlock()
+	return mock.FindByIDFunc(ctx, userID, id)
+}
+
+// FindByIDCalls gets all the calls that were made to FindByID.
+// Check the length with:
+//
+//	len(mockedVoiceRepository.FindByIDCalls())
+func (mock *VoiceRepositoryMock) FindByIDCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	ID     uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}
+	mock.lockFindByID.RLock()
+	calls = mock.calls.FindByID
+	mock.lockFindByID.RUnlock()
+	return calls
+}
+
+// ListByUser calls ListByUserFunc.
+func (mock *VoiceRepositoryMock) ListByUser(ctx context.Context, userID uuid.UUID) ([]model.VoiceProfile, error) {
+	if mock.ListByUserFunc == nil {
+		panic("VoiceRepositoryMock.ListByUserFunc: method is nil but VoiceRepository.ListByUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockListByUser.Lock()
+	mock.calls.ListByUser = append(mock.calls.ListByUser, callInfo)
+	mock.lockListByUser.Unlock()
+	return mock.ListByUserFunc(ctx, userID)
+}
+
+// ListByUserCalls gets all the calls that were made to ListByUser.
+// Check the length with:
+//
+//	len(mockedVoiceRepository.ListByUserCalls())
+func (mock *VoiceRepositoryMock) ListByUserCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockListByUser.RLock()
+	calls = mock.calls.ListByUser
+	mock.lockListByUser.RUnlock()
+	return calls
+}
@@ -0,0 +1,108 @@
+package router
+
+import (
+	"time"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/config"
+	"github.com/you/linkedinify/internal/imagegen"
+	"github.com/you/linkedinify/internal/metrics"
+	"github.com/you/linkedinify/internal/moderation"
+	"github.com/you/linkedinify/internal/service"
+)
+
+// defaultRouting sends every call to OpenAI when no routing file is set.
+func defaultRouting(cfg config.Config) config.Routing {
+	return config.Routing{
+		Providers: []config.Provider{{Name: "openai", Model: ai.OpenAIModel, Token: cfg.OpenAIToken}},
+		Routes:    []config.Route{{Targets: []config.Target{{Provider: "openai"}}}},
+	}
+}
+
+// resilienceConfig is how every call to an AI provider is timed out,
+// retried and circuit-broken.
+func resilienceConfig(cfg config.Config) ai.ResilienceConfig {
+	return ai.ResilienceConfig{
+		Timeout:          cfg.AITimeout,
+		MaxRetries:       cfg.AIMaxRetries,
+		BaseBackoff:      500 * time.Millisecond,
+		MaxBackoff:       10 * time.Second,
+		BreakerThreshold: cfg.AIBreakerThreshold,
+		BreakerCooldown:  cfg.AIBreakerCooldown,
+	}
+}
+
+// newAIClient builds the routing AI client. Each provider gets its own
+// metrics, retries and circuit breaker, so one provider failing fast lets
+// routing fall back to the next. With HealthProbeAI every provider is also
+// returned as a non-critical readiness check.
+func newAIClient(cfg config.Config, m *metrics.Metrics) (ai.Client, []service.HealthCheck) {
+	routing := defaultRouting(cfg)
+	if cfg.AIRouting != nil {
+		routing = *cfg.AIRouting
+	}
+	resilience := resilienceConfig(cfg)
+
+	clients := map[string]ai.Client{}
+	var checks []service.HealthCheck
+	for _, p := range routing.Providers {
+		opts := []ai.OpenAIOption{ai.WithProviderName(p.Name), ai.WithModel(p.Model), ai.WithTokenUsage(m)}
+		if p.BaseURL != "" {
+			opts = append(opts, ai.WithBaseURL(p.BaseURL))
+		}
+		c := ai.NewOpenAI(p.Token, opts...)
+		if pinger, ok := c.(ai.Pinger); ok && cfg.HealthProbeAI {
+			checks = append(checks, service.HealthCheck{Name: p.Name, Probe: pinger.Ping})
+		}
+		clients[p.Name] = ai.WithTracing(ai.WithResilience(ai.WithMetrics(c, p.Name, p.Model, m), resilience), p.Name, p.Model)
+	}
+
+	models := map[string]string{}
+	for _, p := range routing.Providers {
+		models[p.Name] = p.Model
+	}
+	routes := make([]ai.Route, 0, len(routing.Routes))
+	for _, rt := range routing.Routes {
+		route := ai.Route{Styles: rt.Styles, Tiers: rt.Tiers}
+		for _, t := range rt.Targets {
+			route.Backends = append(route.Backends, ai.Backend{
+				Provider: t.Provider,
+				Model:    models[t.Provider],
+				Client:   clients[t.Provider],
+				Weight:   t.Weight,
+			})
+		}
+		routes = append(routes, route)
+	}
+	return ai.NewRouting(routes), checks
+}
+
+// newModerator builds the moderation chain: the local rules, followed by
+// the provider's moderation model when enabled. The model is reached
+// through c, the routing client, so it shares the providers' retries,
+// circuit breakers, metrics and tracing.
+func newModerator(cfg config.Config, c ai.Client) moderation.Moderator {
+	mods := []moderation.Moderator{moderation.NewRules(moderation.Rules{
+		Blocklist:       cfg.ModerationBlocklist,
+		ProfanityAction: moderation.Action(cfg.ModerationProfanity),
+		PIIAction:       moderation.Action(cfg.ModerationPII),
+		InjectionAction: moderation.Action(cfg.ModerationInjection),
+	})}
+	if cfg.ModerationLLM {
+		if cl, ok := c.(ai.Classifier); ok {
+			mods = append(mods, moderation.NewLLM(cl, moderation.Block))
+		}
+	}
+	return moderation.Chain(mods...)
+}
+
+// newImageGenerator picks the generator for post images. A remote
+// generator gets the same metrics, retries, circuit breaker and tracing as
+// the AI providers, with a breaker of its own.
+func newImageGenerator(cfg config.Config, m *metrics.Metrics) imagegen.ImageGenerator {
+	if cfg.ImageGenerator == "openai" {
+		g := imagegen.NewOpenAI(cfg.OpenAIToken, "")
+		return imagegen.WithTracing(imagegen.WithResilience(imagegen.WithMetrics(g, imagegen.OpenAIModel, m), resilienceConfig(cfg)), imagegen.OpenAIModel)
+	}
+	return imagegen.NewPlaceholder()
+}
@@ -0,0 +1,255 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Treblle/treblle-go/v2"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/you/linkedinify/internal/config"
+)
+
+// Observer ships a copy of API traffic to an observability backend.
+type Observer interface {
+	Middleware(next http.Handler) http.Handler
+}
+
+// BodyRedaction hides the named JSON fields, at any depth, in request and
+// response bodies of paths starting with PathPrefix. Bodies on such paths
+// that are not JSON are dropped entirely.
+type BodyRedaction struct {
+	PathPrefix string
+	Fields     []string
+}
+
+// DefaultRedactions keep credentials and the tokens issued for them out of
+// observability backends.
+var DefaultRedactions = []BodyRedaction{
+	{PathPrefix: "/api/v1/auth/", Fields: []string{"email", "password", "token"}},
+}
+
+const redactedValue = "[REDACTED]"
+
+// newObserver builds the observer selected by cfg.Observer.
+func newObserver(cfg config.Config, logger *slog.Logger) (Observer, error) {
+	switch cfg.Observer {
+	case config.ObserverTreblle:
+		logger.Info("API observability via Treblle enabled")
+		return NewTreblleObserver(cfg.TreblleToken, cfg.TreblleAPIKey, cfg.TreblleDebug, DefaultRedactions), nil
+	case config.ObserverJSONL:
+		f, err := os.OpenFile(cfg.ObserverLogPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("opening request log: %w", err)
+		}
+		// The file stays open for the lifetime of the process; every entry
+		// is written with a single call so nothing is lost on exit.
+		logger.Info("API observability via JSONL request log enabled", "path", cfg.ObserverLogPath)
+		return NewJSONLObserver(f, DefaultRedactions), nil
+	case config.ObserverNone:
+		logger.Info("API observability disabled")
+		return NoopObserver{}, nil
+	default:
+		return nil, fmt.Errorf("unknown observer %q", cfg.Observer)
+	}
+}
+
+// NoopObserver discards all traffic.
+type NoopObserver struct{}
+
+func (NoopObserver) Middleware(next http.Handler) http.Handler { return next }
+
+// treblleObserver sends traffic to Treblle. Treblle masks fields by name on
+// every path, so the redacted fields are masked everywhere rather than only
+// below their path prefixes.
+type treblleObserver struct{}
+
+// NewTreblleObserver configures the Treblle SDK, which is global state, and
+// returns an observer using it.
+func NewTreblleObserver(token, apiKey string, debug bool, redactions []BodyRedaction) Observer {
+	var fields []string
+	for _, rule := range redactions {
+		fields = append(fields, rule.Fields...)
+	}
+	treblle.Configure(treblle.Configuration{
+		SDK_TOKEN:              token,
+		API_KEY:                apiKey,
+		AdditionalFieldsToMask: fields,
+		Debug:                  debug,
+	})
+	return treblleObserver{}
+}
+
+func (treblleObserver) Middleware(next http.Handler) http.Handler { return treblle.Middleware(next) }
+
+// maxCapturedBody bounds how much of each body the JSONL observer records.
+const maxCapturedBody = 64 << 10
+
+// jsonlObserver appends one JSON line per request to w.
+type jsonlObserver struct {
+	mu         sync.Mutex
+	w          io.Writer
+	redactions []BodyRedaction
+}
+
+// NewJSONLObserver returns an observer writing one JSON object per request
+// to w, with bodies redacted according to redactions.
+func NewJSONLObserver(w io.Writer, redactions []BodyRedaction) Observer {
+	return &jsonlObserver{w: w, redactions: redactions}
+}
+
+type requestLogEntry struct {
+	Time         time.Time       `json:"time"`
+	TraceID      string          `json:"trace_id,omitempty"`
+	Method       string          `json:"method"`
+	Path         string          `json:"path"`
+	Route        string          `json:"route,omitempty"`
+	Status       int             `json:"status"`
+	DurationMS   int64           `json:"duration_ms"`
+	RequestBody  json.RawMessage `json:"request_body,omitempty"`
+	ResponseBody json.RawMessage `json:"response_body,omitempty"`
+	Truncated    bool            `json:"truncated,omitempty"`
+}
+
+func (o *jsonlObserver) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		var reqBody []byte
+		reqTruncated := false
+		if r.Body != nil && r.Body != http.NoBody {
+			// Read a bounded prefix and hand the handler the full body.
+			prefix, _ := io.ReadAll(io.LimitReader(r.Body, maxCapturedBody+1))
+			r.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(prefix), r.Body), r.Body}
+			reqBody, reqTruncated = capped(prefix)
+		}
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		resp := &cappedBuffer{}
+		ww.Tee(resp)
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		traceID, _ := r.Context().Value(traceIDKey).(string)
+		entry := requestLogEntry{
+			Time:       start.UTC(),
+			TraceID:    traceID,
+			Method:     r.Method,
+			Path:       r.URL.Path,
+			Status:     status,
+			DurationMS: time.Since(start).Milliseconds(),
+			Truncated:  reqTruncated || resp.truncated,
+		}
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			entry.Route = rctx.RoutePattern()
+		}
+		fields := o.fieldsFor(r.URL.Path)
+		entry.RequestBody = redactBody(reqBody, fields)
+		entry.ResponseBody = redactBody(resp.buf.Bytes(), fields)
+
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return
+		}
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		_, _ = o.w.Write(append(line, '\n'))
+	})
+}
+
+// fieldsFor returns the fields to redact on path, or nil when no rule
+// applies.
+func (o *jsonlObserver) fieldsFor(path string) map[string]bool {
+	var fields map[string]bool
+	for _, rule := range o.redactions {
+		if !strings.HasPrefix(path, rule.PathPrefix) {
+			continue
+		}
+		if fields == nil {
+			fields = map[string]bool{}
+		}
+		for _, f := range rule.Fields {
+			fields[strings.ToLower(f)] = true
+		}
+	}
+	return fields
+}
+
+// redactBody returns body as a JSON value for the log entry: JSON bodies
+// with the given fields replaced, other text as a JSON string. Binary bodies
+// and non-JSON bodies on redacted paths are omitted.
+func redactBody(body []byte, fields map[string]bool) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err == nil {
+		if fields != nil {
+			v = redactValue(v, fields)
+		}
+		out, _ := json.Marshal(v)
+		return out
+	}
+	if fields != nil || !utf8.Valid(body) {
+		return nil
+	}
+	out, _ := json.Marshal(string(body))
+	return out
+}
+
+func redactValue(v interface{}, fields map[string]bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			if fields[strings.ToLower(k)] {
+				v[k] = redactedValue
+			} else {
+				v[k] = redactValue(child, fields)
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = redactValue(child, fields)
+		}
+	}
+	return v
+}
+
+func capped(b []byte) ([]byte, bool) {
+	if len(b) > maxCapturedBody {
+		return b[:maxCapturedBody], true
+	}
+	return b, false
+}
+
+// cappedBuffer keeps the first maxCapturedBody bytes written to it.
+type cappedBuffer struct {
+	buf       bytes.Buffer
+	truncated bool
+}
+
+func (c *cappedBuffer) Write(p []byte) (int, error) {
+	if room := maxCapturedBody - c.buf.Len(); room < len(p) {
+		c.truncated = true
+		c.buf.Write(p[:max(room, 0)])
+	} else {
+		c.buf.Write(p)
+	}
+	return len(p), nil
+}
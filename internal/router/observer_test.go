@@ -0,0 +1,70 @@
+package router_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/router"
+)
+
+func serveLogged(t *testing.T, method, path, body string, h http.HandlerFunc) map[string]interface{} {
+	t.Helper()
+	var out bytes.Buffer
+	r := chi.NewRouter()
+	r.Use(router.NewJSONLObserver(&out, router.DefaultRedactions).Middleware)
+	r.Method(method, "/api/v1/{area}/{action}", h)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(method, path, strings.NewReader(body)))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 1)
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	return entry
+}
+
+func TestJSONLObserver_RedactsAuthBodies(t *testing.T) {
+	var seen string
+	entry := serveLogged(t, http.MethodPost, "/api/v1/auth/login",
+		`{"email":"jane@example.com","password":"hunter2"}`,
+		func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			seen = string(b)
+			_, _ = w.Write([]byte(`{"token":"eyJhbGciOi.x.y"}`))
+		})
+
+	assert.Contains(t, seen, "hunter2", "the handler must receive the original body")
+	assert.Equal(t, map[string]interface{}{"email": "[REDACTED]", "password": "[REDACTED]"}, entry["request_body"])
+	assert.Equal(t, map[string]interface{}{"token": "[REDACTED]"}, entry["response_body"])
+	assert.Equal(t, "/api/v1/{area}/{action}", entry["route"])
+	assert.Equal(t, float64(http.StatusOK), entry["status"])
+}
+
+func TestJSONLObserver_KeepsOtherBodies(t *testing.T) {
+	entry := serveLogged(t, http.MethodPost, "/api/v1/posts/import", "plain text",
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"imported":2}`))
+		})
+
+	assert.Equal(t, "plain text", entry["request_body"])
+	assert.Equal(t, map[string]interface{}{"imported": float64(2)}, entry["response_body"])
+	assert.Equal(t, float64(http.StatusCreated), entry["status"])
+}
+
+func TestJSONLObserver_DropsNonJSONAuthBodies(t *testing.T) {
+	entry := serveLogged(t, http.MethodPost, "/api/v1/auth/register", "password=hunter2",
+		func(w http.ResponseWriter, r *http.Request) {})
+
+	assert.NotContains(t, entry, "request_body")
+}
@@ -3,64 +3,146 @@ package router
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"net/http"
+	"time"
 
-	"github.com/Treblle/treblle-go/v2"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/google/uuid"
-	"github.com/you/linkedinify/internal/ai"
 	"github.com/you/linkedinify/internal/config"
 	"github.com/you/linkedinify/internal/db"
+	"github.com/you/linkedinify/internal/flags"
 	"github.com/you/linkedinify/internal/handler"
+	"github.com/you/linkedinify/internal/logging"
+	"github.com/you/linkedinify/internal/metrics"
+	mw "github.com/you/linkedinify/internal/middleware"
+	"github.com/you/linkedinify/internal/normalize"
 	"github.com/you/linkedinify/internal/repository"
 	"github.com/you/linkedinify/internal/service"
+	"github.com/you/linkedinify/internal/telemetry"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// treblleSetupMiddleware configures Treblle and returns a middleware that includes tracing.
-func treblleSetupMiddleware(cfg config.Config) func(http.Handler) http.Handler {
-	// Configure Treblle if credentials are provided
-	if cfg.TreblleToken != "" && cfg.TreblleAPIKey != "" {
-		treblle.Configure(treblle.Configuration{
-			SDK_TOKEN: cfg.TreblleToken,
-			API_KEY:   cfg.TreblleAPIKey,
-			Debug:     true,
-		})
-		log.Println("✓ Treblle monitoring enabled")
-
-		// Return a middleware that combines trace and Treblle middleware
-		return func(next http.Handler) http.Handler {
-			return traceMiddleware(treblle.Middleware(next))
-		}
-	}
-
-	// If Treblle is not configured, log it and only use the trace middleware
-	log.Println("⚠ Treblle monitoring disabled - missing credentials")
-	return traceMiddleware
-}
-
-func New(cfg config.Config) *chi.Mux {
+// New wires repositories, services and handlers into the HTTP router.
+// logger is the base logger that request-scoped loggers derive from.
+// Background jobs run until ctx is cancelled.
+func New(ctx context.Context, cfg config.Config, logger *slog.Logger) *chi.Mux {
 	database := db.New(cfg)
+	m := metrics.New()
+	m.RegisterDB(database.DB, "postgres")
+
 	userRepo := repository.NewUserRepo(database)
 	postRepo := repository.NewPostRepo(database)
-
-	authSvc := service.NewAuth(userRepo, cfg)
-	aiClient := ai.NewOpenAI(cfg.OpenAIToken)
-	liSvc := service.NewLinkedIn(aiClient, postRepo)
+	auditRepo := repository.NewAuditRepo(database)
+	voiceRepo := repository.NewVoiceRepo(database)
+	settingsRepo := repository.NewSettingsRepo(database)
+	flagRepo := repository.NewFlagRepo(database)
+	imageRepo := repository.NewImageRepo(database)
+
+	// Runtime settings and feature flags are reloaded periodically so that
+	// changes made through any instance reach all of them.
+	settingsSvc := service.NewSettings(settingsRepo)
+	settingsCtx := logging.WithContext(ctx, logger.With("component", "settings_watcher"))
+	if err := settingsSvc.Refresh(settingsCtx); err != nil {
+		logger.Error("failed to load runtime settings, using defaults", "err", err)
+	}
+	go service.RunRefresher(settingsCtx, settingsSvc, cfg.SettingsPollInterval)
+	flagSvc := service.NewFlags(flagRepo)
+	flagsCtx := logging.WithContext(ctx, logger.With("component", "flags_watcher"))
+	if err := flagSvc.Refresh(flagsCtx); err != nil {
+		logger.Error("failed to load feature flags, all flags are off", "err", err)
+	}
+	go service.RunRefresher(flagsCtx, flagSvc, cfg.SettingsPollInterval)
+
+	authSvc := service.NewAuth(userRepo, cfg, service.WithLoginMetrics(m))
+	aiClient, aiChecks := newAIClient(cfg, m)
+	suggestSvc := service.NewSuggest(postRepo, userRepo)
+	liSvc := service.NewLinkedIn(aiClient, postRepo,
+		service.WithProfiles(userRepo),
+		service.WithVoices(voiceRepo),
+		service.WithCacheMetrics(m),
+		service.WithRuntimeSettings(settingsSvc),
+		service.WithPostRules(service.PostRules{
+			MaxLength:   cfg.PostMaxLength,
+			HookLength:  cfg.PostHookLength,
+			MaxHashtags: cfg.PostMaxHashtags,
+			Reprompts:   cfg.PostReprompts,
+		}),
+		service.WithModerator(newModerator(cfg, aiClient)),
+		service.WithSuggestions(suggestSvc),
+	)
+	healthChecks := []service.HealthCheck{
+		{Name: "postgres", Critical: true, Probe: database.PingContext},
+		{Name: "schema", Critical: true, Probe: func(ctx context.Context) error { return db.CheckSchema(ctx, database) }},
+	}
+	healthChecks = append(healthChecks, aiChecks...)
+	healthSvc := service.NewHealth(2*time.Second, healthChecks...)
+	voiceSvc := service.NewVoice(aiClient, voiceRepo, service.WithVoiceSettings(settingsSvc))
+	blobs, err := newBlobStore(cfg)
+	if err != nil {
+		logger.Error("blob storage unavailable, image and download endpoints disabled", "store", cfg.BlobStore, "err", err)
+	}
+	blobURLs := newURLSigner(cfg)
+	accountOpts := []service.AccountOption{service.WithArchiveRecords(voiceRepo, imageRepo)}
+	normalizer := normalize.New(cfg.InputMaxLength)
+	liOpts := []handler.LinkedInOption{handler.WithNormalizer(normalizer)}
+	if blobs != nil {
+		accountOpts = append(accountOpts, service.WithBlobs(blobs))
+		liOpts = append(liOpts,
+			handler.WithImages(service.NewImages(newImageGenerator(cfg, m), blobs, postRepo, imageRepo)),
+			handler.WithBlobURLs(blobURLs),
+		)
+	}
+	accountSvc := service.NewAccount(userRepo, postRepo, auditRepo, liSvc, cfg.DeletionGracePeriod, accountOpts...)
+	purgerCtx := logging.WithContext(ctx, logger.With("component", "account_purger"))
+	go service.RunPurger(purgerCtx, accountSvc, time.Hour)
 
 	authH := handler.NewAuth(authSvc)
-	liH := handler.NewLinkedIn(liSvc)
+	liH := handler.NewLinkedIn(liSvc, liOpts...)
+	accountH := handler.NewAccount(accountSvc)
+	voiceH := handler.NewVoice(voiceSvc)
+	suggestH := handler.NewSuggest(suggestSvc, normalizer)
+	healthH := handler.NewHealth(healthSvc)
+	settingsH := handler.NewSettings(settingsSvc)
+	flagH := handler.NewFlag(flagSvc)
+	transformLimit := mw.RateLimit(func() int { return settingsSvc.Current().Values.TransformsPerMinute })
+
+	observer, err := newObserver(cfg, logger)
+	if err != nil {
+		logger.Error("API observability disabled", "err", err)
+		observer = NoopObserver{}
+	}
 
 	r := chi.NewRouter()
-	r.Use(middleware.Logger)
+	r.Use(telemetry.Middleware)
+	r.Use(m.Middleware)
+	r.Use(traceMiddleware)
+	r.Use(requestLogger(logger))
 	r.Use(middleware.Compress(5, "gzip"))
-	r.Use(treblleSetupMiddleware(cfg))
+	r.Use(observer.Middleware)
+	r.Use(flags.Middleware(flagSvc, func(ctx context.Context) flags.Subject {
+		return flags.Subject{UserID: mw.UserID(ctx), Organization: mw.Organization(ctx)}
+	}))
+
+	r.Handle("/metrics", m.Handler())
+	r.Get("/healthz", healthH.Live)
+	r.Get("/readyz", healthH.Ready)
 
 	// Create API v1 router
 	v1Router := chi.NewRouter()
 	v1Router.Mount("/auth", authH.Routes())
-	v1Router.Mount("/posts", liH.Routes(cfg.JWTSecret))
+	v1Router.Mount("/posts", liH.Routes(cfg.JWTSecret, transformLimit))
+	v1Router.Mount("/me", accountH.Routes(cfg.JWTSecret))
+	v1Router.Mount("/voices", voiceH.Routes(cfg.JWTSecret))
+	v1Router.Mount("/suggest", suggestH.Routes(cfg.JWTSecret))
+	if blobs != nil {
+		v1Router.Mount("/blobs", handler.NewBlob(blobs, blobURLs).Routes())
+	}
+	if cfg.AdminToken != "" {
+		v1Router.Mount("/admin/settings", settingsH.Routes(cfg.AdminToken))
+		v1Router.Mount("/admin/flags", flagH.Routes(cfg.AdminToken))
+	}
 
 	// Mount v1 router under /api/v1
 	r.Mount("/api/v1", v1Router)
@@ -73,14 +155,20 @@ type contextKey string
 
 const traceIDKey = contextKey("traceID")
 
-// traceMiddleware generates a unique trace ID and adds it to the request context and response headers.
+// traceMiddleware determines the request's trace ID and adds it to the request context and response headers.
+// It runs after telemetry.Middleware so that, unless the client sent its own treblle-tag-id, the ID is the
+// OpenTelemetry trace ID and log lines, Treblle and exported spans can be joined on it.
 func traceMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Check if a trace ID is already present in the request header.
 		traceID := r.Header.Get("treblle-tag-id")
 		if traceID == "" {
-			// If not, generate a new one.
-			traceID = uuid.New().String()
+			// If not, use the span's trace ID, or generate a new one.
+			if sc := trace.SpanContextFromContext(r.Context()); sc.HasTraceID() {
+				traceID = sc.TraceID().String()
+			} else {
+				traceID = uuid.New().String()
+			}
 			// Add it to the request header so subsequent middlewares can see it.
 			r.Header.Set("treblle-tag-id", traceID)
 		}
@@ -92,3 +180,41 @@ func traceMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// requestLogger stores a request-scoped logger carrying the trace ID in the
+// context and logs one line per completed request. It must run after
+// traceMiddleware.
+func requestLogger(base *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			traceID, _ := r.Context().Value(traceIDKey).(string)
+			logger := base.With("trace_id", traceID)
+			ctx := logging.WithContext(r.Context(), logger)
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			level := slog.LevelInfo
+			if status >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+			// The handler's context may have gained attributes (such as
+			// user_id from the auth middleware) that are not visible here,
+			// so the route pattern is what ties this line to the handler.
+			logger.Log(r.Context(), level, "request completed",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"route", chi.RouteContext(r.Context()).RoutePattern(),
+				"status", status,
+				"bytes", ww.BytesWritten(),
+				"duration_ms", time.Since(start).Milliseconds(),
+				"remote_addr", r.RemoteAddr,
+			)
+		})
+	}
+}
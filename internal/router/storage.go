@@ -0,0 +1,44 @@
+package router
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+
+	"github.com/you/linkedinify/internal/config"
+	"github.com/you/linkedinify/internal/storage"
+)
+
+// blobURLBase is where BlobHandler is mounted; signed links point there.
+const blobURLBase = "/api/v1/blobs"
+
+// newBlobStore builds the configured blob store, wrapped to enforce the
+// per-user storage quota.
+func newBlobStore(cfg config.Config) (*storage.Quota, error) {
+	var (
+		store storage.BlobStore
+		err   error
+	)
+	if cfg.BlobStore == "s3" {
+		store, err = storage.NewS3(storage.S3Config{
+			Endpoint:        cfg.S3Endpoint,
+			Bucket:          cfg.S3Bucket,
+			Region:          cfg.S3Region,
+			AccessKeyID:     cfg.S3AccessKeyID,
+			SecretAccessKey: cfg.S3SecretAccessKey,
+		})
+	} else {
+		store, err = storage.NewLocal(cfg.BlobDir)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return storage.NewQuota(store, cfg.StorageQuotaBytes), nil
+}
+
+// newURLSigner signs download links with a key derived from the JWT
+// secret, so that a link can never pass for a token or the other way round.
+func newURLSigner(cfg config.Config) *storage.URLSigner {
+	mac := hmac.New(sha256.New, cfg.JWTSecret)
+	mac.Write([]byte("blob-urls"))
+	return storage.NewURLSigner(mac.Sum(nil), blobURLBase, cfg.BlobURLTTL)
+}
@@ -0,0 +1,471 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/logging"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/storage"
+)
+
+// ErrNoPendingDeletion is returned when cancelling a deletion that was never requested.
+var ErrNoPendingDeletion = errors.New("no account deletion is pending")
+
+// TransformCache is the part of the LinkedIn service the purger needs to
+// scrub cached transforms of deleted users.
+type TransformCache interface {
+	// CachedUsers lists the users with cached transforms.
+	CachedUsers() []uuid.UUID
+	// Forget drops the cached transforms of the given users.
+	Forget(userIDs ...uuid.UUID)
+}
+
+// AccountServiceInteractor defines the operations on a user's own account.
+type AccountServiceInteractor interface {
+	Profile(ctx context.Context, userID uuid.UUID) (*model.User, error)
+	UpdateProfile(ctx context.Context, userID uuid.UUID, upd ProfileUpdate) (*model.User, error)
+	RequestDeletion(ctx context.Context, userID uuid.UUID) (*model.User, error)
+	CancelDeletion(ctx context.Context, userID uuid.UUID) error
+	ExportArchive(ctx context.Context, userID uuid.UUID, w io.Writer) error
+	// StorageUsage reports how much of their storage quota the user's
+	// images and other files take.
+	StorageUsage(ctx context.Context, userID uuid.UUID) (*StorageUsage, error)
+	PurgeExpired(ctx context.Context) (int, error)
+}
+
+// StorageUsage is the size of a user's stored files against their quota,
+// in bytes. A Quota of 0 means no limit.
+type StorageUsage struct {
+	Used  int64
+	Quota int64
+}
+
+type AccountService struct {
+	users repository.UserRepository
+	posts repository.PostRepository
+	audit repository.AuditRepository
+	cache TransformCache
+	grace time.Duration
+	blobs *storage.Quota
+	// optional, see WithArchiveRecords
+	voices repository.VoiceRepository
+	images repository.ImageRepository
+}
+
+// AccountOption configures optional dependencies of the account service.
+type AccountOption func(*AccountService)
+
+// WithBlobs reports usage of the user's blobs in blobs and deletes them
+// with the account.
+func WithBlobs(blobs *storage.Quota) AccountOption {
+	return func(a *AccountService) { a.blobs = blobs }
+}
+
+// WithArchiveRecords adds the user's voice profiles and images to the
+// archive of ExportArchive. The images' files come from the store of
+// WithBlobs.
+func WithArchiveRecords(voices repository.VoiceRepository, images repository.ImageRepository) AccountOption {
+	return func(a *AccountService) { a.voices, a.images = voices, images }
+}
+
+// NewAccount creates a new AccountService. Deleted accounts stay recoverable
+// for the grace period before PurgeExpired removes them for good.
+func NewAccount(users repository.UserRepository, posts repository.PostRepository, audit repository.AuditRepository, cache TransformCache, grace time.Duration, opts ...AccountOption) AccountServiceInteractor {
+	a := &AccountService{users: users, posts: posts, audit: audit, cache: cache, grace: grace}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+func (a *AccountService) Profile(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	return a.users.FindByID(ctx, userID)
+}
+
+// UpdateProfile applies a partial profile update. Validation failures wrap
+// ErrInvalidProfile.
+func (a *AccountService) UpdateProfile(ctx context.Context, userID uuid.UUID, upd ProfileUpdate) (*model.User, error) {
+	u, err := a.users.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := upd.apply(u); err != nil {
+		return nil, err
+	}
+	if u.DefaultHashtags == nil {
+		u.DefaultHashtags = []string{}
+	}
+	if err := a.users.Update(ctx, u, profileColumns...); err != nil {
+		return nil, err
+	}
+	a.record(ctx, userID, model.AuditProfileUpdated, "")
+	return u, nil
+}
+
+var profileColumns = []string{
+	"display_name", "job_title", "industry", "preferred_language",
+	"default_style", "default_hashtags", "signature", "max_length",
+}
+
+// RequestDeletion schedules the account for deletion after the grace period.
+// Requesting again while a deletion is pending keeps the original schedule.
+func (a *AccountService) RequestDeletion(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	u, err := a.users.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u.PendingDeletion() {
+		return u, nil
+	}
+
+	now := time.Now()
+	u.DeletionRequestedAt = now
+	u.DeleteAfter = now.Add(a.grace)
+	if err := a.users.Update(ctx, u, "deletion_requested_at", "delete_after"); err != nil {
+		return nil, err
+	}
+	a.record(ctx, userID, model.AuditDeletionRequested, "scheduled for "+u.DeleteAfter.UTC().Format(time.RFC3339))
+	return u, nil
+}
+
+func (a *AccountService) CancelDeletion(ctx context.Context, userID uuid.UUID) error {
+	u, err := a.users.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !u.PendingDeletion() {
+		return ErrNoPendingDeletion
+	}
+
+	u.DeletionRequestedAt = time.Time{}
+	u.DeleteAfter = time.Time{}
+	if err := a.users.Update(ctx, u, "deletion_requested_at", "delete_after"); err != nil {
+		return err
+	}
+	a.record(ctx, userID, model.AuditDeletionCancelled, "")
+	return nil
+}
+
+// PurgeExpired hard-deletes every account whose grace period has run out,
+// with their stored files, and evicts cached transforms of accounts that no
+// longer exist. It returns the number of accounts removed; a failure on one
+// account does not stop the others. Files go first, so an account whose
+// files could not all be deleted is kept and tried again next time.
+//
+// Every instance runs the purger, so each one's cache is scrubbed within an
+// interval of an account being purged by any of them.
+func (a *AccountService) PurgeExpired(ctx context.Context) (int, error) {
+	due, err := a.users.ListDueForDeletion(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	var errs []error
+	for _, u := range due {
+		var err error
+		if a.blobs != nil {
+			_, err = storage.DeletePrefix(ctx, a.blobs, u.ID.String()+"/")
+		}
+		if err == nil {
+			err = a.users.Delete(ctx, u.ID)
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		logging.FromContext(ctx).Info("purged account", "user_id", u.ID)
+		purged++
+	}
+	if err := a.scrubCache(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("scrubbing transform cache: %w", err))
+	}
+	return purged, errors.Join(errs...)
+}
+
+// scrubCache evicts the cached transforms of users who no longer exist.
+func (a *AccountService) scrubCache(ctx context.Context) error {
+	cached := a.cache.CachedUsers()
+	if len(cached) == 0 {
+		return nil
+	}
+	existing, err := a.users.Existing(ctx, cached)
+	if err != nil {
+		return err
+	}
+	keep := make(map[uuid.UUID]bool, len(existing))
+	for _, id := range existing {
+		keep[id] = true
+	}
+	var gone []uuid.UUID
+	for _, id := range cached {
+		if !keep[id] {
+			gone = append(gone, id)
+		}
+	}
+	if len(gone) > 0 {
+		a.cache.Forget(gone...)
+	}
+	return nil
+}
+
+func (a *AccountService) StorageUsage(ctx context.Context, userID uuid.UUID) (*StorageUsage, error) {
+	if a.blobs == nil {
+		return &StorageUsage{}, nil
+	}
+	used, err := a.blobs.Usage(ctx, userID.String())
+	if err != nil {
+		return nil, err
+	}
+	return &StorageUsage{Used: used, Quota: a.blobs.Limit()}, nil
+}
+
+// RunPurger calls PurgeExpired every interval until ctx is cancelled. It
+// logs through the logger carried by ctx.
+func RunPurger(ctx context.Context, svc AccountServiceInteractor, interval time.Duration) {
+	logger := logging.FromContext(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := svc.PurgeExpired(ctx)
+			if err != nil {
+				logger.Error("account purge incomplete", "purged", n, "err", err)
+			} else if n > 0 {
+				logger.Info("purged deleted accounts", "purged", n)
+			}
+		}
+	}
+}
+
+// ExportArchive writes a zip archive with everything stored about the user:
+// profile.json, posts.jsonl (re-importable via the posts import endpoint),
+// voices.json, images.json, usage.json, audit.json and, under files/, every
+// file the user has stored, images included.
+func (a *AccountService) ExportArchive(ctx context.Context, userID uuid.UUID, w io.Writer) error {
+	u, err := a.users.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	a.record(ctx, userID, model.AuditDataExported, "")
+	events, err := a.audit.ListByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	zw := zip.NewWriter(w)
+	if err := writeZipJSON(zw, "profile.json", newArchiveProfile(u)); err != nil {
+		return err
+	}
+
+	pf, err := zw.Create("posts.jsonl")
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(pf)
+	usage := archiveUsage{ByMonth: map[string]int{}}
+	err = a.posts.ForEachByUser(ctx, userID, func(p *model.LinkedInPost) error {
+		usage.TotalPosts++
+		usage.ByMonth[p.CreatedAt.UTC().Format("2006-01")]++
+		return enc.Encode(model.NewPostRecord(p))
+	})
+	if err != nil {
+		return err
+	}
+	if err := a.archiveVoices(ctx, zw, userID); err != nil {
+		return err
+	}
+	if err := a.archiveImages(ctx, zw, userID); err != nil {
+		return err
+	}
+	if err := a.archiveFiles(ctx, zw, userID); err != nil {
+		return err
+	}
+
+	if err := writeZipJSON(zw, "usage.json", usage); err != nil {
+		return err
+	}
+	audit := make([]archiveAuditEvent, 0, len(events))
+	for _, e := range events {
+		audit = append(audit, archiveAuditEvent{Action: e.Action, Detail: e.Detail, CreatedAt: e.CreatedAt})
+	}
+	if err := writeZipJSON(zw, "audit.json", audit); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+func (a *AccountService) archiveVoices(ctx context.Context, zw *zip.Writer, userID uuid.UUID) error {
+	voices := []archiveVoice{}
+	if a.voices != nil {
+		found, err := a.voices.ListByUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+		for _, v := range found {
+			voices = append(voices, archiveVoice{ID: v.ID, Name: v.Name, Examples: v.Examples, Guidance: v.Guidance, CreatedAt: v.CreatedAt})
+		}
+	}
+	return writeZipJSON(zw, "voices.json", voices)
+}
+
+func (a *AccountService) archiveImages(ctx context.Context, zw *zip.Writer, userID uuid.UUID) error {
+	images := []archiveImage{}
+	if a.images != nil {
+		found, err := a.images.ListByUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+		for _, img := range found {
+			images = append(images, archiveImage{
+				ID:          img.ID,
+				PostID:      img.PostID,
+				File:        archiveFileName(userID, img.BlobKey),
+				ContentType: img.ContentType,
+				Size:        img.Size,
+				Source:      img.Source,
+				CreatedAt:   img.CreatedAt,
+			})
+		}
+	}
+	return writeZipJSON(zw, "images.json", images)
+}
+
+// archiveFiles copies the user's stored files into files/.
+func (a *AccountService) archiveFiles(ctx context.Context, zw *zip.Writer, userID uuid.UUID) error {
+	if a.blobs == nil {
+		return nil
+	}
+	blobs, err := a.blobs.List(ctx, userID.String()+"/")
+	if err != nil {
+		return err
+	}
+	for _, b := range blobs {
+		if err := copyBlob(ctx, zw, a.blobs, b.Key, archiveFileName(userID, b.Key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyBlob(ctx context.Context, zw *zip.Writer, store storage.BlobStore, key, name string) error {
+	rc, _, err := store.Open(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		// Deleted since it was listed.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, rc)
+	return err
+}
+
+// archiveFileName is where the blob with the given key goes in the archive.
+func archiveFileName(userID uuid.UUID, key string) string {
+	return "files/" + strings.TrimPrefix(key, userID.String()+"/")
+}
+
+// record writes an audit event. Auditing is best effort and never fails the
+// operation being audited.
+func (a *AccountService) record(ctx context.Context, userID uuid.UUID, action, detail string) {
+	err := a.audit.Record(ctx, &model.AuditEvent{ID: uuid.New(), UserID: userID, Action: action, Detail: detail})
+	if err != nil {
+		logging.FromContext(ctx).Error("failed to record audit event", "action", action, "user_id", userID, "err", err)
+	}
+}
+
+func writeZipJSON(zw *zip.Writer, name string, v interface{}) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+type archiveProfile struct {
+	ID                  uuid.UUID  `json:"id"`
+	Email               string     `json:"email"`
+	DisplayName         string     `json:"display_name"`
+	JobTitle            string     `json:"job_title"`
+	Industry            string     `json:"industry"`
+	PreferredLanguage   string     `json:"preferred_language"`
+	DefaultStyle        string     `json:"default_style"`
+	DefaultHashtags     []string   `json:"default_hashtags"`
+	Signature           string     `json:"signature"`
+	MaxLength           int        `json:"max_length"`
+	CreatedAt           time.Time  `json:"created_at"`
+	DeletionRequestedAt *time.Time `json:"deletion_requested_at,omitempty"`
+	DeleteAfter         *time.Time `json:"delete_after,omitempty"`
+}
+
+func newArchiveProfile(u *model.User) archiveProfile {
+	p := archiveProfile{
+		ID:                u.ID,
+		Email:             u.Email,
+		DisplayName:       u.DisplayName,
+		JobTitle:          u.JobTitle,
+		Industry:          u.Industry,
+		PreferredLanguage: u.PreferredLanguage,
+		DefaultStyle:      u.DefaultStyle,
+		DefaultHashtags:   u.DefaultHashtags,
+		Signature:         u.Signature,
+		MaxLength:         u.MaxLength,
+		CreatedAt:         u.CreatedAt,
+	}
+	if u.PendingDeletion() {
+		p.DeletionRequestedAt = &u.DeletionRequestedAt
+		p.DeleteAfter = &u.DeleteAfter
+	}
+	return p
+}
+
+type archiveVoice struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Examples  []string  `json:"examples"`
+	Guidance  string    `json:"guidance"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// archiveImage describes an image; File is its path in the archive.
+type archiveImage struct {
+	ID          uuid.UUID `json:"id"`
+	PostID      uuid.UUID `json:"post_id"`
+	File        string    `json:"file"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Source      string    `json:"source"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type archiveUsage struct {
+	TotalPosts int            `json:"total_posts"`
+	ByMonth    map[string]int `json:"by_month"`
+}
+
+type archiveAuditEvent struct {
+	Action    string    `json:"action"`
+	Detail    string    `json:"detail,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
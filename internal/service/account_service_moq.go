@@ -0,0 +1,390 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/you/linkedinify/internal/model"
+	"io"
+	"sync"
+)
+
+// Ensure, that AccountServiceInteractorMock does implement AccountServiceInteractor.
+// If this is not the case, regenerate this file with moq.
+var _ AccountServiceInteractor = &AccountServiceInteractorMock{}
+
+// AccountServiceInteractorMock is a mock implementation of AccountServiceInteractor.
+//
+//	func TestSomethingThatUsesAccountServiceInteractor(t *testing.T) {
+//
+//		// make and configure a mocked AccountServiceInteractor
+//		mockedAccountServiceInteractor := &AccountServiceInteractorMock{
+//			CancelDeletionFunc: func(ctx context.Context, userID uuid.UUID) error {
+//				panic("mock out the CancelDeletion method")
+//			},
+//			ExportArchiveFunc: func(ctx context.Context, userID uuid.UUID, w io.Writer) error {
+//				panic("mock out the ExportArchive method")
+//			},
+//			ProfileFunc: func(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+//				panic("mock out the Profile method")
+//			},
+//			PurgeExpiredFunc: func(ctx context.Context) (int, error) {
+//				panic("mock out the PurgeExpired method")
+//			},
+//			RequestDeletionFunc: func(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+//				panic("mock out the RequestDeletion method")
+//			},
+//			StorageUsageFunc: func(ctx context.Context, userID uuid.UUID) (*StorageUsage, error) {
+//				panic("mock out the StorageUsage method")
+//			},
+//			UpdateProfileFunc: func(ctx context.Context, userID uuid.UUID, upd ProfileUpdate) (*model.User, error) {
+//				panic("mock out the UpdateProfile method")
+//			},
+//		}
+//
+//		// use mockedAccountServiceInteractor in code that requires AccountServiceInteractor
+//		// and then make assertions.
+//
+//	}
+type AccountServiceInteractorMock struct {
+	// CancelDeletionFunc mocks the CancelDeletion method.
+	CancelDeletionFunc func(ctx context.Context, userID uuid.UUID) error
+
+	// ExportArchiveFunc mocks the ExportArchive method.
+	ExportArchiveFunc func(ctx context.Context, userID uuid.UUID, w io.Writer) error
+
+	// ProfileFunc mocks the Profile method.
+	ProfileFunc func(ctx context.Context, userID uuid.UUID) (*model.User, error)
+
+	// PurgeExpiredFunc mocks the PurgeExpired method.
+	PurgeExpiredFunc func(ctx context.Context) (int, error)
+
+	// RequestDeletionFunc mocks the RequestDeletion method.
+	RequestDeletionFunc func(ctx context.Context, userID uuid.UUID) (*model.User, error)
+
+	// StorageUsageFunc mocks the StorageUsage method.
+	StorageUsageFunc func(ctx context.Context, userID uuid.UUID) (*StorageUsage, error)
+
+	// UpdateProfileFunc mocks the UpdateProfile method.
+	UpdateProfileFunc func(ctx context.Context, userID uuid.UUID, upd ProfileUpdate) (*model.User, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CancelDeletion holds details about calls to the CancelDeletion method.
+		CancelDeletion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// ExportArchive holds details about calls to the ExportArchive method.
+		ExportArchive []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// W is the w argument value.
+			W io.Writer
+		}
+		// Profile holds details about calls to the Profile method.
+		Profile []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// PurgeExpired holds details about calls to the PurgeExpired method.
+		PurgeExpired []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// RequestDeletion holds details about calls to the RequestDeletion method.
+		RequestDeletion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// StorageUsage holds details about calls to the StorageUsage method.
+		StorageUsage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// UpdateProfile holds details about calls to the UpdateProfile method.
+		UpdateProfile []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Upd is the upd argument value.
+			Upd ProfileUpdate
+		}
+	}
+	lockCancelDeletion  sync.RWMutex
+	lockExportArchive   sync.RWMutex
+	lockProfile         sync.RWMutex
+	lockPurgeExpired    sync.RWMutex
+	lockRequestDeletion sync.RWMutex
+	lockStorageUsage    sync.RWMutex
+	lockUpdateProfile   sync.RWMutex
+}
+
+// CancelDeletion calls CancelDeletionFunc.
+func (mock *AccountServiceInteractorMock) CancelDeletion(ctx context.Context, userID uuid.UUID) error {
+	if mock.CancelDeletionFunc == nil {
+		panic("AccountServiceInteractorMock.CancelDeletionFunc: method is nil but AccountServiceInteractor.CancelDeletion was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockCancelDeletion.Lock()
+	mock.calls.CancelDeletion = append(mock.calls.CancelDeletion, callInfo)
+	mock.lockCancelDeletion.Unlock()
+	return mock.CancelDeletionFunc(ctx, userID)
+}
+
+// CancelDeletionCalls gets all the calls that were made to CancelDeletion.
+// Check the length with:
+//
+//	len(mockedAccountServiceInteractor.CancelDeletionCalls())
+func (mock *AccountServiceInteractorMock) CancelDeletionCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockCancelDeletion.RLock()
+	calls = mock.calls.CancelDeletion
+	mock.lockCancelDeletion.RUnlock()
+	return calls
+}
+
+// ExportArchive calls ExportArchiveFunc.
+func (mock *AccountServiceInteractorMock) ExportArchive(ctx context.Context, userID uuid.UUID, w io.Writer) error {
+	if mock.ExportArchiveFunc == nil {
+		panic("AccountServiceInteractorMock.ExportArchiveFunc: method is nil but AccountServiceInteractor.ExportArchive was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		W      io.Writer
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		W:      w,
+	}
+	mock.lockExportArchive.Lock()
+	mock.calls.ExportArchive = append(mock.calls.ExportArchive, callInfo)
+	mock.lockExportArchive.Unlock()
+	return mock.ExportArchiveFunc(ctx, userID, w)
+}
+
+// ExportArchiveCalls gets all the calls that were made to ExportArchive.
+// Check the length with:
+//
+//	len(mockedAccountServiceInteractor.ExportArchiveCalls())
+func (mock *AccountServiceInteractorMock) ExportArchiveCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	W      io.Writer
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		W      io.Writer
+	}
+	mock.lockExportArchive.RLock()
+	calls = mock.calls.ExportArchive
+	mock.lockExportArchive.RUnlock()
+	return calls
+}
+
+// Profile calls ProfileFunc.
+func (mock *AccountServiceInteractorMock) Profile(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	if mock.ProfileFunc == nil {
+		panic("AccountServiceInteractorMock.ProfileFunc: method is nil but AccountServiceInteractor.Profile was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockProfile.Lock()
+	mock.calls.Profile = append(mock.calls.Profile, callInfo)
+	mock.lockProfile.Unlock()
+	return mock.ProfileFunc(ctx, userID)
+}
+
+// ProfileCalls gets all the calls that were made to Profile.
+// Check the length with:
+//
+//	len(mockedAccountServiceInteractor.ProfileCalls())
+func (mock *AccountServiceInteractorMock) ProfileCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockProfile.RLock()
+	calls = mock.calls.Profile
+	mock.lockProfile.RUnlock()
+	return calls
+}
+
+// PurgeExpired calls PurgeExpiredFunc.
+func (mock *AccountServiceInteractorMock) PurgeExpired(ctx context.Context) (int, error) {
+	if mock.PurgeExpiredFunc == nil {
+		panic("AccountServiceInteractorMock.PurgeExpiredFunc: method is nil but AccountServiceInteractor.PurgeExpired was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPurgeExpired.Lock()
+	mock.calls.PurgeExpired = append(mock.calls.PurgeExpired, callInfo)
+	mock.lockPurgeExpired.Unlock()
+	return mock.PurgeExpiredFunc(ctx)
+}
+
+// PurgeExpiredCalls gets all the calls that were made to PurgeExpired.
+// Check the length with:
+//
+//	len(mockedAccountServiceInteractor.PurgeExpiredCalls())
+func (mock *AccountServiceInteractorMock) PurgeExpiredCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockPurgeExpired.RLock()
+	calls = mock.calls.PurgeExpired
+	mock.lockPurgeExpired.RUnlock()
+	return calls
+}
+
+// RequestDeletion calls RequestDeletionFunc.
+func (mock *AccountServiceInteractorMock) RequestDeletion(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	if mock.RequestDeletionFunc == nil {
+		panic("AccountServiceInteractorMock.RequestDeletionFunc: method is nil but AccountServiceInteractor.RequestDeletion was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockRequestDeletion.Lock()
+	mock.calls.RequestDeletion = append(mock.calls.RequestDeletion, callInfo)
+	mock.lockRequestDeletion.Unlock()
+	return mock.RequestDeletionFunc(ctx, userID)
+}
+
+// RequestDeletionCalls gets all the calls that were made to RequestDeletion.
+// Check the length with:
+//
+//	len(mockedAccountServiceInteractor.RequestDeletionCalls())
+func (mock *AccountServiceInteractorMock) RequestDeletionCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockRequestDeletion.RLock()
+	calls = mock.calls.RequestDeletion
+	mock.lockRequestDeletion.RUnlock()
+	return calls
+}
+
+// StorageUsage calls StorageUsageFunc.
+func (mock *AccountServiceInteractorMock) StorageUsage(ctx context.Context, userID uuid.UUID) (*StorageUsage, error) {
+	if mock.StorageUsageFunc == nil {
+		panic("AccountServiceInteractorMock.StorageUsageFunc: method is nil but AccountServiceInteractor.StorageUsage was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockStorageUsage.Lock()
+	mock.calls.StorageUsage = append(mock.calls.StorageUsage, callInfo)
+	mock.lockStorageUsage.Unlock()
+	return mock.StorageUsageFunc(ctx, userID)
+}
+
+// StorageUsageCalls gets all the calls that were made to StorageUsage.
+// Check the length with:
+//
+//	len(mockedAccountServiceInteractor.StorageUsageCalls())
+func (mock *AccountServiceInteractorMock) StorageUsageCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockStorageUsage.RLock()
+	calls = mock.calls.StorageUsage
+	mock.lockStorageUsage.RUnlock()
+	return calls
+}
+
+// UpdateProfile calls UpdateProfileFunc.
+func (mock *AccountServiceInteractorMock) UpdateProfile(ctx context.Context, userID uuid.UUID, upd ProfileUpdate) (*model.User, error) {
+	if mock.UpdateProfileFunc == nil {
+		panic("AccountServiceInteractorMock.UpdateProfileFunc: method is nil but AccountServiceInteractor.UpdateProfile was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Upd    ProfileUpdate
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Upd:    upd,
+	}
+	mock.lockUpdateProfile.Lock()
+	mock.calls.UpdateProfile = append(mock.calls.UpdateProfile, callInfo)
+	mock.lockUpdateProfile.Unlock()
+	return mock.UpdateProfileFunc(ctx, userID, upd)
+}
+
+// UpdateProfileCalls gets all the calls that were made to UpdateProfile.
+// Check the length with:
+//
+//	len(mockedAccountServiceInteractor.UpdateProfileCalls())
+func (mock *AccountServiceInteractorMock) UpdateProfileCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Upd    ProfileUpdate
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Upd    ProfileUpdate
+	}
+	mock.lockUpdateProfile.RLock()
+	calls = mock.calls.UpdateProfile
+	mock.lockUpdateProfile.RUnlock()
+	return calls
+}
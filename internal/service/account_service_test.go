@@ -0,0 +1,289 @@
+package service_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/service"
+	"github.com/you/linkedinify/internal/storage"
+)
+
+func newNoopAuditRepo() *repository.AuditRepositoryMock {
+	return &repository.AuditRepositoryMock{
+		RecordFunc: func(ctx context.Context, e *model.AuditEvent) error { return nil },
+		ListByUserFunc: func(ctx context.Context, userID uuid.UUID) ([]model.AuditEvent, error) {
+			return nil, nil
+		},
+	}
+}
+
+func TestAccountService_RequestDeletion_SchedulesAfterGracePeriod(t *testing.T) {
+	userID := uuid.New()
+	mockUserRepo := &repository.UserRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+			return &model.User{ID: id}, nil
+		},
+		UpdateFunc: func(ctx context.Context, u *model.User, columns ...string) error {
+			assert.ElementsMatch(t, []string{"deletion_requested_at", "delete_after"}, columns)
+			return nil
+		},
+	}
+	auditRepo := newNoopAuditRepo()
+	accountSvc := service.NewAccount(mockUserRepo, &repository.PostRepositoryMock{}, auditRepo, &service.TransformCacheMock{}, 48*time.Hour)
+
+	u, err := accountSvc.RequestDeletion(context.Background(), userID)
+	require.NoError(t, err)
+	assert.True(t, u.PendingDeletion())
+	assert.Equal(t, 48*time.Hour, u.DeleteAfter.Sub(u.DeletionRequestedAt))
+	require.Len(t, auditRepo.RecordCalls(), 1)
+	assert.Equal(t, model.AuditDeletionRequested, auditRepo.RecordCalls()[0].E.Action)
+
+	// A repeated request keeps the original schedule.
+	scheduled := u.DeleteAfter
+	mockUserRepo.FindByIDFunc = func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+		return u, nil
+	}
+	again, err := accountSvc.RequestDeletion(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, scheduled, again.DeleteAfter)
+	assert.Len(t, mockUserRepo.UpdateCalls(), 1)
+}
+
+func TestAccountService_CancelDeletion_NothingPending(t *testing.T) {
+	mockUserRepo := &repository.UserRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+			return &model.User{ID: id}, nil
+		},
+	}
+	accountSvc := service.NewAccount(mockUserRepo, &repository.PostRepositoryMock{}, newNoopAuditRepo(), &service.TransformCacheMock{}, time.Hour)
+
+	err := accountSvc.CancelDeletion(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, service.ErrNoPendingDeletion)
+	assert.Len(t, mockUserRepo.UpdateCalls(), 0)
+}
+
+func TestAccountService_PurgeExpired_DeletesUsersAndScrubsCache(t *testing.T) {
+	purgedID := uuid.New()
+	failingID := uuid.New()
+	deleteErr := errors.New("delete failed")
+
+	mockUserRepo := &repository.UserRepositoryMock{
+		ListDueForDeletionFunc: func(ctx context.Context, now time.Time) ([]model.User, error) {
+			return []model.User{{ID: purgedID}, {ID: failingID}}, nil
+		},
+		DeleteFunc: func(ctx context.Context, id uuid.UUID) error {
+			if id == failingID {
+				return deleteErr
+			}
+			return nil
+		},
+	}
+	activeID, purgedElsewhereID := uuid.New(), uuid.New()
+	mockUserRepo.ExistingFunc = func(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+		assert.ElementsMatch(t, []uuid.UUID{purgedID, activeID, purgedElsewhereID, failingID}, ids)
+		return []uuid.UUID{activeID, failingID}, nil
+	}
+	mockCache := &service.TransformCacheMock{
+		CachedUsersFunc: func() []uuid.UUID { return []uuid.UUID{purgedID, activeID, purgedElsewhereID, failingID} },
+		ForgetFunc:      func(userIDs ...uuid.UUID) {},
+	}
+	accountSvc := service.NewAccount(mockUserRepo, &repository.PostRepositoryMock{}, newNoopAuditRepo(), mockCache, time.Hour)
+
+	n, err := accountSvc.PurgeExpired(context.Background())
+	assert.ErrorIs(t, err, deleteErr)
+	assert.Equal(t, 1, n)
+	require.Len(t, mockCache.ForgetCalls(), 1)
+	assert.ElementsMatch(t, []uuid.UUID{purgedID, purgedElsewhereID}, mockCache.ForgetCalls()[0].UserIDs,
+		"accounts purged by other instances are scrubbed too")
+}
+
+func TestAccountService_PurgeExpired_DeletesBlobs(t *testing.T) {
+	ctx := context.Background()
+	purgedID, otherID := uuid.New(), uuid.New()
+	local, err := storage.NewLocal(t.TempDir())
+	require.NoError(t, err)
+	blobs := storage.NewQuota(local, 0)
+	for _, key := range []string{purgedID.String() + "/images/a.svg", purgedID.String() + "/images/b.png", otherID.String() + "/images/c.svg"} {
+		_, err := blobs.Put(ctx, key, strings.NewReader("x"), "")
+		require.NoError(t, err)
+	}
+	mockUserRepo := &repository.UserRepositoryMock{
+		ListDueForDeletionFunc: func(ctx context.Context, now time.Time) ([]model.User, error) {
+			return []model.User{{ID: purgedID}}, nil
+		},
+		DeleteFunc: func(ctx context.Context, id uuid.UUID) error { return nil },
+	}
+	cache := &service.TransformCacheMock{CachedUsersFunc: func() []uuid.UUID { return nil }}
+	accountSvc := service.NewAccount(mockUserRepo, &repository.PostRepositoryMock{}, newNoopAuditRepo(), cache, time.Hour, service.WithBlobs(blobs))
+
+	n, err := accountSvc.PurgeExpired(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	left, err := blobs.List(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, left, 1)
+	assert.Equal(t, otherID.String()+"/images/c.svg", left[0].Key)
+}
+
+func TestAccountService_StorageUsage(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	local, err := storage.NewLocal(t.TempDir())
+	require.NoError(t, err)
+	_, err = local.Put(ctx, userID.String()+"/images/a.svg", strings.NewReader("12345"), "")
+	require.NoError(t, err)
+	accountSvc := service.NewAccount(&repository.UserRepositoryMock{}, &repository.PostRepositoryMock{}, newNoopAuditRepo(), &service.TransformCacheMock{}, time.Hour, service.WithBlobs(storage.NewQuota(local, 1000)))
+
+	usage, err := accountSvc.StorageUsage(ctx, userID)
+
+	require.NoError(t, err)
+	assert.Equal(t, &service.StorageUsage{Used: 5, Quota: 1000}, usage)
+}
+
+func TestAccountService_ExportArchive(t *testing.T) {
+	userID := uuid.New()
+	createdAt := time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC)
+	mockUserRepo := &repository.UserRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+			return &model.User{ID: id, Email: "me@example.com", PasswordHash: "secret-hash"}, nil
+		},
+	}
+	postID, imageID := uuid.New(), uuid.New()
+	mockPostRepo := &repository.PostRepositoryMock{
+		ForEachByUserFunc: func(ctx context.Context, id uuid.UUID, fn func(*model.LinkedInPost) error) error {
+			for i := 0; i < 2; i++ {
+				post := &model.LinkedInPost{
+					ID: uuid.New(), InputText: "in", OutputText: "out", CreatedAt: createdAt,
+					Language: "en", Provider: "openai", Model: "gpt-4o", Quality: &model.PostQuality{Score: 64},
+					ModerationFlags: []model.ModerationFlag{{Stage: "input", Category: "pii"}},
+				}
+				if err := fn(post); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	voices := &repository.VoiceRepositoryMock{
+		ListByUserFunc: func(ctx context.Context, id uuid.UUID) ([]model.VoiceProfile, error) {
+			return []model.VoiceProfile{{ID: uuid.New(), UserID: id, Name: "Founder", Examples: []string{"sample post"}, Guidance: "short"}}, nil
+		},
+	}
+	key := userID.String() + "/images/" + imageID.String() + ".svg"
+	images := &repository.ImageRepositoryMock{
+		ListByUserFunc: func(ctx context.Context, id uuid.UUID) ([]model.PostImage, error) {
+			return []model.PostImage{{ID: imageID, PostID: postID, UserID: id, BlobKey: key, ContentType: "image/svg+xml", Size: 6, Source: "placeholder"}}, nil
+		},
+	}
+	local, err := storage.NewLocal(t.TempDir())
+	require.NoError(t, err)
+	blobs := storage.NewQuota(local, 0)
+	_, err = blobs.Put(context.Background(), key, strings.NewReader("<svg/>"), "")
+	require.NoError(t, err)
+	_, err = blobs.Put(context.Background(), uuid.NewString()+"/images/other.svg", strings.NewReader("<svg/>"), "")
+	require.NoError(t, err)
+	accountSvc := service.NewAccount(mockUserRepo, mockPostRepo, newNoopAuditRepo(), &service.TransformCacheMock{}, time.Hour,
+		service.WithBlobs(blobs), service.WithArchiveRecords(voices, images))
+
+	var buf bytes.Buffer
+	require.NoError(t, accountSvc.ExportArchive(context.Background(), userID, &buf))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		b, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(b)
+	}
+	require.Contains(t, files, "profile.json")
+	assert.Contains(t, files["profile.json"], "me@example.com")
+	assert.NotContains(t, files["profile.json"], "secret-hash")
+	assert.Equal(t, 2, bytes.Count([]byte(files["posts.jsonl"]), []byte("\n")))
+	for _, want := range []string{`"language":"en"`, `"provider":"openai"`, `"model":"gpt-4o"`, `"moderation_flags":[{"stage":"input","category":"pii"}]`, `"score":64`} {
+		assert.Contains(t, files["posts.jsonl"], want)
+	}
+	assert.Contains(t, files["voices.json"], "sample post")
+
+	var archived []struct {
+		ID   uuid.UUID `json:"id"`
+		File string    `json:"file"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(files["images.json"]), &archived))
+	require.Len(t, archived, 1)
+	assert.Equal(t, imageID, archived[0].ID)
+	assert.Equal(t, "<svg/>", files[archived[0].File])
+	assert.Len(t, files, 7, "other users' files are left out")
+
+	var usage struct {
+		TotalPosts int            `json:"total_posts"`
+		ByMonth    map[string]int `json:"by_month"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(files["usage.json"]), &usage))
+	assert.Equal(t, 2, usage.TotalPosts)
+	assert.Equal(t, map[string]int{"2024-03": 2}, usage.ByMonth)
+	assert.Contains(t, files, "audit.json")
+}
+
+func TestAccountService_UpdateProfile(t *testing.T) {
+	userID := uuid.New()
+	mockUserRepo := &repository.UserRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+			return &model.User{ID: id, DisplayName: "Old name", JobTitle: "Engineer"}, nil
+		},
+		UpdateFunc: func(ctx context.Context, u *model.User, columns ...string) error { return nil },
+	}
+	accountSvc := service.NewAccount(mockUserRepo, &repository.PostRepositoryMock{}, newNoopAuditRepo(), &service.TransformCacheMock{}, time.Hour)
+
+	name := "  Ada Lovelace "
+	lang := "pt-BR"
+	tags := []string{"#AI", "ai", " engineering "}
+	u, err := accountSvc.UpdateProfile(context.Background(), userID, service.ProfileUpdate{
+		DisplayName:       &name,
+		PreferredLanguage: &lang,
+		DefaultHashtags:   &tags,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Ada Lovelace", u.DisplayName)
+	assert.Equal(t, "Engineer", u.JobTitle, "fields not in the update are kept")
+	assert.Equal(t, "pt-BR", u.PreferredLanguage)
+	assert.Equal(t, []string{"AI", "engineering"}, u.DefaultHashtags)
+	assert.Len(t, mockUserRepo.UpdateCalls(), 1)
+}
+
+func TestAccountService_UpdateProfile_ReportsAllProblems(t *testing.T) {
+	mockUserRepo := &repository.UserRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+			return &model.User{ID: id}, nil
+		},
+	}
+	accountSvc := service.NewAccount(mockUserRepo, &repository.PostRepositoryMock{}, newNoopAuditRepo(), &service.TransformCacheMock{}, time.Hour)
+
+	lang := "not a language"
+	maxLength := 5000
+	_, err := accountSvc.UpdateProfile(context.Background(), uuid.New(), service.ProfileUpdate{
+		PreferredLanguage: &lang,
+		MaxLength:         &maxLength,
+	})
+	require.ErrorIs(t, err, service.ErrInvalidProfile)
+	assert.Contains(t, err.Error(), "preferred_language")
+	assert.Contains(t, err.Error(), "max_length")
+	assert.Len(t, mockUserRepo.UpdateCalls(), 0)
+}
@@ -3,6 +3,7 @@ package service
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -24,15 +25,34 @@ type AuthServiceInteractor interface {
 	Login(ctx context.Context, email, password string) (string, error)
 }
 
+// LoginMetrics counts login outcomes.
+type LoginMetrics interface {
+	LoginSucceeded()
+	LoginFailed()
+}
+
 type AuthService struct {
-	repo repository.UserRepository
-	cfg  AuthConfigProvider // Uses the interface
+	repo   repository.UserRepository
+	cfg    AuthConfigProvider // Uses the interface
+	logins LoginMetrics       // optional, see WithLoginMetrics
+}
+
+// AuthOption configures optional collaborators of an AuthService.
+type AuthOption func(*AuthService)
+
+// WithLoginMetrics reports every login attempt's outcome to m.
+func WithLoginMetrics(m LoginMetrics) AuthOption {
+	return func(a *AuthService) { a.logins = m }
 }
 
 // NewAuth creates a new AuthService instance.
 // It now accepts AuthConfigProvider and returns AuthServiceInteractor.
-func NewAuth(repo repository.UserRepository, cfg AuthConfigProvider) AuthServiceInteractor {
-	return &AuthService{repo: repo, cfg: cfg}
+func NewAuth(repo repository.UserRepository, cfg AuthConfigProvider, opts ...AuthOption) AuthServiceInteractor {
+	a := &AuthService{repo: repo, cfg: cfg}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
 }
 
 func (a *AuthService) Register(ctx context.Context, email, password string) (string, error) {
@@ -45,14 +65,26 @@ func (a *AuthService) Register(ctx context.Context, email, password string) (str
 		Email:        email,
 		PasswordHash: string(hash),
 		APIToken:     uuid.NewString(),
+		// Written as '{}' rather than NULL, which the column rejects.
+		DefaultHashtags: []string{},
 	}
 	if err := a.repo.Create(ctx, user); err != nil {
 		return "", err
 	}
-	return a.generateJWT(user.ID)
+	return a.generateJWT(user)
 }
 
-func (a *AuthService) Login(ctx context.Context, email, password string) (string, error) {
+func (a *AuthService) Login(ctx context.Context, email, password string) (token string, err error) {
+	if a.logins != nil {
+		defer func() {
+			if err != nil {
+				a.logins.LoginFailed()
+			} else {
+				a.logins.LoginSucceeded()
+			}
+		}()
+	}
+
 	u, err := a.repo.FindByEmail(ctx, email)
 	if err != nil {
 		return "", err
@@ -61,14 +93,27 @@ func (a *AuthService) Login(ctx context.Context, email, password string) (string
 	if err != nil {
 		return "", jwt.ErrTokenInvalidAudience
 	}
-	return a.generateJWT(u.ID)
+	return a.generateJWT(u)
 }
 
-func (a *AuthService) generateJWT(userID uuid.UUID) (string, error) {
+func (a *AuthService) generateJWT(u *model.User) (string, error) {
 	claims := jwt.MapClaims{
-		"sub": userID.String(),
+		"sub": u.ID.String(),
+		"org": organization(u.Email),
 		"exp": time.Now().Add(24 * time.Hour).Unix(),
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(a.cfg.GetJWTSecret())
 }
+
+// organization identifies the user's organization by their email domain,
+// which is what feature flags target. Email addresses are not verified, so
+// the organization is a rollout hint anyone can claim, never an access
+// boundary.
+func organization(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+	return strings.ToLower(email[at+1:])
+}
@@ -44,6 +44,15 @@ func parseTestJWT(t *testing.T, tokenString string, secret []byte) (string, int6
 	return sub, int64(expFloat)
 }
 
+func parseTestOrg(t *testing.T, tokenString string) string {
+	t.Helper()
+	claims := jwt.MapClaims{}
+	_, _, err := jwt.NewParser().ParseUnverified(tokenString, claims)
+	require.NoError(t, err)
+	org, _ := claims["org"].(string)
+	return org
+}
+
 func TestAuthService_Register_Success(t *testing.T) {
 	mockUserRepo := &repository.UserRepositoryMock{
 		CreateFunc: func(ctx context.Context, u *model.User) error {
@@ -128,6 +137,7 @@ func TestAuthService_Login_Success(t *testing.T) {
 	// Verify JWT
 	userIDStr, exp := parseTestJWT(t, tokenString, []byte(testJWTSecret))
 	assert.Equal(t, testUserID.String(), userIDStr, "UserID in JWT does not match")
+	assert.Equal(t, "example.com", parseTestOrg(t, tokenString), "org claim is the email domain")
 	assert.True(t, exp > time.Now().Unix(), "Token should not be expired")
 
 	assert.Len(t, mockUserRepo.FindByEmailCalls(), 1)
@@ -191,3 +201,30 @@ func TestAuthService_Login_RepositoryError(t *testing.T) {
 	assert.Len(t, mockUserRepo.FindByEmailCalls(), 1)
 	assert.Len(t, mockConfigProvider.GetJWTSecretCalls(), 0)
 }
+
+type loginCounter struct{ ok, failed int }
+
+func (c *loginCounter) LoginSucceeded() { c.ok++ }
+func (c *loginCounter) LoginFailed()    { c.failed++ }
+
+func TestAuthService_Login_ReportsOutcome(t *testing.T) {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	mockUserRepo := &repository.UserRepositoryMock{
+		FindByEmailFunc: func(ctx context.Context, email string) (*model.User, error) {
+			return &model.User{ID: uuid.New(), Email: email, PasswordHash: string(hashedPassword)}, nil
+		},
+	}
+	mockConfigProvider := &service.AuthConfigProviderMock{
+		GetJWTSecretFunc: func() []byte { return []byte(testJWTSecret) },
+	}
+	counter := &loginCounter{}
+	authSvc := service.NewAuth(mockUserRepo, mockConfigProvider, service.WithLoginMetrics(counter))
+
+	_, err := authSvc.Login(context.Background(), "test@example.com", "password123")
+	require.NoError(t, err)
+	_, err = authSvc.Login(context.Background(), "test@example.com", "wrong")
+	require.Error(t, err)
+
+	assert.Equal(t, 1, counter.ok)
+	assert.Equal(t, 1, counter.failed)
+}
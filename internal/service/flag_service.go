@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/you/linkedinify/internal/flags"
+	"github.com/you/linkedinify/internal/logging"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+)
+
+var (
+	ErrFlagNotFound = errors.New("feature flag not found")
+	// ErrInvalidFlag wraps validation failures of a feature flag.
+	ErrInvalidFlag = errors.New("invalid feature flag")
+)
+
+var flagKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,63}$`)
+
+// FlagServiceInteractor manages feature flags and serves the snapshot that
+// requests evaluate.
+type FlagServiceInteractor interface {
+	flags.Source
+	List(ctx context.Context) ([]model.FeatureFlag, error)
+	Get(ctx context.Context, key string) (*model.FeatureFlag, error)
+	// Put creates or replaces a flag. Boolean flags are created by leaving
+	// Variants empty.
+	Put(ctx context.Context, f *model.FeatureFlag) (*model.FeatureFlag, error)
+	Delete(ctx context.Context, key string) error
+	// Refresh reloads every flag from the store.
+	Refresh(ctx context.Context) error
+}
+
+type FlagService struct {
+	repo     repository.FlagRepository
+	snapshot atomic.Pointer[flags.Set]
+}
+
+// NewFlags creates a FlagService with no flags until Refresh loads them.
+func NewFlags(repo repository.FlagRepository) FlagServiceInteractor {
+	s := &FlagService{repo: repo}
+	s.snapshot.Store(&flags.Set{})
+	return s
+}
+
+func (s *FlagService) Flags() flags.Set {
+	return *s.snapshot.Load()
+}
+
+func (s *FlagService) List(ctx context.Context) ([]model.FeatureFlag, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *FlagService) Get(ctx context.Context, key string) (*model.FeatureFlag, error) {
+	f, err := s.repo.FindByKey(ctx, key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrFlagNotFound
+	}
+	return f, err
+}
+
+func (s *FlagService) Put(ctx context.Context, f *model.FeatureFlag) (*model.FeatureFlag, error) {
+	if err := normalizeFlag(f); err != nil {
+		return nil, err
+	}
+	f.UpdatedAt = time.Now()
+	if err := s.repo.Upsert(ctx, f); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("feature flag saved", "flag", f.Key, "enabled", f.Enabled)
+	return f, s.Refresh(ctx)
+}
+
+func (s *FlagService) Delete(ctx context.Context, key string) error {
+	found, err := s.repo.Delete(ctx, key)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrFlagNotFound
+	}
+	logging.FromContext(ctx).Info("feature flag deleted", "flag", key)
+	return s.Refresh(ctx)
+}
+
+func (s *FlagService) Refresh(ctx context.Context) error {
+	all, err := s.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+	set := make(flags.Set, len(all))
+	for _, f := range all {
+		set[f.Key] = f
+	}
+	s.snapshot.Store(&set)
+	return nil
+}
+
+// normalizeFlag fills in boolean flag defaults and checks that every
+// variant referenced is declared. All problems are reported together.
+func normalizeFlag(f *model.FeatureFlag) error {
+	if len(f.Variants) == 0 {
+		f.Variants = []string{flags.Off, flags.On}
+	}
+	if f.DefaultVariant == "" {
+		f.DefaultVariant = f.Variants[0]
+	}
+	if f.Rules == nil {
+		f.Rules = []model.FlagRule{}
+	}
+
+	var problems []string
+	if !flagKeyPattern.MatchString(f.Key) {
+		problems = append(problems, "key must be 1-64 lowercase letters, digits, '.', '_' or '-'")
+	}
+	seen := map[string]bool{}
+	for _, v := range f.Variants {
+		if v == "" || seen[v] {
+			problems = append(problems, fmt.Sprintf("variant %q is empty or duplicated", v))
+		}
+		seen[v] = true
+	}
+	if !slices.Contains(f.Variants, f.DefaultVariant) {
+		problems = append(problems, fmt.Sprintf("default_variant %q is not one of the variants", f.DefaultVariant))
+	}
+	for i, r := range f.Rules {
+		if !slices.Contains(f.Variants, r.Variant) {
+			problems = append(problems, fmt.Sprintf("rule %d: variant %q is not one of the variants", i+1, r.Variant))
+		}
+		if r.Percentage < 0 || r.Percentage > 100 {
+			problems = append(problems, fmt.Sprintf("rule %d: percentage must be between 0 and 100", i+1))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidFlag, strings.Join(problems, "; "))
+	}
+	return nil
+}
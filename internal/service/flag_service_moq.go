@@ -0,0 +1,308 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package service
+
+import (
+	"context"
+	"github.com/you/linkedinify/internal/flags"
+	"github.com/you/linkedinify/internal/model"
+	"sync"
+)
+
+// Ensure, that FlagServiceInteractorMock does implement FlagServiceInteractor.
+// If this is not the case, regenerate this file with moq.
+var _ FlagServiceInteractor = &FlagServiceInteractorMock{}
+
+// FlagServiceInteractorMock is a mock implementation of FlagServiceInteractor.
+//
+//	func TestSomethingThatUsesFlagServiceInteractor(t *testing.T) {
+//
+//		// make and configure a mocked FlagServiceInteractor
+//		mockedFlagServiceInteractor := &FlagServiceInteractorMock{
+//			DeleteFunc: func(ctx context.Context, key string) error {
+//				panic("mock out the Delete method")
+//			},
+//			FlagsFunc: func() flags.Set {
+//				panic("mock out the Flags method")
+//			},
+//			GetFunc: func(ctx context.Context, key string) (*model.FeatureFlag, error) {
+//				panic("mock out the Get method")
+//			},
+//			ListFunc: func(ctx context.Context) ([]model.FeatureFlag, error) {
+//				panic("mock out the List method")
+//			},
+//			PutFunc: func(ctx context.Context, f *model.FeatureFlag) (*model.FeatureFlag, error) {
+//				panic("mock out the Put method")
+//			},
+//			RefreshFunc: func(ctx context.Context) error {
+//				panic("mock out the Refresh method")
+//			},
+//		}
+//
+//		// use mockedFlagServiceInteractor in code that requires FlagServiceInteractor
+//		// and then make assertions.
+//
+//	}
+type FlagServiceInteractorMock struct {
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, key string) error
+
+	// FlagsFunc mocks the Flags method.
+	FlagsFunc func() flags.Set
+
+	// GetFunc mocks the Get method.
+	GetFunc func(ctx context.Context, key string) (*model.FeatureFlag, error)
+
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context) ([]model.FeatureFlag, error)
+
+	// PutFunc mocks the Put method.
+	PutFunc func(ctx context.Context, f *model.FeatureFlag) (*model.FeatureFlag, error)
+
+	// RefreshFunc mocks the Refresh method.
+	RefreshFunc func(ctx context.Context) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// Flags holds details about calls to the Flags method.
+		Flags []struct {
+		}
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Put holds details about calls to the Put method.
+		Put []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// F is the f argument value.
+			F *model.FeatureFlag
+		}
+		// Refresh holds details about calls to the Refresh method.
+		Refresh []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockDelete  sync.RWMutex
+	lockFlags   sync.RWMutex
+	lockGet     sync.RWMutex
+	lockList    sync.RWMutex
+	lockPut     sync.RWMutex
+	lockRefresh sync.RWMutex
+}
+
+// Delete calls DeleteFunc.
+func (mock *FlagServiceInteractorMock) Delete(ctx context.Context, key string) error {
+	if mock.DeleteFunc == nil {
+		panic("FlagServiceInteractorMock.DeleteFunc: method is nil but FlagServiceInteractor.Delete was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(ctx, key)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedFlagServiceInteractor.DeleteCalls())
+func (mock *FlagServiceInteractorMock) DeleteCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// Flags calls FlagsFunc.
+func (mock *FlagServiceInteractorMock) Flags() flags.Set {
+	if mock.FlagsFunc == nil {
+		panic("FlagServiceInteractorMock.FlagsFunc: method is nil but FlagServiceInteractor.Flags was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockFlags.Lock()
+	mock.calls.Flags = append(mock.calls.Flags, callInfo)
+	mock.lockFlags.Unlock()
+	return mock.FlagsFunc()
+}
+
+// FlagsCalls gets all the calls that were made to Flags.
+// Check the length with:
+//
+//	len(mockedFlagServiceInteractor.FlagsCalls())
+func (mock *FlagServiceInteractorMock) FlagsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockFlags.RLock()
+	calls = mock.calls.Flags
+	mock.lockFlags.RUnlock()
+	return calls
+}
+
+// Get calls GetFunc.
+func (mock *FlagServiceInteractorMock) Get(ctx context.Context, key string) (*model.FeatureFlag, error) {
+	if mock.GetFunc == nil {
+		panic("FlagServiceInteractorMock.GetFunc: method is nil but FlagServiceInteractor.Get was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	return mock.GetFunc(ctx, key)
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedFlagServiceInteractor.GetCalls())
+func (mock *FlagServiceInteractorMock) GetCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// List calls ListFunc.
+func (mock *FlagServiceInteractorMock) List(ctx context.Context) ([]model.FeatureFlag, error) {
+	if mock.ListFunc == nil {
+		panic("FlagServiceInteractorMock.ListFunc: method is nil but FlagServiceInteractor.List was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(ctx)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedFlagServiceInteractor.ListCalls())
+func (mock *FlagServiceInteractorMock) ListCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// Put calls PutFunc.
+func (mock *FlagServiceInteractorMock) Put(ctx context.Context, f *model.FeatureFlag) (*model.FeatureFlag, error) {
+	if mock.PutFunc == nil {
+		panic("FlagServiceInteractorMock.PutFunc: method is nil but FlagServiceInteractor.Put was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		F   *model.FeatureFlag
+	}{
+		Ctx: ctx,
+		F:   f,
+	}
+	mock.lockPut.Lock()
+	mock.calls.Put = append(mock.calls.Put, callInfo)
+	mock.lockPut.Unlock()
+	return mock.PutFunc(ctx, f)
+}
+
+// PutCalls gets all the calls that were made to Put.
+// Check the length with:
+//
+//	len(mockedFlagServiceInteractor.PutCalls())
+func (mock *FlagServiceInteractorMock) PutCalls() []struct {
+	Ctx context.Context
+	F   *model.FeatureFlag
+} {
+	var calls []struct {
+		Ctx context.Context
+		F   *model.FeatureFlag
+	}
+	mock.lockPut.RLock()
+	calls = mock.calls.Put
+	mock.lockPut.RUnlock()
+	return calls
+}
+
+// Refresh calls RefreshFunc.
+func (mock *FlagServiceInteractorMock) Refresh(ctx context.Context) error {
+	if mock.RefreshFunc == nil {
+		panic("FlagServiceInteractorMock.RefreshFunc: method is nil but FlagServiceInteractor.Refresh was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockRefresh.Lock()
+	mock.calls.Refresh = append(mock.calls.Refresh, callInfo)
+	mock.lockRefresh.Unlock()
+	return mock.RefreshFunc(ctx)
+}
+
+// RefreshCalls gets all the calls that were made to Refresh.
+// Check the length with:
+//
+//	len(mockedFlagServiceInteractor.RefreshCalls())
+func (mock *FlagServiceInteractorMock) RefreshCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockRefresh.RLock()
+	calls = mock.calls.Refresh
+	mock.lockRefresh.RUnlock()
+	return calls
+}
@@ -0,0 +1,83 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/flags"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/service"
+)
+
+func TestFlagService_PutDefaultsBooleanAndRefreshes(t *testing.T) {
+	var stored []model.FeatureFlag
+	repo := &repository.FlagRepositoryMock{
+		UpsertFunc: func(ctx context.Context, f *model.FeatureFlag) error {
+			stored = append(stored, *f)
+			return nil
+		},
+		ListFunc: func(ctx context.Context) ([]model.FeatureFlag, error) { return stored, nil },
+	}
+	svc := service.NewFlags(repo)
+
+	f, err := svc.Put(context.Background(), &model.FeatureFlag{
+		Key: "new-editor", Enabled: true,
+		Rules: []model.FlagRule{{Percentage: 100, Variant: flags.On}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{flags.Off, flags.On}, f.Variants)
+	assert.Equal(t, flags.Off, f.DefaultVariant)
+	assert.Equal(t, flags.On, svc.Flags().Variant("new-editor", flags.Subject{UserID: uuid.New()}))
+}
+
+func TestFlagService_PutValidation(t *testing.T) {
+	svc := service.NewFlags(&repository.FlagRepositoryMock{})
+	_, err := svc.Put(context.Background(), &model.FeatureFlag{
+		Key:            "Bad Key",
+		Variants:       []string{"a", "a"},
+		DefaultVariant: "c",
+		Rules:          []model.FlagRule{{Percentage: 101, Variant: "z"}},
+	})
+	require.ErrorIs(t, err, service.ErrInvalidFlag)
+	for _, want := range []string{"key must be", `variant "a" is empty or duplicated`, `default_variant "c"`, `rule 1: variant "z"`, "rule 1: percentage"} {
+		assert.Contains(t, err.Error(), want)
+	}
+}
+
+func TestLinkedInService_Transform_StyleFromFlag(t *testing.T) {
+	userID := uuid.New()
+	set := flags.Set{flags.PostStyle: {
+		Key: flags.PostStyle, Enabled: true, Variants: []string{flags.DefaultStyle, "witty"}, DefaultVariant: flags.DefaultStyle,
+		Rules: []model.FlagRule{{UserIDs: []uuid.UUID{userID}, Percentage: 100, Variant: "witty"}},
+	}}
+	ctx := flags.WithSet(context.Background(), set, func(context.Context) flags.Subject { return flags.Subject{UserID: userID} })
+
+	var styles []string
+	aiClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, text string, opts ai.Options) (string, error) {
+			styles = append(styles, opts.Style)
+			return "post", nil
+		},
+	}
+	users := &repository.UserRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+			return &model.User{ID: id}, nil
+		},
+	}
+	posts := &repository.PostRepositoryMock{SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil }}
+	svc := service.NewLinkedIn(aiClient, posts, service.WithProfiles(users))
+
+	_, err := svc.Transform(ctx, userID, "hello", service.TransformOptions{})
+	require.NoError(t, err)
+	otherCtx := flags.WithSet(context.Background(), set, func(context.Context) flags.Subject { return flags.Subject{UserID: uuid.New()} })
+	_, err = svc.Transform(otherCtx, uuid.New(), "hello", service.TransformOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"witty", ""}, styles)
+}
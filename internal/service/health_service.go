@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Health states, from best to worst.
+const (
+	HealthOK          = "ok"
+	HealthDegraded    = "degraded"
+	HealthUnavailable = "unavailable"
+)
+
+// HealthCheck probes one dependency.
+type HealthCheck struct {
+	Name string
+	// Critical checks make the service unready when they fail; failures of
+	// other checks only mark it degraded.
+	Critical bool
+	Probe    func(ctx context.Context) error
+}
+
+// DependencyHealth is the outcome of a single HealthCheck.
+type DependencyHealth struct {
+	Name     string
+	Critical bool
+	Healthy  bool
+	Latency  time.Duration
+	Err      error
+}
+
+// HealthReport is the outcome of all checks. Status is HealthOK,
+// HealthDegraded or HealthUnavailable.
+type HealthReport struct {
+	Status       string
+	Dependencies []DependencyHealth
+}
+
+// Ready reports whether the service should receive traffic.
+func (r HealthReport) Ready() bool { return r.Status != HealthUnavailable }
+
+// HealthServiceInteractor reports on the dependencies of the service.
+type HealthServiceInteractor interface {
+	Readiness(ctx context.Context) HealthReport
+}
+
+type HealthService struct {
+	checks  []HealthCheck
+	timeout time.Duration
+}
+
+// NewHealth creates a HealthService running checks concurrently, each
+// bounded by timeout.
+func NewHealth(timeout time.Duration, checks ...HealthCheck) HealthServiceInteractor {
+	return &HealthService{checks: checks, timeout: timeout}
+}
+
+func (h *HealthService) Readiness(ctx context.Context) HealthReport {
+	deps := make([]DependencyHealth, len(h.checks))
+	var wg sync.WaitGroup
+	for i, c := range h.checks {
+		wg.Add(1)
+		go func(i int, c HealthCheck) {
+			defer wg.Done()
+			cctx, cancel := context.WithTimeout(ctx, h.timeout)
+			defer cancel()
+			start := time.Now()
+			err := c.Probe(cctx)
+			deps[i] = DependencyHealth{
+				Name:     c.Name,
+				Critical: c.Critical,
+				Healthy:  err == nil,
+				Latency:  time.Since(start),
+				Err:      err,
+			}
+		}(i, c)
+	}
+	wg.Wait()
+
+	report := HealthReport{Status: HealthOK, Dependencies: deps}
+	for _, d := range deps {
+		switch {
+		case d.Healthy:
+		case d.Critical:
+			report.Status = HealthUnavailable
+		case report.Status == HealthOK:
+			report.Status = HealthDegraded
+		}
+	}
+	return report
+}
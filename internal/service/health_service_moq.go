@@ -0,0 +1,75 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package service
+
+import (
+	"context"
+	"sync"
+)
+
+// Ensure, that HealthServiceInteractorMock does implement HealthServiceInteractor.
+// If this is not the case, regenerate this file with moq.
+var _ HealthServiceInteractor = &HealthServiceInteractorMock{}
+
+// HealthServiceInteractorMock is a mock implementation of HealthServiceInteractor.
+//
+//	func TestSomethingThatUsesHealthServiceInteractor(t *testing.T) {
+//
+//		// make and configure a mocked HealthServiceInteractor
+//		mockedHealthServiceInteractor := &HealthServiceInteractorMock{
+//			ReadinessFunc: func(ctx context.Context) HealthReport {
+//				panic("mock out the Readiness method")
+//			},
+//		}
+//
+//		// use mockedHealthServiceInteractor in code that requires HealthServiceInteractor
+//		// and then make assertions.
+//
+//	}
+type HealthServiceInteractorMock struct {
+	// ReadinessFunc mocks the Readiness method.
+	ReadinessFunc func(ctx context.Context) HealthReport
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Readiness holds details about calls to the Readiness method.
+		Readiness []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockReadiness sync.RWMutex
+}
+
+// Readiness calls ReadinessFunc.
+func (mock *HealthServiceInteractorMock) Readiness(ctx context.Context) HealthReport {
+	if mock.ReadinessFunc == nil {
+		panic("HealthServiceInteractorMock.ReadinessFunc: method is nil but HealthServiceInteractor.Readiness was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockReadiness.Lock()
+	mock.calls.Readiness = append(mock.calls.Readiness, callInfo)
+	mock.lockReadiness.Unlock()
+	return mock.ReadinessFunc(ctx)
+}
+
+// ReadinessCalls gets all the calls that were made to Readiness.
+// Check the length with:
+//
+//	len(mockedHealthServiceInteractor.ReadinessCalls())
+func (mock *HealthServiceInteractorMock) ReadinessCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockReadiness.RLock()
+	calls = mock.calls.Readiness
+	mock.lockReadiness.RUnlock()
+	return calls
+}
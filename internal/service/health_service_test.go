@@ -0,0 +1,61 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/service"
+)
+
+func probe(err error) func(context.Context) error {
+	return func(context.Context) error { return err }
+}
+
+func TestHealthService_Readiness(t *testing.T) {
+	down := errors.New("down")
+	tests := []struct {
+		name   string
+		checks []service.HealthCheck
+		want   string
+	}{
+		{"all healthy", []service.HealthCheck{{Name: "db", Critical: true, Probe: probe(nil)}}, service.HealthOK},
+		{"optional failure degrades", []service.HealthCheck{
+			{Name: "db", Critical: true, Probe: probe(nil)},
+			{Name: "ai", Probe: probe(down)},
+		}, service.HealthDegraded},
+		{"critical failure is unavailable", []service.HealthCheck{
+			{Name: "ai", Probe: probe(down)},
+			{Name: "db", Critical: true, Probe: probe(down)},
+		}, service.HealthUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := service.NewHealth(time.Second, tt.checks...).Readiness(context.Background())
+			assert.Equal(t, tt.want, report.Status)
+			assert.Equal(t, tt.want != service.HealthUnavailable, report.Ready())
+			require.Len(t, report.Dependencies, len(tt.checks))
+			for i, d := range report.Dependencies {
+				assert.Equal(t, tt.checks[i].Name, d.Name)
+			}
+		})
+	}
+}
+
+func TestHealthService_Readiness_TimesOutSlowChecks(t *testing.T) {
+	svc := service.NewHealth(10*time.Millisecond, service.HealthCheck{
+		Name: "slow", Critical: true,
+		Probe: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+
+	report := svc.Readiness(context.Background())
+	assert.Equal(t, service.HealthUnavailable, report.Status)
+	assert.ErrorIs(t, report.Dependencies[0].Err, context.DeadlineExceeded)
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/carousel"
+	"github.com/you/linkedinify/internal/hashtag"
+	"github.com/you/linkedinify/internal/imagegen"
+	"github.com/you/linkedinify/internal/logging"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/postformat"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/storage"
+)
+
+var (
+	// ErrImageNotFound is returned for unknown image IDs and images of other
+	// posts or users.
+	ErrImageNotFound = errors.New("image not found")
+	// ErrInvalidImage is wrapped by every rejected upload and by carousels
+	// of posts without text.
+	ErrInvalidImage = errors.New("invalid image")
+)
+
+// MaxImageSize is the largest image that can be uploaded, in bytes.
+const MaxImageSize = 5 << 20
+
+// imageTopics is how many of a post's keywords an image is asked to
+// illustrate.
+const imageTopics = 3
+
+// uploadTypes are the image types accepted for upload, by the content type
+// detected from their first bytes, with the extension they are stored
+// under. SVG is not among them: it can carry scripts.
+var uploadTypes = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// ImageServiceInteractor manages the images attached to posts.
+type ImageServiceInteractor interface {
+	// Generate makes an image for the post with the configured generator
+	// and attaches it.
+	Generate(ctx context.Context, userID, postID uuid.UUID) (*model.PostImage, error)
+	// Attach stores an uploaded PNG, JPEG, GIF or WebP image of at most
+	// MaxImageSize bytes for the post.
+	Attach(ctx context.Context, userID, postID uuid.UUID, r io.Reader) (*model.PostImage, error)
+	// List returns the post's images, oldest first.
+	List(ctx context.Context, userID, postID uuid.UUID) ([]model.PostImage, error)
+	// Open returns an image with its content, which the caller must close.
+	Open(ctx context.Context, userID, postID, imageID uuid.UUID) (*model.PostImage, io.ReadCloser, error)
+	// Carousel renders the post as a PDF carousel, see package carousel.
+	Carousel(ctx context.Context, userID, postID uuid.UUID) ([]byte, error)
+}
+
+type ImageService struct {
+	gen    imagegen.ImageGenerator
+	blobs  storage.BlobStore
+	posts  repository.PostRepository
+	images repository.ImageRepository
+}
+
+// NewImages creates a new ImageService instance.
+func NewImages(gen imagegen.ImageGenerator, blobs storage.BlobStore, pr repository.PostRepository, ir repository.ImageRepository) ImageServiceInteractor {
+	return &ImageService{gen: gen, blobs: blobs, posts: pr, images: ir}
+}
+
+// Generate describes the post to the generator by its first line and
+// keywords. Generators that send the request elsewhere only get them with
+// personal data removed.
+func (s *ImageService) Generate(ctx context.Context, userID, postID uuid.UUID) (*model.PostImage, error) {
+	post, err := s.findPost(ctx, userID, postID)
+	if err != nil {
+		return nil, err
+	}
+	text := post.OutputText
+	if !s.gen.Local() {
+		text, _, _ = redact(text)
+		text = placeholderPattern.ReplaceAllString(text, "")
+	}
+	req := imagegen.Request{Headline: headline(text)}
+	for _, kw := range hashtag.Keywords(text, imageTopics) {
+		if tag := hashtag.Tag(kw.Phrase); tag != "" {
+			req.Topics = append(req.Topics, tag)
+		}
+	}
+	img, err := s.gen.Generate(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("generating image with %s: %w", s.gen.Name(), err)
+	}
+	return s.store(ctx, post, img.Data, img.ContentType, img.Extension, s.gen.Name())
+}
+
+func (s *ImageService) Attach(ctx context.Context, userID, postID uuid.UUID, r io.Reader) (*model.PostImage, error) {
+	post, err := s.findPost(ctx, userID, postID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(io.LimitReader(r, MaxImageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(data) == 0:
+		return nil, fmt.Errorf("%w: the image is empty", ErrInvalidImage)
+	case len(data) > MaxImageSize:
+		return nil, fmt.Errorf("%w: images may be at most %d MB", ErrInvalidImage, MaxImageSize>>20)
+	}
+	contentType := storage.DetectContentType("", data, "")
+	ext, ok := uploadTypes[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: only PNG, JPEG, GIF and WebP images are accepted", ErrInvalidImage)
+	}
+	return s.store(ctx, post, data, contentType, ext, model.ImageSourceUpload)
+}
+
+func (s *ImageService) List(ctx context.Context, userID, postID uuid.UUID) ([]model.PostImage, error) {
+	if _, err := s.findPost(ctx, userID, postID); err != nil {
+		return nil, err
+	}
+	return s.images.ListByPost(ctx, userID, postID)
+}
+
+func (s *ImageService) Open(ctx context.Context, userID, postID, imageID uuid.UUID) (*model.PostImage, io.ReadCloser, error) {
+	img, err := s.images.FindByID(ctx, userID, postID, imageID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, ErrImageNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	rc, _, err := s.blobs.Open(ctx, img.BlobKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil, ErrImageNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return img, rc, nil
+}
+
+func (s *ImageService) Carousel(ctx context.Context, userID, postID uuid.UUID) ([]byte, error) {
+	post, err := s.findPost(ctx, userID, postID)
+	if err != nil {
+		return nil, err
+	}
+	slides := carousel.Slides(post.OutputText)
+	if len(slides) == 0 {
+		return nil, fmt.Errorf("%w: the post has no text for a carousel", ErrInvalidImage)
+	}
+	// The carousel fonts cover Western European languages only; rather
+	// than lose words, such posts get no carousel.
+	if missing := carousel.Unsupported(slides); len(missing) > 0 {
+		if len(missing) > 10 {
+			missing = missing[:10]
+		}
+		return nil, fmt.Errorf("%w: the carousel cannot show the characters %q", ErrInvalidImage, string(missing))
+	}
+	return carousel.PDF(slides, carousel.Options{Title: slides[0].Heading}), nil
+}
+
+func (s *ImageService) findPost(ctx context.Context, userID, postID uuid.UUID) (*model.LinkedInPost, error) {
+	post, err := s.posts.FindByID(ctx, userID, postID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPostNotFound
+	}
+	return post, err
+}
+
+// store puts the image into the blob store under the user's prefix and
+// records it. The blob is removed again when it cannot be recorded.
+func (s *ImageService) store(ctx context.Context, post *model.LinkedInPost, data []byte, contentType, ext, source string) (*model.PostImage, error) {
+	img := &model.PostImage{
+		ID:          uuid.New(),
+		PostID:      post.ID,
+		UserID:      post.UserID,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Source:      source,
+	}
+	img.BlobKey = fmt.Sprintf("%s/images/%s%s", img.UserID, img.ID, ext)
+	if _, err := s.blobs.Put(ctx, img.BlobKey, bytes.NewReader(data), contentType); err != nil {
+		return nil, err
+	}
+	if err := s.images.Create(ctx, img); err != nil {
+		if derr := s.blobs.Delete(ctx, img.BlobKey); derr != nil {
+			logging.FromContext(ctx).Warn("orphaned image blob", "key", img.BlobKey, "err", derr)
+		}
+		return nil, err
+	}
+	return img, nil
+}
+
+// headline is the first line of a post as plain text.
+func headline(post string) string {
+	text, err := postformat.Render(post, postformat.Plain)
+	if err != nil {
+		text = post
+	}
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
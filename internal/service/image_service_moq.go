@@ -0,0 +1,326 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/you/linkedinify/internal/model"
+	"io"
+	"sync"
+)
+
+// Ensure, that ImageServiceInteractorMock does implement ImageServiceInteractor.
+// If this is not the case, regenerate this file with moq.
+var _ ImageServiceInteractor = &ImageServiceInteractorMock{}
+
+// ImageServiceInteractorMock is a mock implementation of ImageServiceInteractor.
+//
+//	func TestSomethingThatUsesImageServiceInteractor(t *testing.T) {
+//
+//		// make and configure a mocked ImageServiceInteractor
+//		mockedImageServiceInteractor := &ImageServiceInteractorMock{
+//			AttachFunc: func(ctx context.Context, userID uuid.UUID, postID uuid.UUID, r io.Reader) (*model.PostImage, error) {
+//				panic("mock out the Attach method")
+//			},
+//			CarouselFunc: func(ctx context.Context, userID uuid.UUID, postID uuid.UUID) ([]byte, error) {
+//				panic("mock out the Carousel method")
+//			},
+//			GenerateFunc: func(ctx context.Context, userID uuid.UUID, postID uuid.UUID) (*model.PostImage, error) {
+//				panic("mock out the Generate method")
+//			},
+//			ListFunc: func(ctx context.Context, userID uuid.UUID, postID uuid.UUID) ([]model.PostImage, error) {
+//				panic("mock out the List method")
+//			},
+//			OpenFunc: func(ctx context.Context, userID uuid.UUID, postID uuid.UUID, imageID uuid.UUID) (*model.PostImage, io.ReadCloser, error) {
+//				panic("mock out the Open method")
+//			},
+//		}
+//
+//		// use mockedImageServiceInteractor in code that requires ImageServiceInteractor
+//		// and then make assertions.
+//
+//	}
+type ImageServiceInteractorMock struct {
+	// AttachFunc mocks the Attach method.
+	AttachFunc func(ctx context.Context, userID uuid.UUID, postID uuid.UUID, r io.Reader) (*model.PostImage, error)
+
+	// CarouselFunc mocks the Carousel method.
+	CarouselFunc func(ctx context.Context, userID uuid.UUID, postID uuid.UUID) ([]byte, error)
+
+	// GenerateFunc mocks the Generate method.
+	GenerateFunc func(ctx context.Context, userID uuid.UUID, postID uuid.UUID) (*model.PostImage, error)
+
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context, userID uuid.UUID, postID uuid.UUID) ([]model.PostImage, error)
+
+	// OpenFunc mocks the Open method.
+	OpenFunc func(ctx context.Context, userID uuid.UUID, postID uuid.UUID, imageID uuid.UUID) (*model.PostImage, io.ReadCloser, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Attach holds details about calls to the Attach method.
+		Attach []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// PostID is the postID argument value.
+			PostID uuid.UUID
+			// R is the r argument value.
+			R io.Reader
+		}
+		// Carousel holds details about calls to the Carousel method.
+		Carousel []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// PostID is the postID argument value.
+			PostID uuid.UUID
+		}
+		// Generate holds details about calls to the Generate method.
+		Generate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// PostID is the postID argument value.
+			PostID uuid.UUID
+		}
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// PostID is the postID argument value.
+			PostID uuid.UUID
+		}
+		// Open holds details about calls to the Open method.
+		Open []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// PostID is the postID argument value.
+			PostID uuid.UUID
+			// ImageID is the imageID argument value.
+			ImageID uuid.UUID
+		}
+	}
+	lockAttach   sync.RWMutex
+	lockCarousel sync.RWMutex
+	lockGenerate sync.RWMutex
+	lockList     sync.RWMutex
+	lockOpen     sync.RWMutex
+}
+
+// Attach calls AttachFunc.
+func (mock *ImageServiceInteractorMock) Attach(ctx context.Context, userID uuid.UUID, postID uuid.UUID, r io.Reader) (*model.PostImage, error) {
+	if mock.AttachFunc == nil {
+		panic("ImageServiceInteractorMock.AttachFunc: method is nil but ImageServiceInteractor.Attach was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+		R      io.Reader
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		PostID: postID,
+		R:      r,
+	}
+	mock.lockAttach.Lock()
+	mock.calls.Attach = append(mock.calls.Attach, callInfo)
+	mock.lockAttach.Unlock()
+	return mock.AttachFunc(ctx, userID, postID, r)
+}
+
+// AttachCalls gets all the calls that were made to Attach.
+// Check the length with:
+//
+//	len(mockedImageServiceInteractor.AttachCalls())
+func (mock *ImageServiceInteractorMock) AttachCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	PostID uuid.UUID
+	R      io.Reader
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+		R      io.Reader
+	}
+	mock.lockAttach.RLock()
+	calls = mock.calls.Attach
+	mock.lockAttach.RUnlock()
+	return calls
+}
+
+// Carousel calls CarouselFunc.
+func (mock *ImageServiceInteractorMock) Carousel(ctx context.Context, userID uuid.UUID, postID uuid.UUID) ([]byte, error) {
+	if mock.CarouselFunc == nil {
+		panic("ImageServiceInteractorMock.CarouselFunc: method is nil but ImageServiceInteractor.Carousel was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		PostID: postID,
+	}
+	mock.lockCarousel.Lock()
+	mock.calls.Carousel = append(mock.calls.Carousel, callInfo)
+	mock.lockCarousel.Unlock()
+	return mock.CarouselFunc(ctx, userID, postID)
+}
+
+// CarouselCalls gets all the calls that were made to Carousel.
+// Check the length with:
+//
+//	len(mockedImageServiceInteractor.CarouselCalls())
+func (mock *ImageServiceInteractorMock) CarouselCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	PostID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+	}
+	mock.lockCarousel.RLock()
+	calls = mock.calls.Carousel
+	mock.lockCarousel.RUnlock()
+	return calls
+}
+
+// Generate calls GenerateFunc.
+func (mock *ImageServiceInteractorMock) Generate(ctx context.Context, userID uuid.UUID, postID uuid.UUID) (*model.PostImage, error) {
+	if mock.GenerateFunc == nil {
+		panic("ImageServiceInteractorMock.GenerateFunc: method is nil but ImageServiceInteractor.Generate was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		PostID: postID,
+	}
+	mock.lockGenerate.Lock()
+	mock.calls.Generate = append(mock.calls.Generate, callInfo)
+	mock.lockGenerate.Unlock()
+	return mock.GenerateFunc(ctx, userID, postID)
+}
+
+// GenerateCalls gets all the calls that were made to Generate.
+// Check the length with:
+//
+//	len(mockedImageServiceInteractor.GenerateCalls())
+func (mock *ImageServiceInteractorMock) GenerateCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	PostID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+	}
+	mock.lockGenerate.RLock()
+	calls = mock.calls.Generate
+	mock.lockGenerate.RUnlock()
+	return calls
+}
+
+// List calls ListFunc.
+func (mock *ImageServiceInteractorMock) List(ctx context.Context, userID uuid.UUID, postID uuid.UUID) ([]model.PostImage, error) {
+	if mock.ListFunc == nil {
+		panic("ImageServiceInteractorMock.ListFunc: method is nil but ImageServiceInteractor.List was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		PostID: postID,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(ctx, userID, postID)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedImageServiceInteractor.ListCalls())
+func (mock *ImageServiceInteractorMock) ListCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	PostID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// Open calls OpenFunc.
+func (mock *ImageServiceInteractorMock) Open(ctx context.Context, userID uuid.UUID, postID uuid.UUID, imageID uuid.UUID) (*model.PostImage, io.ReadCloser, error) {
+	if mock.OpenFunc == nil {
+		panic("ImageServiceInteractorMock.OpenFunc: method is nil but ImageServiceInteractor.Open was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		UserID  uuid.UUID
+		PostID  uuid.UUID
+		ImageID uuid.UUID
+	}{
+		Ctx:     ctx,
+		UserID:  userID,
+		PostID:  postID,
+		ImageID: imageID,
+	}
+	mock.lockOpen.Lock()
+	mock.calls.Open = append(mock.calls.Open, callInfo)
+	mock.lockOpen.Unlock()
+	return mock.OpenFunc(ctx, userID, postID, imageID)
+}
+
+// OpenCalls gets all the calls that were made to Open.
+// Check the length with:
+//
+//	len(mockedImageServiceInteractor.OpenCalls())
+func (mock *ImageServiceInteractorMock) OpenCalls() []struct {
+	Ctx     context.Context
+	UserID  uuid.UUID
+	PostID  uuid.UUID
+	ImageID uuid.UUID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		UserID  uuid.UUID
+		PostID  uuid.UUID
+		ImageID uuid.UUID
+	}
+	mock.lockOpen.RLock()
+	calls = mock.calls.Open
+	mock.lockOpen.RUnlock()
+	return calls
+}
@@ -0,0 +1,245 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/imagegen"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/service"
+	"github.com/you/linkedinify/internal/storage"
+)
+
+func pngBytes(t *testing.T) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.White)
+	var b bytes.Buffer
+	require.NoError(t, png.Encode(&b, img))
+	return b.Bytes()
+}
+
+// imageFixture wires an ImageService to a post of userID, an in-memory
+// image repository and a blob store in a temporary directory.
+type imageFixture struct {
+	userID, postID uuid.UUID
+	blobs          *storage.Local
+	images         *repository.ImageRepositoryMock
+	created        []model.PostImage
+}
+
+func newImageFixture(t *testing.T) *imageFixture {
+	t.Helper()
+	blobs, err := storage.NewLocal(t.TempDir())
+	require.NoError(t, err)
+	f := &imageFixture{userID: uuid.New(), postID: uuid.New(), blobs: blobs}
+	f.images = &repository.ImageRepositoryMock{
+		CreateFunc: func(ctx context.Context, img *model.PostImage) error {
+			f.created = append(f.created, *img)
+			return nil
+		},
+		ListByPostFunc: func(ctx context.Context, userID, postID uuid.UUID) ([]model.PostImage, error) {
+			return f.created, nil
+		},
+		FindByIDFunc: func(ctx context.Context, userID, postID, id uuid.UUID) (*model.PostImage, error) {
+			for _, img := range f.created {
+				if img.ID == id && img.PostID == postID && img.UserID == userID {
+					return &img, nil
+				}
+			}
+			return nil, sql.ErrNoRows
+		},
+	}
+	return f
+}
+
+func (f *imageFixture) posts(text string) *repository.PostRepositoryMock {
+	return &repository.PostRepositoryMock{FindByIDFunc: func(ctx context.Context, userID, id uuid.UUID) (*model.LinkedInPost, error) {
+		if userID != f.userID || id != f.postID {
+			return nil, sql.ErrNoRows
+		}
+		return &model.LinkedInPost{ID: id, UserID: userID, OutputText: text}, nil
+	}}
+}
+
+func TestImageService_Generate_StoresImage(t *testing.T) {
+	f := newImageFixture(t)
+	svc := service.NewImages(imagegen.NewPlaceholder(), f.blobs, f.posts("Shipping beats perfection.\n\nWe launched the beta of our payments API."), f.images)
+
+	img, err := svc.Generate(context.Background(), f.userID, f.postID)
+
+	require.NoError(t, err)
+	assert.Equal(t, "placeholder", img.Source)
+	assert.Equal(t, "image/svg+xml", img.ContentType)
+	assert.Equal(t, f.userID.String()+"/images/"+img.ID.String()+".svg", img.BlobKey)
+	require.Len(t, f.created, 1)
+
+	got, rc, err := svc.Open(context.Background(), f.userID, f.postID, img.ID)
+	require.NoError(t, err)
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	assert.Equal(t, img.ID, got.ID)
+	assert.Contains(t, string(data), "Shipping beats perfection.")
+	assert.EqualValues(t, len(data), img.Size)
+}
+
+func TestImageService_Generate_RedactsForRemoteGenerators(t *testing.T) {
+	var req imagegen.Request
+	gen := &imagegen.ImageGeneratorMock{
+		NameFunc:  func() string { return "remote" },
+		LocalFunc: func() bool { return false },
+		GenerateFunc: func(ctx context.Context, r imagegen.Request) (*imagegen.Image, error) {
+			req = r
+			return &imagegen.Image{Data: []byte("png"), ContentType: "image/png", Extension: ".png"}, nil
+		},
+	}
+	f := newImageFixture(t)
+	svc := service.NewImages(gen, f.blobs, f.posts("Write to jane.doe@example.com for the onboarding checklist"), f.images)
+
+	_, err := svc.Generate(context.Background(), f.userID, f.postID)
+
+	require.NoError(t, err)
+	assert.NotContains(t, req.Headline, "jane.doe@example.com")
+	assert.NotContains(t, req.Headline, "[EMAIL")
+	assert.Contains(t, req.Headline, "onboarding checklist")
+}
+
+func TestImageService_Generate_FailureStoresNothing(t *testing.T) {
+	gen := &imagegen.ImageGeneratorMock{
+		NameFunc:  func() string { return "remote" },
+		LocalFunc: func() bool { return false },
+		GenerateFunc: func(ctx context.Context, r imagegen.Request) (*imagegen.Image, error) {
+			return nil, errors.New("quota exceeded")
+		},
+	}
+	f := newImageFixture(t)
+	svc := service.NewImages(gen, f.blobs, f.posts("Hello"), f.images)
+
+	_, err := svc.Generate(context.Background(), f.userID, f.postID)
+
+	assert.ErrorContains(t, err, "quota exceeded")
+	assert.Empty(t, f.created)
+}
+
+func TestImageService_Generate_RemovesBlobWhenRecordFails(t *testing.T) {
+	f := newImageFixture(t)
+	var key string
+	f.images.CreateFunc = func(ctx context.Context, img *model.PostImage) error {
+		key = img.BlobKey
+		return errors.New("db down")
+	}
+	svc := service.NewImages(imagegen.NewPlaceholder(), f.blobs, f.posts("Hello"), f.images)
+
+	_, err := svc.Generate(context.Background(), f.userID, f.postID)
+
+	require.Error(t, err)
+	_, _, err = f.blobs.Open(context.Background(), key)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestImageService_UnknownPost(t *testing.T) {
+	f := newImageFixture(t)
+	svc := service.NewImages(imagegen.NewPlaceholder(), f.blobs, f.posts("Hello"), f.images)
+	ctx := context.Background()
+	other := uuid.New()
+
+	_, err := svc.Generate(ctx, f.userID, other)
+	assert.ErrorIs(t, err, service.ErrPostNotFound)
+	_, err = svc.Attach(ctx, f.userID, other, bytes.NewReader(pngBytes(t)))
+	assert.ErrorIs(t, err, service.ErrPostNotFound)
+	_, err = svc.List(ctx, uuid.New(), f.postID)
+	assert.ErrorIs(t, err, service.ErrPostNotFound)
+	_, err = svc.Carousel(ctx, f.userID, other)
+	assert.ErrorIs(t, err, service.ErrPostNotFound)
+	_, _, err = svc.Open(ctx, f.userID, f.postID, uuid.New())
+	assert.ErrorIs(t, err, service.ErrImageNotFound)
+}
+
+func TestImageService_Attach(t *testing.T) {
+	f := newImageFixture(t)
+	svc := service.NewImages(imagegen.NewPlaceholder(), f.blobs, f.posts("Hello"), f.images)
+	ctx := context.Background()
+
+	img, err := svc.Attach(ctx, f.userID, f.postID, bytes.NewReader(pngBytes(t)))
+	require.NoError(t, err)
+	assert.Equal(t, model.ImageSourceUpload, img.Source)
+	assert.Equal(t, "image/png", img.ContentType)
+	assert.True(t, strings.HasSuffix(img.BlobKey, ".png"))
+
+	list, err := svc.List(ctx, f.userID, f.postID)
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+}
+
+func TestImageService_Attach_Rejects(t *testing.T) {
+	f := newImageFixture(t)
+	svc := service.NewImages(imagegen.NewPlaceholder(), f.blobs, f.posts("Hello"), f.images)
+
+	for name, body := range map[string][]byte{
+		"empty":     nil,
+		"svg":       []byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`),
+		"html":      []byte(`<!DOCTYPE html><html></html>`),
+		"too large": append(pngBytes(t), make([]byte, service.MaxImageSize)...),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := svc.Attach(context.Background(), f.userID, f.postID, bytes.NewReader(body))
+			assert.ErrorIs(t, err, service.ErrInvalidImage)
+		})
+	}
+	assert.Empty(t, f.created)
+}
+
+func TestImageService_Carousel(t *testing.T) {
+	f := newImageFixture(t)
+	svc := service.NewImages(imagegen.NewPlaceholder(), f.blobs, f.posts("Three lessons from our launch\n\nShip early.\n\nListen to users.\n\n#startups"), f.images)
+
+	pdf, err := svc.Carousel(context.Background(), f.userID, f.postID)
+
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4")))
+	assert.Contains(t, string(pdf), "/Count 3")
+	assert.Contains(t, string(pdf), "(Three lessons from our launch)")
+}
+
+func TestImageService_Carousel_EmptyPost(t *testing.T) {
+	f := newImageFixture(t)
+	svc := service.NewImages(imagegen.NewPlaceholder(), f.blobs, f.posts("  "), f.images)
+
+	_, err := svc.Carousel(context.Background(), f.userID, f.postID)
+
+	assert.ErrorIs(t, err, service.ErrInvalidImage)
+}
+
+func TestImageService_Carousel_UnsupportedScript(t *testing.T) {
+	f := newImageFixture(t)
+	svc := service.NewImages(imagegen.NewPlaceholder(), f.blobs, f.posts("Launch 🚀\n\nСпасибо команде!"), f.images)
+
+	_, err := svc.Carousel(context.Background(), f.userID, f.postID)
+
+	assert.ErrorIs(t, err, service.ErrInvalidImage)
+	assert.Contains(t, err.Error(), `"Спасибокмн"`, "at most ten characters are listed")
+}
+
+func TestImageService_Attach_OverQuota(t *testing.T) {
+	f := newImageFixture(t)
+	svc := service.NewImages(imagegen.NewPlaceholder(), storage.NewQuota(f.blobs, 10), f.posts("Hello"), f.images)
+
+	_, err := svc.Attach(context.Background(), f.userID, f.postID, bytes.NewReader(pngBytes(t)))
+
+	assert.ErrorIs(t, err, storage.ErrQuotaExceeded)
+	assert.Empty(t, f.created)
+}
@@ -3,77 +3,498 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"sync" // Added for RWMutex
+	"unicode/utf8"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 
 	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/flags"
+	"github.com/you/linkedinify/internal/logging"
 	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/moderation"
+	"github.com/you/linkedinify/internal/quality"
 	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/telemetry"
 )
 
+// ErrPostNotFound is returned for unknown post IDs and posts owned by someone else.
+var ErrPostNotFound = errors.New("post not found")
+
 // LinkedInServiceInteractor defines the operations for LinkedIn related services.
 type LinkedInServiceInteractor interface {
-	Transform(ctx context.Context, userID uuid.UUID, text string) (string, error)
-	History(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]model.LinkedInPost, error)
+	// Transform generates a post from text and returns it as saved to the
+	// user's history, scored for quality.
+	Transform(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (*model.LinkedInPost, error)
+	History(ctx context.Context, userID uuid.UUID, page, pageSize int, filter repository.PostFilter) ([]model.LinkedInPost, error)
+	Translate(ctx context.Context, userID, postID uuid.UUID, languages []string) ([]model.LinkedInPost, error)
+	Export(ctx context.Context, userID uuid.UUID, fn func(*model.LinkedInPost) error) error
+	Import(ctx context.Context, userID uuid.UUID, posts []model.LinkedInPost) (int, error)
+	TransformCache
+}
+
+// TransformOptions are per-request choices layered over the author's
+// profile defaults.
+type TransformOptions struct {
+	// VoiceProfileID selects one of the user's voice profiles to imitate.
+	VoiceProfileID uuid.UUID
+	// Language is a BCP-47 tag overriding the profile's preferred language.
+	Language string
+	// SuggestHashtags adds suggested hashtags to the profile's defaults,
+	// see WithSuggestions.
+	SuggestHashtags bool
 }
 
 type LinkedInService struct {
-	ai    ai.Client
-	posts repository.PostRepository
-	cache map[string]string // Added for in-memory caching
-	mu    sync.RWMutex      // Added for cache synchronization
+	ai     ai.Client
+	posts  repository.PostRepository
+	users  repository.UserRepository  // optional, see WithProfiles
+	voices repository.VoiceRepository // optional, see WithVoices
+	stats  CacheMetrics               // optional, see WithCacheMetrics
+	rules  PostRules                  // see WithPostRules
+	// optional, see WithModerator
+	moderator moderation.Moderator
+	// optional, see WithRuntimeSettings
+	settings RuntimeSettingsSource
+	// optional, see WithSuggestions
+	suggest SuggestServiceInteractor
+	// cache maps each user to the outputs generated for them, keyed by
+	// input text and prompt options, so entries can be dropped per user.
+	cache map[uuid.UUID]map[string]cachedPost
+	mu    sync.RWMutex // Added for cache synchronization
+}
+
+// cachedPost is a generated post, the AI backend that produced it and
+// what moderation flagged in it.
+type cachedPost struct {
+	text    string
+	served  ai.Served
+	flagged []moderation.Finding
+}
+
+// LinkedInOption configures optional collaborators of a LinkedInService.
+type LinkedInOption func(*LinkedInService)
+
+// WithProfiles makes Transform personalize prompts with the author's
+// profile and generation defaults.
+func WithProfiles(users repository.UserRepository) LinkedInOption {
+	return func(l *LinkedInService) { l.users = users }
 }
 
+// WithVoices enables TransformOptions.VoiceProfileID.
+func WithVoices(voices repository.VoiceRepository) LinkedInOption {
+	return func(l *LinkedInService) { l.voices = voices }
+}
+
+// CacheMetrics receives transform cache statistics.
+type CacheMetrics interface {
+	CacheHit()
+	CacheMiss()
+	CacheSize(entries int)
+}
+
+// WithCacheMetrics reports cache hits, misses and size to m.
+func WithCacheMetrics(m CacheMetrics) LinkedInOption {
+	return func(l *LinkedInService) { l.stats = m }
+}
+
+// WithRuntimeSettings applies the current runtime settings, such as the
+// system prompt, to every transform.
+func WithRuntimeSettings(src RuntimeSettingsSource) LinkedInOption {
+	return func(l *LinkedInService) { l.settings = src }
+}
+
+// WithPostRules replaces DefaultPostRules as the constraints generated
+// posts are held to.
+func WithPostRules(rules PostRules) LinkedInOption {
+	return func(l *LinkedInService) { l.rules = rules }
+}
+
+type noCacheMetrics struct{}
+
+func (noCacheMetrics) CacheHit()     {}
+func (noCacheMetrics) CacheMiss()    {}
+func (noCacheMetrics) CacheSize(int) {}
+
 // NewLinkedIn creates a new LinkedInService instance.
 // It now returns the LinkedInServiceInteractor interface.
-func NewLinkedIn(ai ai.Client, pr repository.PostRepository) LinkedInServiceInteractor {
-	return &LinkedInService{
+func NewLinkedIn(ai ai.Client, pr repository.PostRepository, opts ...LinkedInOption) LinkedInServiceInteractor {
+	l := &LinkedInService{
 		ai:    ai,
 		posts: pr,
-		cache: make(map[string]string), // Initialize cache
+		cache: make(map[uuid.UUID]map[string]cachedPost), // Initialize cache
+		stats: noCacheMetrics{},
+		rules: DefaultPostRules(),
+	}
+	for _, opt := range opts {
+		opt(l)
 	}
+	return l
 }
 
-func (l *LinkedInService) Transform(ctx context.Context, userID uuid.UUID, text string) (string, error) {
+func (l *LinkedInService) Transform(ctx context.Context, userID uuid.UUID, text string, topts TransformOptions) (post *model.LinkedInPost, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "LinkedInService.Transform")
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	opts, err := l.promptOptions(ctx, userID, topts)
+	if err != nil {
+		return nil, err
+	}
+	safeText := text
+	red, err := protect(ctx, l.settings, append([]*string{&safeText}, promptPersonalData(&opts)...)...)
+	if err != nil {
+		return nil, err
+	}
+	// The local rules see the text as written; what was redacted stays
+	// hidden from remote moderators.
+	modCtx := moderation.WithMask(ctx, red.hide)
+	inputFlags, err := l.moderate(modCtx, moderation.Input, moderatedInput(text, opts))
+	if err != nil {
+		return nil, err
+	}
+	opts.Placeholders = red != nil
+	if topts.SuggestHashtags {
+		l.addSuggestedHashtags(ctx, userID, safeText, &opts)
+	}
+	key := text + "\x00" + fmt.Sprintf("%#v", opts)
+
 	// Check cache first (read lock)
 	l.mu.RLock()
-	cachedOutput, found := l.cache[text]
+	cached, found := l.cache[userID][key]
 	l.mu.RUnlock()
+	span.SetAttributes(attribute.Bool("cache.hit", found), attribute.String("post.language", opts.Language))
 
-	var out string
-	var err error
-
+	logger := logging.FromContext(ctx)
 	if found {
-		out = cachedOutput
+		logger.Debug("transform served from cache")
+		l.stats.CacheHit()
 	} else {
+		l.stats.CacheMiss()
 		// If not found, call AI, then write to cache (write lock)
-		out, err = l.ai.Transform(ctx, text)
+		aiCtx, served := ai.TrackServed(ctx)
+		out, err := l.generate(aiCtx, safeText, red, opts)
+		if err != nil {
+			logger.Error("AI transform failed", "err", err)
+			return nil, err
+		}
+		outputFlags, err := l.moderate(modCtx, moderation.Output, out)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
+		cached = cachedPost{text: out, served: *served, flagged: outputFlags}
 
 		l.mu.Lock()
-		l.cache[text] = out
+		if l.cache[userID] == nil {
+			l.cache[userID] = make(map[string]cachedPost)
+		}
+		l.cache[userID][key] = cached
+		l.stats.CacheSize(l.cacheLen())
 		l.mu.Unlock()
 	}
 
 	// Save the transformation to history regardless of cache hit/miss
-	post := &model.LinkedInPost{
+	score := quality.Analyze(cached.text, opts.Language)
+	post = &model.LinkedInPost{
 		ID:         uuid.New(),
 		UserID:     userID,
 		InputText:  text,
-		OutputText: out,
+		OutputText: cached.text,
+		Language:   opts.Language,
+		Provider:   cached.served.Provider,
+		Model:      cached.served.Model,
+		// Input findings depend on the text alone, so only the output's
+		// are cached.
+		ModerationFlags: moderationFlags(inputFlags, cached.flagged),
+		Quality:         &score,
 	}
 	if err = l.posts.Save(ctx, post); err != nil {
 		// Note: If saving fails, we might have already transformed and cached.
 		// Depending on requirements, one might want to invalidate the cache entry here.
 		// For now, we'll return the error and keep the cache entry.
+		return nil, err
+	}
+	return post, nil
+}
+
+// generate asks the AI for a post from text, redacted by red, and holds it
+// to the post rules. A post that breaks them is regenerated with feedback
+// while reprompts remain, and truncated if it is still too long after that.
+func (l *LinkedInService) generate(ctx context.Context, text string, red *redaction, opts ai.Options) (string, error) {
+	post, err := l.ai.Transform(ctx, text, opts)
+	if err != nil {
 		return "", err
 	}
-	return out, nil
+	post = l.rules.clean(red.restore(post))
+
+	requested := opts.MaxLength
+	if requested <= 0 {
+		requested = ai.DefaultMaxLength
+	}
+	limit := l.rules.limit(requested)
+	logger := logging.FromContext(ctx)
+	for i := 0; i < l.rules.Reprompts; i++ {
+		problems := l.rules.violations(post, limit)
+		if len(problems) == 0 {
+			return post, nil
+		}
+		logger.Info("regenerating post that breaks the post rules", "problems", problems)
+		retry := opts
+		retry.Feedback = problems
+		next, err := l.ai.Transform(ctx, text, retry)
+		if err != nil {
+			// The draft can still be truncated into shape.
+			logger.Warn("regenerating post failed", "err", err)
+			break
+		}
+		post = l.rules.clean(red.restore(next))
+	}
+	if n := utf8.RuneCountInString(post); n > limit {
+		logger.Info("truncating post over the length limit", "length", n, "limit", limit)
+		post = truncate(post, limit)
+	}
+	return post, nil
+}
+
+// promptOptions derives the prompt personalization from the user's profile
+// and the per-request options.
+func (l *LinkedInService) promptOptions(ctx context.Context, userID uuid.UUID, topts TransformOptions) (ai.Options, error) {
+	var opts ai.Options
+	if l.users != nil {
+		u, err := l.users.FindByID(ctx, userID)
+		if err != nil {
+			return ai.Options{}, err
+		}
+		opts = ai.Options{
+			AuthorName: u.DisplayName,
+			JobTitle:   u.JobTitle,
+			Industry:   u.Industry,
+			Language:   u.PreferredLanguage,
+			Style:      u.DefaultStyle,
+			Hashtags:   u.DefaultHashtags,
+			Signature:  u.Signature,
+			MaxLength:  u.MaxLength,
+		}
+	}
+
+	if topts.Language != "" {
+		lang, err := canonicalLanguage(topts.Language)
+		if err != nil {
+			return ai.Options{}, err
+		}
+		opts.Language = lang
+	}
+
+	if topts.VoiceProfileID != uuid.Nil {
+		if l.voices == nil {
+			return ai.Options{}, ErrVoiceProfileNotFound
+		}
+		vp, err := findVoice(ctx, l.voices, userID, topts.VoiceProfileID)
+		if err != nil {
+			return ai.Options{}, err
+		}
+		opts.Voice = ai.Voice{Guidance: vp.Guidance, Examples: vp.Examples}
+	}
+
+	// Styles being rolled out reach users who have not picked one.
+	if v := flags.Variant(ctx, flags.PostStyle); opts.Style == "" && v != flags.DefaultStyle {
+		opts.Style = v
+	}
+	opts.Tier = flags.Variant(ctx, flags.UserTier)
+
+	if l.settings != nil {
+		opts.SystemPrompt = l.settings.Current().Values.SystemPrompt
+	}
+	return opts, nil
+}
+
+func (l *LinkedInService) History(ctx context.Context, userID uuid.UUID, page, pageSize int, filter repository.PostFilter) ([]model.LinkedInPost, error) {
+	lang, err := canonicalLanguage(filter.Language)
+	if err != nil {
+		return nil, err
+	}
+	filter.Language = lang
+	return l.posts.ListByUser(ctx, userID, page, pageSize, filter)
+}
+
+// maxTranslations caps how many languages one Translate call may request.
+const maxTranslations = 5
+
+// Translate produces localized variants of one of the user's stored posts,
+// one per language, each saved and linked to the original.
+func (l *LinkedInService) Translate(ctx context.Context, userID, postID uuid.UUID, languages []string) ([]model.LinkedInPost, error) {
+	var targets []string
+	seen := map[string]bool{}
+	for _, raw := range languages {
+		lang, err := canonicalLanguage(raw)
+		if err != nil {
+			return nil, err
+		}
+		if lang == "" || seen[lang] {
+			continue
+		}
+		seen[lang] = true
+		targets = append(targets, lang)
+	}
+	if len(targets) == 0 || len(targets) > maxTranslations {
+		return nil, fmt.Errorf("%w: request between 1 and %d languages", ErrInvalidLanguage, maxTranslations)
+	}
+
+	source, err := l.posts.FindByID(ctx, userID, postID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPostNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := l.lengthLimit(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	variants := make([]model.LinkedInPost, 0, len(targets))
+	for _, lang := range targets {
+		safeText := source.OutputText
+		red, err := protect(ctx, l.settings, &safeText)
+		if err != nil {
+			return nil, err
+		}
+		aiCtx, served := ai.TrackServed(ctx)
+		out, err := l.ai.Translate(aiCtx, safeText, lang)
+		if err != nil {
+			return nil, err
+		}
+		// Translations are held to the same rules as the posts they come
+		// from, but are not regenerated: they keep their source's wording.
+		out = l.rules.fit(red.restore(out), limit)
+		findings, err := l.moderate(moderation.WithMask(ctx, red.hide), moderation.Output, out)
+		if err != nil {
+			return nil, err
+		}
+		score := quality.Analyze(out, lang)
+		variant := model.LinkedInPost{
+			ID:           uuid.New(),
+			UserID:       userID,
+			InputText:    source.OutputText,
+			OutputText:   out,
+			Language:     lang,
+			SourcePostID: source.ID,
+			Provider:     served.Provider,
+			Model:        served.Model,
+			// Translations inherit the moderation of their source post
+			// and add their own output findings.
+			ModerationFlags: append(slices.Clone(source.ModerationFlags), moderationFlags(findings)...),
+			Quality:         &score,
+		}
+		if err := l.posts.Save(ctx, &variant); err != nil {
+			return nil, err
+		}
+		variants = append(variants, variant)
+	}
+	return variants, nil
+}
+
+// moderatedInput is what input moderation sees: the text and what the
+// author wrote into their profile and voice, all of which reach the model.
+func moderatedInput(text string, opts ai.Options) string {
+	parts := []string{text}
+	for _, field := range append([]string{opts.AuthorName, opts.JobTitle, opts.Industry, opts.Style, opts.Signature, opts.Voice.Guidance}, opts.Voice.Examples...) {
+		if field != "" {
+			parts = append(parts, field)
+		}
+	}
+	return strings.Join(parts, "\n\n")
 }
 
-func (l *LinkedInService) History(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]model.LinkedInPost, error) {
-	return l.posts.ListByUser(ctx, userID, page, pageSize)
+// lengthLimit is the length the user's posts must keep to when no length
+// is requested.
+func (l *LinkedInService) lengthLimit(ctx context.Context, userID uuid.UUID) (int, error) {
+	requested := ai.DefaultMaxLength
+	if l.users != nil {
+		u, err := l.users.FindByID(ctx, userID)
+		if err != nil {
+			return 0, err
+		}
+		if u.MaxLength > 0 {
+			requested = u.MaxLength
+		}
+	}
+	return l.rules.limit(requested), nil
+}
+
+// Export streams the user's full post history, oldest first, to fn.
+func (l *LinkedInService) Export(ctx context.Context, userID uuid.UUID, fn func(*model.LinkedInPost) error) error {
+	return l.posts.ForEachByUser(ctx, userID, fn)
+}
+
+// Import restores previously exported posts into the user's history.
+// Posts are re-owned by userID; IDs that already exist (in the batch or in
+// the database) are skipped. It returns the number of posts written.
+func (l *LinkedInService) Import(ctx context.Context, userID uuid.UUID, posts []model.LinkedInPost) (int, error) {
+	seen := make(map[uuid.UUID]struct{}, len(posts))
+	batch := make([]model.LinkedInPost, 0, len(posts))
+	for i, p := range posts {
+		lang, err := canonicalLanguage(p.Language)
+		if err != nil {
+			return 0, fmt.Errorf("post %d: %w", i+1, err)
+		}
+		p.Language = lang
+		if p.ID == uuid.Nil {
+			p.ID = uuid.New()
+		}
+		if _, dup := seen[p.ID]; dup {
+			continue
+		}
+		seen[p.ID] = struct{}{}
+		p.UserID = userID
+		if p.ModerationFlags == nil {
+			p.ModerationFlags = moderationFlags()
+		}
+		// Scores are always recomputed; an imported one could be made up.
+		score := quality.Analyze(p.OutputText, p.Language)
+		p.Quality = &score
+		batch = append(batch, p)
+	}
+	return l.posts.Import(ctx, batch)
+}
+
+func (l *LinkedInService) CachedUsers() []uuid.UUID {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	ids := make([]uuid.UUID, 0, len(l.cache))
+	for id := range l.cache {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (l *LinkedInService) Forget(userIDs ...uuid.UUID) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, id := range userIDs {
+		delete(l.cache, id)
+	}
+	l.stats.CacheSize(l.cacheLen())
+}
+
+// cacheLen counts cached outputs. Callers must hold l.mu.
+func (l *LinkedInService) cacheLen() int {
+	n := 0
+	for _, outputs := range l.cache {
+		n += len(outputs)
+	}
+	return n
 }
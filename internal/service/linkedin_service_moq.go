@@ -7,6 +7,7 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
 	"sync"
 )
 
@@ -20,12 +21,27 @@ var _ LinkedInServiceInteractor = &LinkedInServiceInteractorMock{}
 //
 //		// make and configure a mocked LinkedInServiceInteractor
 //		mockedLinkedInServiceInteractor := &LinkedInServiceInteractorMock{
-//			HistoryFunc: func(ctx context.Context, userID uuid.UUID, page int, pageSize int) ([]model.LinkedInPost, error) {
+//			CachedUsersFunc: func() []uuid.UUID {
+//				panic("mock out the CachedUsers method")
+//			},
+//			ExportFunc: func(ctx context.Context, userID uuid.UUID, fn func(*model.LinkedInPost) error) error {
+//				panic("mock out the Export method")
+//			},
+//			ForgetFunc: func(userIDs ...uuid.UUID)  {
+//				panic("mock out the Forget method")
+//			},
+//			HistoryFunc: func(ctx context.Context, userID uuid.UUID, page int, pageSize int, filter repository.PostFilter) ([]model.LinkedInPost, error) {
 //				panic("mock out the History method")
 //			},
-//			TransformFunc: func(ctx context.Context, userID uuid.UUID, text string) (string, error) {
+//			ImportFunc: func(ctx context.Context, userID uuid.UUID, posts []model.LinkedInPost) (int, error) {
+//				panic("mock out the Import method")
+//			},
+//			TransformFunc: func(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (*model.LinkedInPost, error) {
 //				panic("mock out the Transform method")
 //			},
+//			TranslateFunc: func(ctx context.Context, userID uuid.UUID, postID uuid.UUID, languages []string) ([]model.LinkedInPost, error) {
+//				panic("mock out the Translate method")
+//			},
 //		}
 //
 //		// use mockedLinkedInServiceInteractor in code that requires LinkedInServiceInteractor
@@ -33,14 +49,46 @@ var _ LinkedInServiceInteractor = &LinkedInServiceInteractorMock{}
 //
 //	}
 type LinkedInServiceInteractorMock struct {
+	// CachedUsersFunc mocks the CachedUsers method.
+	CachedUsersFunc func() []uuid.UUID
+
+	// ExportFunc mocks the Export method.
+	ExportFunc func(ctx context.Context, userID uuid.UUID, fn func(*model.LinkedInPost) error) error
+
+	// ForgetFunc mocks the Forget method.
+	ForgetFunc func(userIDs ...uuid.UUID)
+
 	// HistoryFunc mocks the History method.
-	HistoryFunc func(ctx context.Context, userID uuid.UUID, page int, pageSize int) ([]model.LinkedInPost, error)
+	HistoryFunc func(ctx context.Context, userID uuid.UUID, page int, pageSize int, filter repository.PostFilter) ([]model.LinkedInPost, error)
+
+	// ImportFunc mocks the Import method.
+	ImportFunc func(ctx context.Context, userID uuid.UUID, posts []model.LinkedInPost) (int, error)
 
 	// TransformFunc mocks the Transform method.
-	TransformFunc func(ctx context.Context, userID uuid.UUID, text string) (string, error)
+	TransformFunc func(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (*model.LinkedInPost, error)
+
+	// TranslateFunc mocks the Translate method.
+	TranslateFunc func(ctx context.Context, userID uuid.UUID, postID uuid.UUID, languages []string) ([]model.LinkedInPost, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// CachedUsers holds details about calls to the CachedUsers method.
+		CachedUsers []struct {
+		}
+		// Export holds details about calls to the Export method.
+		Export []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Fn is the fn argument value.
+			Fn func(*model.LinkedInPost) error
+		}
+		// Forget holds details about calls to the Forget method.
+		Forget []struct {
+			// UserIDs is the userIDs argument value.
+			UserIDs []uuid.UUID
+		}
 		// History holds details about calls to the History method.
 		History []struct {
 			// Ctx is the ctx argument value.
@@ -51,6 +99,17 @@ type LinkedInServiceInteractorMock struct {
 			Page int
 			// PageSize is the pageSize argument value.
 			PageSize int
+			// Filter is the filter argument value.
+			Filter repository.PostFilter
+		}
+		// Import holds details about calls to the Import method.
+		Import []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Posts is the posts argument value.
+			Posts []model.LinkedInPost
 		}
 		// Transform holds details about calls to the Transform method.
 		Transform []struct {
@@ -60,14 +119,131 @@ type LinkedInServiceInteractorMock struct {
 			UserID uuid.UUID
 			// Text is the text argument value.
 			Text string
+			// Opts is the opts argument value.
+			Opts TransformOptions
 		}
+		// Translate holds details about calls to the Translate method.
+		Translate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// PostID is the postID argument value.
+			PostID uuid.UUID
+			// Languages is the languages argument value.
+			Languages []string
+		}
+	}
+	lockCachedUsers sync.RWMutex
+	lockExport      sync.RWMutex
+	lockForget      sync.RWMutex
+	lockHistory     sync.RWMutex
+	lockImport      sync.RWMutex
+	lockTransform   sync.RWMutex
+	lockTranslate   sync.RWMutex
+}
+
+// CachedUsers calls CachedUsersFunc.
+func (mock *LinkedInServiceInteractorMock) CachedUsers() []uuid.UUID {
+	if mock.CachedUsersFunc == nil {
+		panic("LinkedInServiceInteractorMock.CachedUsersFunc: method is nil but LinkedInServiceInteractor.CachedUsers was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockCachedUsers.Lock()
+	mock.calls.CachedUsers = append(mock.calls.CachedUsers, callInfo)
+	mock.lockCachedUsers.Unlock()
+	return mock.CachedUsersFunc()
+}
+
+// CachedUsersCalls gets all the calls that were made to CachedUsers.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.CachedUsersCalls())
+func (mock *LinkedInServiceInteractorMock) CachedUsersCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockCachedUsers.RLock()
+	calls = mock.calls.CachedUsers
+	mock.lockCachedUsers.RUnlock()
+	return calls
+}
+
+// Export calls ExportFunc.
+func (mock *LinkedInServiceInteractorMock) Export(ctx context.Context, userID uuid.UUID, fn func(*model.LinkedInPost) error) error {
+	if mock.ExportFunc == nil {
+		panic("LinkedInServiceInteractorMock.ExportFunc: method is nil but LinkedInServiceInteractor.Export was just called")
 	}
-	lockHistory   sync.RWMutex
-	lockTransform sync.RWMutex
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Fn     func(*model.LinkedInPost) error
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Fn:     fn,
+	}
+	mock.lockExport.Lock()
+	mock.calls.Export = append(mock.calls.Export, callInfo)
+	mock.lockExport.Unlock()
+	return mock.ExportFunc(ctx, userID, fn)
+}
+
+// ExportCalls gets all the calls that were made to Export.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.ExportCalls())
+func (mock *LinkedInServiceInteractorMock) ExportCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Fn     func(*model.LinkedInPost) error
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Fn     func(*model.LinkedInPost) error
+	}
+	mock.lockExport.RLock()
+	calls = mock.calls.Export
+	mock.lockExport.RUnlock()
+	return calls
+}
+
+// Forget calls ForgetFunc.
+func (mock *LinkedInServiceInteractorMock) Forget(userIDs ...uuid.UUID) {
+	if mock.ForgetFunc == nil {
+		panic("LinkedInServiceInteractorMock.ForgetFunc: method is nil but LinkedInServiceInteractor.Forget was just called")
+	}
+	callInfo := struct {
+		UserIDs []uuid.UUID
+	}{
+		UserIDs: userIDs,
+	}
+	mock.lockForget.Lock()
+	mock.calls.Forget = append(mock.calls.Forget, callInfo)
+	mock.lockForget.Unlock()
+	mock.ForgetFunc(userIDs...)
+}
+
+// ForgetCalls gets all the calls that were made to Forget.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.ForgetCalls())
+func (mock *LinkedInServiceInteractorMock) ForgetCalls() []struct {
+	UserIDs []uuid.UUID
+} {
+	var calls []struct {
+		UserIDs []uuid.UUID
+	}
+	mock.lockForget.RLock()
+	calls = mock.calls.Forget
+	mock.lockForget.RUnlock()
+	return calls
 }
 
 // History calls HistoryFunc.
-func (mock *LinkedInServiceInteractorMock) History(ctx context.Context, userID uuid.UUID, page int, pageSize int) ([]model.LinkedInPost, error) {
+func (mock *LinkedInServiceInteractorMock) History(ctx context.Context, userID uuid.UUID, page int, pageSize int, filter repository.PostFilter) ([]model.LinkedInPost, error) {
 	if mock.HistoryFunc == nil {
 		panic("LinkedInServiceInteractorMock.HistoryFunc: method is nil but LinkedInServiceInteractor.History was just called")
 	}
@@ -76,16 +252,18 @@ func (mock *LinkedInServiceInteractorMock) History(ctx context.Context, userID u
 		UserID   uuid.UUID
 		Page     int
 		PageSize int
+		Filter   repository.PostFilter
 	}{
 		Ctx:      ctx,
 		UserID:   userID,
 		Page:     page,
 		PageSize: pageSize,
+		Filter:   filter,
 	}
 	mock.lockHistory.Lock()
 	mock.calls.History = append(mock.calls.History, callInfo)
 	mock.lockHistory.Unlock()
-	return mock.HistoryFunc(ctx, userID, page, pageSize)
+	return mock.HistoryFunc(ctx, userID, page, pageSize, filter)
 }
 
 // HistoryCalls gets all the calls that were made to History.
@@ -97,12 +275,14 @@ func (mock *LinkedInServiceInteractorMock) HistoryCalls() []struct {
 	UserID   uuid.UUID
 	Page     int
 	PageSize int
+	Filter   repository.PostFilter
 } {
 	var calls []struct {
 		Ctx      context.Context
 		UserID   uuid.UUID
 		Page     int
 		PageSize int
+		Filter   repository.PostFilter
 	}
 	mock.lockHistory.RLock()
 	calls = mock.calls.History
@@ -110,8 +290,48 @@ func (mock *LinkedInServiceInteractorMock) HistoryCalls() []struct {
 	return calls
 }
 
+// Import calls ImportFunc.
+func (mock *LinkedInServiceInteractorMock) Import(ctx context.Context, userID uuid.UUID, posts []model.LinkedInPost) (int, error) {
+	if mock.ImportFunc == nil {
+		panic("LinkedInServiceInteractorMock.ImportFunc: method is nil but LinkedInServiceInteractor.Import was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Posts  []model.LinkedInPost
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Posts:  posts,
+	}
+	mock.lockImport.Lock()
+	mock.calls.Import = append(mock.calls.Import, callInfo)
+	mock.lockImport.Unlock()
+	return mock.ImportFunc(ctx, userID, posts)
+}
+
+// ImportCalls gets all the calls that were made to Import.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.ImportCalls())
+func (mock *LinkedInServiceInteractorMock) ImportCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Posts  []model.LinkedInPost
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Posts  []model.LinkedInPost
+	}
+	mock.lockImport.RLock()
+	calls = mock.calls.Import
+	mock.lockImport.RUnlock()
+	return calls
+}
+
 // Transform calls TransformFunc.
-func (mock *LinkedInServiceInteractorMock) Transform(ctx context.Context, userID uuid.UUID, text string) (string, error) {
+func (mock *LinkedInServiceInteractorMock) Transform(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (*model.LinkedInPost, error) {
 	if mock.TransformFunc == nil {
 		panic("LinkedInServiceInteractorMock.TransformFunc: method is nil but LinkedInServiceInteractor.Transform was just called")
 	}
@@ -119,15 +339,17 @@ func (mock *LinkedInServiceInteractorMock) Transform(ctx context.Context, userID
 		Ctx    context.Context
 		UserID uuid.UUID
 		Text   string
+		Opts   TransformOptions
 	}{
 		Ctx:    ctx,
 		UserID: userID,
 		Text:   text,
+		Opts:   opts,
 	}
 	mock.lockTransform.Lock()
 	mock.calls.Transform = append(mock.calls.Transform, callInfo)
 	mock.lockTransform.Unlock()
-	return mock.TransformFunc(ctx, userID, text)
+	return mock.TransformFunc(ctx, userID, text, opts)
 }
 
 // TransformCalls gets all the calls that were made to Transform.
@@ -138,14 +360,60 @@ func (mock *LinkedInServiceInteractorMock) TransformCalls() []struct {
 	Ctx    context.Context
 	UserID uuid.UUID
 	Text   string
+	Opts   TransformOptions
 } {
 	var calls []struct {
 		Ctx    context.Context
 		UserID uuid.UUID
 		Text   string
+		Opts   TransformOptions
 	}
 	mock.lockTransform.RLock()
 	calls = mock.calls.Transform
 	mock.lockTransform.RUnlock()
 	return calls
 }
+
+// Translate calls TranslateFunc.
+func (mock *LinkedInServiceInteractorMock) Translate(ctx context.Context, userID uuid.UUID, postID uuid.UUID, languages []string) ([]model.LinkedInPost, error) {
+	if mock.TranslateFunc == nil {
+		panic("LinkedInServiceInteractorMock.TranslateFunc: method is nil but LinkedInServiceInteractor.Translate was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		UserID    uuid.UUID
+		PostID    uuid.UUID
+		Languages []string
+	}{
+		Ctx:       ctx,
+		UserID:    userID,
+		PostID:    postID,
+		Languages: languages,
+	}
+	mock.lockTranslate.Lock()
+	mock.calls.Translate = append(mock.calls.Translate, callInfo)
+	mock.lockTranslate.Unlock()
+	return mock.TranslateFunc(ctx, userID, postID, languages)
+}
+
+// TranslateCalls gets all the calls that were made to Translate.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.TranslateCalls())
+func (mock *LinkedInServiceInteractorMock) TranslateCalls() []struct {
+	Ctx       context.Context
+	UserID    uuid.UUID
+	PostID    uuid.UUID
+	Languages []string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		UserID    uuid.UUID
+		PostID    uuid.UUID
+		Languages []string
+	}
+	mock.lockTranslate.RLock()
+	calls = mock.calls.Translate
+	mock.lockTranslate.RUnlock()
+	return calls
+}
@@ -3,6 +3,7 @@ package service_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 	"time"
@@ -12,14 +13,16 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/flags"
 	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/moderation"
 	"github.com/you/linkedinify/internal/repository"
 	"github.com/you/linkedinify/internal/service"
 )
 
 func TestLinkedInService_Transform_Success(t *testing.T) {
 	mockAIClient := &ai.ClientMock{
-		TransformFunc: func(ctx context.Context, text string) (string, error) {
+		TransformFunc: func(ctx context.Context, text string, opts ai.Options) (string, error) {
 			assert.Equal(t, "original text", text)
 			return "ai transformed text", nil
 		},
@@ -41,26 +44,48 @@ func TestLinkedInService_Transform_Success(t *testing.T) {
 	userID, _ := uuid.Parse("11111111-1111-1111-1111-111111111111")
 	inputText := "original text"
 
-	transformedText, err := liSvc.Transform(context.Background(), userID, inputText)
+	transformedText, err := liSvc.Transform(context.Background(), userID, inputText, service.TransformOptions{})
 	require.NoError(t, err)
-	assert.Equal(t, "ai transformed text", transformedText)
+	assert.Equal(t, "ai transformed text", transformedText.OutputText)
 
 	assert.Len(t, mockAIClient.TransformCalls(), 1, "Expected AIClient.Transform to be called once on first call (cache miss)")
 	assert.Len(t, mockPostRepo.SaveCalls(), 1, "Expected PostRepository.Save to be called once on first call")
 
 	// Second call with the same input - should be a cache hit
-	transformedTextCached, errCached := liSvc.Transform(context.Background(), userID, inputText)
+	transformedTextCached, errCached := liSvc.Transform(context.Background(), userID, inputText, service.TransformOptions{})
 	require.NoError(t, errCached)
-	assert.Equal(t, "ai transformed text", transformedTextCached)
+	assert.Equal(t, "ai transformed text", transformedTextCached.OutputText)
 
 	assert.Len(t, mockAIClient.TransformCalls(), 1, "Expected AIClient.Transform to still be called only once (cache hit)")
 	assert.Len(t, mockPostRepo.SaveCalls(), 2, "Expected PostRepository.Save to be called twice (once for cache miss, once for cache hit)")
 }
 
+func TestLinkedInService_ForgetUsers(t *testing.T) {
+	mockAIClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, text string, opts ai.Options) (string, error) { return "post", nil },
+	}
+	mockPostRepo := &repository.PostRepositoryMock{SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil }}
+	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo)
+	alice, bob := uuid.New(), uuid.New()
+
+	for _, id := range []uuid.UUID{alice, bob, alice} {
+		_, err := liSvc.Transform(context.Background(), id, "same input", service.TransformOptions{})
+		require.NoError(t, err)
+	}
+	assert.Len(t, mockAIClient.TransformCalls(), 2, "users do not share cached posts")
+	assert.ElementsMatch(t, []uuid.UUID{alice, bob}, liSvc.CachedUsers())
+
+	liSvc.Forget(alice)
+	assert.Equal(t, []uuid.UUID{bob}, liSvc.CachedUsers())
+	_, err := liSvc.Transform(context.Background(), bob, "same input", service.TransformOptions{})
+	require.NoError(t, err)
+	assert.Len(t, mockAIClient.TransformCalls(), 2)
+}
+
 func TestLinkedInService_Transform_AIClientError(t *testing.T) {
 	aiError := errors.New("ai client failed")
 	mockAIClient := &ai.ClientMock{
-		TransformFunc: func(ctx context.Context, text string) (string, error) {
+		TransformFunc: func(ctx context.Context, text string, opts ai.Options) (string, error) {
 			return "", aiError
 		},
 	}
@@ -69,7 +94,7 @@ func TestLinkedInService_Transform_AIClientError(t *testing.T) {
 	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo)
 	userID, _ := uuid.Parse("test-user-id")
 
-	_, err := liSvc.Transform(context.Background(), userID, "some text")
+	_, err := liSvc.Transform(context.Background(), userID, "some text", service.TransformOptions{})
 	require.Error(t, err)
 	assert.Equal(t, aiError, err)
 
@@ -80,7 +105,7 @@ func TestLinkedInService_Transform_AIClientError(t *testing.T) {
 func TestLinkedInService_Transform_RepositorySaveError(t *testing.T) {
 	repoSaveError := errors.New("failed to save post")
 	mockAIClient := &ai.ClientMock{
-		TransformFunc: func(ctx context.Context, text string) (string, error) {
+		TransformFunc: func(ctx context.Context, text string, opts ai.Options) (string, error) {
 			return "transformed text", nil
 		},
 	}
@@ -93,7 +118,7 @@ func TestLinkedInService_Transform_RepositorySaveError(t *testing.T) {
 	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo)
 	userID, _ := uuid.Parse("test-user-id")
 
-	_, err := liSvc.Transform(context.Background(), userID, "some text")
+	_, err := liSvc.Transform(context.Background(), userID, "some text", service.TransformOptions{})
 	require.Error(t, err)
 	assert.Equal(t, repoSaveError, err)
 
@@ -109,7 +134,7 @@ func TestLinkedInService_History_Success(t *testing.T) {
 	}
 
 	mockPostRepo := &repository.PostRepositoryMock{
-		ListByUserFunc: func(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]model.LinkedInPost, error) {
+		ListByUserFunc: func(ctx context.Context, userID uuid.UUID, page, pageSize int, filter repository.PostFilter) ([]model.LinkedInPost, error) {
 			assert.Equal(t, testUserID, userID)
 			assert.Equal(t, 1, page)
 			assert.Equal(t, 10, pageSize)
@@ -120,7 +145,7 @@ func TestLinkedInService_History_Success(t *testing.T) {
 
 	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo)
 
-	posts, err := liSvc.History(context.Background(), testUserID, 1, 10)
+	posts, err := liSvc.History(context.Background(), testUserID, 1, 10, repository.PostFilter{})
 	require.NoError(t, err)
 	assert.Equal(t, expectedPosts, posts)
 	assert.Len(t, mockPostRepo.ListByUserCalls(), 1)
@@ -131,7 +156,7 @@ func TestLinkedInService_History_RepositoryError(t *testing.T) {
 	testUserID, _ := uuid.Parse("history-user-id-err")
 
 	mockPostRepo := &repository.PostRepositoryMock{
-		ListByUserFunc: func(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]model.LinkedInPost, error) {
+		ListByUserFunc: func(ctx context.Context, userID uuid.UUID, page, pageSize int, filter repository.PostFilter) ([]model.LinkedInPost, error) {
 			return nil, repoListError
 		},
 	}
@@ -139,8 +164,296 @@ func TestLinkedInService_History_RepositoryError(t *testing.T) {
 
 	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo)
 
-	_, err := liSvc.History(context.Background(), testUserID, 1, 10)
+	_, err := liSvc.History(context.Background(), testUserID, 1, 10, repository.PostFilter{})
 	require.Error(t, err)
 	assert.Equal(t, repoListError, err)
 	assert.Len(t, mockPostRepo.ListByUserCalls(), 1)
 }
+
+func TestLinkedInService_Import_ReownsAndDeduplicates(t *testing.T) {
+	testUserID := uuid.New()
+	otherUserID := uuid.New()
+	dupID := uuid.New()
+
+	mockPostRepo := &repository.PostRepositoryMock{
+		ImportFunc: func(ctx context.Context, posts []model.LinkedInPost) (int, error) {
+			require.Len(t, posts, 2)
+			assert.Equal(t, dupID, posts[0].ID)
+			assert.NotEqual(t, uuid.Nil, posts[1].ID)
+			for _, p := range posts {
+				assert.Equal(t, testUserID, p.UserID)
+			}
+			return 1, nil
+		},
+	}
+	liSvc := service.NewLinkedIn(&ai.ClientMock{}, mockPostRepo)
+
+	imported, err := liSvc.Import(context.Background(), testUserID, []model.LinkedInPost{
+		{ID: dupID, UserID: otherUserID, InputText: "in1", OutputText: "out1"},
+		{ID: dupID, InputText: "in1 again", OutputText: "out1 again"},
+		{InputText: "in2", OutputText: "out2"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, imported)
+	assert.Len(t, mockPostRepo.ImportCalls(), 1)
+}
+
+func TestLinkedInService_Import_CanonicalizesLanguage(t *testing.T) {
+	mockPostRepo := &repository.PostRepositoryMock{
+		ImportFunc: func(ctx context.Context, posts []model.LinkedInPost) (int, error) {
+			require.Len(t, posts, 2)
+			assert.Equal(t, "en-US", posts[0].Language)
+			assert.Equal(t, "", posts[1].Language)
+			return len(posts), nil
+		},
+	}
+	liSvc := service.NewLinkedIn(&ai.ClientMock{}, mockPostRepo)
+
+	_, err := liSvc.Import(context.Background(), uuid.New(), []model.LinkedInPost{
+		{OutputText: "Hello", Language: "EN_us"},
+		{OutputText: "Hi"},
+	})
+	require.NoError(t, err)
+
+	_, err = liSvc.Import(context.Background(), uuid.New(), []model.LinkedInPost{
+		{OutputText: "Hello", Language: "en"},
+		{OutputText: "Hi", Language: "english please"},
+	})
+	assert.ErrorIs(t, err, service.ErrInvalidLanguage)
+	assert.Contains(t, err.Error(), "post 2")
+	assert.Len(t, mockPostRepo.ImportCalls(), 1)
+}
+
+func TestLinkedInService_Transform_UsesProfileDefaults(t *testing.T) {
+	userID := uuid.New()
+	mockUserRepo := &repository.UserRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+			assert.Equal(t, userID, id)
+			return &model.User{ID: id, DisplayName: "Ada", Industry: "fintech", DefaultHashtags: []string{"ai"}, MaxLength: 600}, nil
+		},
+	}
+	mockAIClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, text string, opts ai.Options) (string, error) {
+			return "post for " + opts.AuthorName, nil
+		},
+	}
+	mockPostRepo := &repository.PostRepositoryMock{
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+
+	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo, service.WithProfiles(mockUserRepo))
+
+	out, err := liSvc.Transform(context.Background(), userID, "text", service.TransformOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "post for Ada", out.OutputText)
+	require.Len(t, mockAIClient.TransformCalls(), 1)
+	assert.Equal(t, ai.Options{AuthorName: "Ada", Industry: "fintech", Hashtags: []string{"ai"}, MaxLength: 600}, mockAIClient.TransformCalls()[0].Opts)
+}
+
+func TestLinkedInService_Translate_SavesLinkedVariants(t *testing.T) {
+	userID := uuid.New()
+	sourceID := uuid.New()
+	mockPostRepo := &repository.PostRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, uid, id uuid.UUID) (*model.LinkedInPost, error) {
+			assert.Equal(t, userID, uid)
+			return &model.LinkedInPost{ID: id, UserID: uid, InputText: "in", OutputText: "Hello network!", Language: "en"}, nil
+		},
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	mockAIClient := &ai.ClientMock{
+		TranslateFunc: func(ctx context.Context, text, language string) (string, error) {
+			assert.Equal(t, "Hello network!", text)
+			return "[" + language + "] " + text, nil
+		},
+	}
+	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo)
+
+	variants, err := liSvc.Translate(context.Background(), userID, sourceID, []string{"DE", "pt_br", "de"})
+	require.NoError(t, err)
+	require.Len(t, variants, 2, "duplicate languages are translated once")
+	assert.Equal(t, "de", variants[0].Language)
+	assert.Equal(t, "pt-BR", variants[1].Language)
+	for _, v := range variants {
+		assert.Equal(t, sourceID, v.SourcePostID)
+		assert.Equal(t, userID, v.UserID)
+	}
+	assert.Equal(t, "[pt-BR] Hello network!", variants[1].OutputText)
+	assert.Len(t, mockPostRepo.SaveCalls(), 2)
+}
+
+func TestLinkedInService_Translate_UnknownPost(t *testing.T) {
+	mockPostRepo := &repository.PostRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, uid, id uuid.UUID) (*model.LinkedInPost, error) {
+			return nil, sql.ErrNoRows
+		},
+	}
+	liSvc := service.NewLinkedIn(&ai.ClientMock{}, mockPostRepo)
+
+	_, err := liSvc.Translate(context.Background(), uuid.New(), uuid.New(), []string{"fr"})
+	assert.ErrorIs(t, err, service.ErrPostNotFound)
+}
+
+func TestLinkedInService_Transform_LanguageOverridesProfile(t *testing.T) {
+	mockUserRepo := &repository.UserRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+			return &model.User{ID: id, PreferredLanguage: "en"}, nil
+		},
+	}
+	mockAIClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, text string, opts ai.Options) (string, error) {
+			return "bonjour", nil
+		},
+	}
+	var saved *model.LinkedInPost
+	mockPostRepo := &repository.PostRepositoryMock{
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { saved = p; return nil },
+	}
+	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo, service.WithProfiles(mockUserRepo))
+
+	_, err := liSvc.Transform(context.Background(), uuid.New(), "hello", service.TransformOptions{Language: "fr-ca"})
+	require.NoError(t, err)
+	assert.Equal(t, "fr-CA", mockAIClient.TransformCalls()[0].Opts.Language)
+	require.NotNil(t, saved)
+	assert.Equal(t, "fr-CA", saved.Language)
+
+	_, err = liSvc.Transform(context.Background(), uuid.New(), "hello", service.TransformOptions{Language: "not a tag"})
+	assert.ErrorIs(t, err, service.ErrInvalidLanguage)
+}
+
+func TestLinkedInService_Transform_RecordsProvider(t *testing.T) {
+	userID := uuid.New()
+	set := flags.Set{flags.UserTier: {
+		Key: flags.UserTier, Enabled: true, Variants: []string{"free", "pro"}, DefaultVariant: "free",
+		Rules: []model.FlagRule{{UserIDs: []uuid.UUID{userID}, Percentage: 100, Variant: "pro"}},
+	}}
+	ctx := flags.WithSet(context.Background(), set, func(context.Context) flags.Subject { return flags.Subject{UserID: userID} })
+
+	pro := &ai.ClientMock{TransformFunc: func(ctx context.Context, text string, opts ai.Options) (string, error) {
+		return "pro post", nil
+	}}
+	aiClient := ai.NewRouting([]ai.Route{
+		{Tiers: []string{"pro"}, Backends: []ai.Backend{{Provider: "openai", Model: "gpt-4o", Client: pro}}},
+		{Backends: []ai.Backend{{Provider: "openai", Model: "gpt-4o-mini"}}},
+	})
+	var saved []model.LinkedInPost
+	posts := &repository.PostRepositoryMock{SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error {
+		saved = append(saved, *p)
+		return nil
+	}}
+	svc := service.NewLinkedIn(aiClient, posts)
+
+	for range 2 {
+		out, err := svc.Transform(ctx, userID, "hello", service.TransformOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "pro post", out.OutputText)
+	}
+
+	require.Len(t, saved, 2)
+	for _, p := range saved {
+		assert.Equal(t, "openai", p.Provider, "cache hits keep the provider of the cached post")
+		assert.Equal(t, "gpt-4o", p.Model)
+	}
+	assert.Len(t, pro.TransformCalls(), 1)
+}
+
+func TestLinkedInService_Transform_Moderation(t *testing.T) {
+	moderator := moderation.NewRules(moderation.Rules{
+		Blocklist:       []string{"project falcon"},
+		ProfanityAction: moderation.Flag,
+		PIIAction:       moderation.Flag,
+	})
+	aiClient := &ai.ClientMock{TransformFunc: func(ctx context.Context, text string, opts ai.Options) (string, error) {
+		if text == "leak it" {
+			return "Big reveal: Project Falcon ships soon!", nil
+		}
+		return "Write me at jane@example.com!", nil
+	}}
+	var saved []model.LinkedInPost
+	posts := &repository.PostRepositoryMock{SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error {
+		saved = append(saved, *p)
+		return nil
+	}}
+	svc := service.NewLinkedIn(aiClient, posts, service.WithModerator(moderator))
+	ctx := context.Background()
+
+	// Blocked input never reaches the AI.
+	_, err := svc.Transform(ctx, uuid.New(), "all about Project Falcon", service.TransformOptions{})
+	var rejected *service.RejectedError
+	require.ErrorAs(t, err, &rejected)
+	assert.ErrorIs(t, err, service.ErrContentRejected)
+	assert.Equal(t, []moderation.Finding{{Stage: moderation.Input, Category: moderation.CategoryBlocklist, Detail: "project falcon", Action: moderation.Block}}, rejected.Findings)
+	assert.Empty(t, aiClient.TransformCalls())
+
+	// Blocked output is neither saved nor cached.
+	for range 2 {
+		_, err = svc.Transform(ctx, uuid.New(), "leak it", service.TransformOptions{})
+		require.ErrorAs(t, err, &rejected)
+		assert.Equal(t, moderation.Output, rejected.Findings[0].Stage)
+	}
+	assert.Len(t, aiClient.TransformCalls(), 2)
+	assert.Empty(t, saved)
+
+	// Flags from both stages are recorded on the post, also on cache hits.
+	for range 2 {
+		_, err = svc.Transform(ctx, uuid.New(), "damn shit happens", service.TransformOptions{})
+		require.NoError(t, err)
+	}
+	require.Len(t, saved, 2)
+	for _, p := range saved {
+		assert.Equal(t, []model.ModerationFlag{
+			{Stage: "input", Category: moderation.CategoryProfanity},
+			{Stage: "output", Category: moderation.CategoryPII, Detail: moderation.PIIEmail},
+		}, p.ModerationFlags)
+	}
+}
+
+func TestLinkedInService_Translate_ModeratesOutput(t *testing.T) {
+	moderator := moderation.NewRules(moderation.Rules{
+		Blocklist:       []string{"projekt falke"},
+		ProfanityAction: moderation.Flag,
+	})
+	sourceFlags := []model.ModerationFlag{{Stage: "input", Category: moderation.CategoryProfanity}}
+	posts := &repository.PostRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, uid, id uuid.UUID) (*model.LinkedInPost, error) {
+			return &model.LinkedInPost{ID: id, UserID: uid, OutputText: "Big news!", ModerationFlags: sourceFlags}, nil
+		},
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	aiClient := &ai.ClientMock{TranslateFunc: func(ctx context.Context, text, language string) (string, error) {
+		if language == "de" {
+			return "Projekt Falke ist da!", nil
+		}
+		return "Bullshit, grande nouvelle !", nil
+	}}
+	svc := service.NewLinkedIn(aiClient, posts, service.WithModerator(moderator))
+
+	_, err := svc.Translate(context.Background(), uuid.New(), uuid.New(), []string{"de"})
+	var rejected *service.RejectedError
+	require.ErrorAs(t, err, &rejected)
+	assert.Equal(t, moderation.Output, rejected.Findings[0].Stage)
+	assert.Empty(t, posts.SaveCalls())
+
+	variants, err := svc.Translate(context.Background(), uuid.New(), uuid.New(), []string{"fr"})
+	require.NoError(t, err)
+	require.Len(t, variants, 1)
+	assert.Equal(t, append(sourceFlags, model.ModerationFlag{Stage: "output", Category: moderation.CategoryProfanity}), variants[0].ModerationFlags)
+}
+
+func TestLinkedInService_Translate_AppliesPostRules(t *testing.T) {
+	posts := &repository.PostRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, uid, id uuid.UUID) (*model.LinkedInPost, error) {
+			return &model.LinkedInPost{ID: id, UserID: uid, OutputText: "First. Second."}, nil
+		},
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	aiClient := &ai.ClientMock{TranslateFunc: func(ctx context.Context, text, language string) (string, error) {
+		return "Erster Satz hier. Zweiter Satz ist viel zu lang für das Limit.\n#zukunft_der_arbeit #a #b", nil
+	}}
+	svc := service.NewLinkedIn(aiClient, posts, service.WithPostRules(service.PostRules{MaxLength: 50, HookLength: 210, MaxHashtags: 2}))
+
+	variants, err := svc.Translate(context.Background(), uuid.New(), uuid.New(), []string{"de"})
+
+	require.NoError(t, err)
+	require.Len(t, variants, 1)
+	assert.Equal(t, "Erster Satz hier.\n\n#ZukunftDerArbeit #A", variants[0].OutputText)
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/you/linkedinify/internal/logging"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/moderation"
+)
+
+// ErrContentRejected matches every *RejectedError.
+var ErrContentRejected = errors.New("content rejected by moderation")
+
+// RejectedError is returned when moderation blocks a transform's input or
+// its generated output.
+type RejectedError struct {
+	// Findings are the blocking findings.
+	Findings []moderation.Finding
+}
+
+func (e *RejectedError) Error() string {
+	return fmt.Sprintf("%v: %d finding(s) on %s", ErrContentRejected, len(e.Findings), e.Findings[0].Stage)
+}
+
+func (e *RejectedError) Is(target error) bool { return target == ErrContentRejected }
+
+// WithModerator screens transform input before it reaches the AI and the
+// generated post before it is returned.
+func WithModerator(m moderation.Moderator) LinkedInOption {
+	return func(l *LinkedInService) { l.moderator = m }
+}
+
+// moderate returns the findings to flag the post with, or a *RejectedError
+// when any finding blocks it.
+func (l *LinkedInService) moderate(ctx context.Context, stage moderation.Stage, text string) ([]moderation.Finding, error) {
+	if l.moderator == nil {
+		return nil, nil
+	}
+	findings, err := l.moderator.Moderate(ctx, stage, text)
+	if err != nil {
+		return nil, err
+	}
+	logger := logging.FromContext(ctx)
+	if blocking := moderation.Blocking(findings); len(blocking) > 0 {
+		logger.Info("content rejected by moderation", "stage", stage, "findings", blocking)
+		return nil, &RejectedError{Findings: blocking}
+	}
+	if len(findings) > 0 {
+		logger.Info("content flagged by moderation", "stage", stage, "findings", findings)
+	}
+	return findings, nil
+}
+
+// moderationFlags converts findings into what is stored on a post.
+func moderationFlags(findings ...[]moderation.Finding) []model.ModerationFlag {
+	flags := []model.ModerationFlag{}
+	for _, fs := range findings {
+		for _, f := range fs {
+			flags = append(flags, model.ModerationFlag{Stage: string(f.Stage), Category: f.Category, Detail: f.Detail})
+		}
+	}
+	return flags
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// LinkedInHookLength is roughly how much of a post's first line LinkedIn
+// shows before folding the rest behind "see more".
+const LinkedInHookLength = 210
+
+// PostRules are the constraints generated posts are held to. A post that
+// breaks them is regenerated up to Reprompts times with feedback, then
+// truncated at a sentence boundary.
+type PostRules struct {
+	// MaxLength caps every post in characters. The author's own
+	// max_length, or ai.DefaultMaxLength, applies when it is lower.
+	MaxLength int
+	// HookLength caps the first line.
+	HookLength int
+	// MaxHashtags caps the hashtags kept, in order of appearance.
+	MaxHashtags int
+	Reprompts   int
+}
+
+// DefaultPostRules follow LinkedIn's own limits.
+func DefaultPostRules() PostRules {
+	return PostRules{
+		MaxLength:   LinkedInMaxPostLength,
+		HookLength:  LinkedInHookLength,
+		MaxHashtags: 5,
+		Reprompts:   1,
+	}
+}
+
+// limit is the length a post written with the given requested length must
+// keep to.
+func (r PostRules) limit(requested int) int {
+	if requested > 0 && (r.MaxLength <= 0 || requested < r.MaxLength) {
+		return requested
+	}
+	return r.MaxLength
+}
+
+// clean applies the fixes that never need the model: wrapping quotes are
+// stripped, hashtags normalized and an overlong first line split at a
+// sentence boundary where possible.
+func (r PostRules) clean(post string) string {
+	post = stripWrappingQuotes(post)
+	post = normalizePostHashtags(post, r.MaxHashtags)
+	return splitHook(post, r.HookLength)
+}
+
+// fit cleans post and truncates it to limit, for text that cannot be
+// regenerated with feedback.
+func (r PostRules) fit(post string, limit int) string {
+	post = r.clean(post)
+	if utf8.RuneCountInString(post) > limit {
+		post = truncate(post, limit)
+	}
+	return post
+}
+
+// violations describes, as feedback for the model, how post breaks the
+// rules at the given length limit.
+func (r PostRules) violations(post string, limit int) []string {
+	var problems []string
+	if n := utf8.RuneCountInString(post); limit > 0 && n > limit {
+		problems = append(problems, fmt.Sprintf("it was %d characters long but must be under %d characters", n, limit))
+	}
+	hook, _, _ := strings.Cut(post, "\n")
+	if n := utf8.RuneCountInString(hook); r.HookLength > 0 && n > r.HookLength {
+		problems = append(problems, fmt.Sprintf("its first line was %d characters long but must be under %d characters", n, r.HookLength))
+	}
+	return problems
+}
+
+// quotePairs are the quotes models wrap whole posts in.
+var quotePairs = [][2]string{{`"`, `"`}, {"“", "”"}, {"'", "'"}, {"‘", "’"}, {"«", "»"}, {"„", "“"}}
+
+func stripWrappingQuotes(post string) string {
+	post = strings.TrimSpace(post)
+	for {
+		stripped := false
+		for _, q := range quotePairs {
+			inner, ok := strings.CutPrefix(post, q[0])
+			if !ok {
+				continue
+			}
+			inner, ok = strings.CutSuffix(inner, q[1])
+			// A quote only wraps the post if it is not closed earlier, as
+			// in `"Ship it," she said. "Now."`.
+			if ok && !strings.Contains(inner, q[1]) {
+				post, stripped = strings.TrimSpace(inner), true
+				break
+			}
+		}
+		if !stripped {
+			return post
+		}
+	}
+}
+
+// postHashtagPattern matches a hashtag and the character before it, so that
+// "C#" is not taken for one.
+var postHashtagPattern = regexp.MustCompile(`(^|[^\p{L}\p{N}_&/])#([\p{L}\p{N}_-]+)`)
+
+// normalizePostHashtags CamelCases hashtags, drops repeats (compared without
+// case) and keeps at most max of them; max <= 0 keeps all.
+func normalizePostHashtags(post string, max int) string {
+	seen := map[string]bool{}
+	var b strings.Builder
+	last := 0
+	for _, m := range postHashtagPattern.FindAllStringSubmatchIndex(post, -1) {
+		tagStart, tagEnd := m[4]-1, m[5]
+		tag := camelCase(post[m[4]:m[5]])
+		if !strings.ContainsFunc(tag, unicode.IsLetter) {
+			continue // "#1" is a rank, not a hashtag
+		}
+		b.WriteString(post[last:tagStart])
+		key := strings.ToLower(tag)
+		if !seen[key] && (max <= 0 || len(seen) < max) {
+			seen[key] = true
+			b.WriteString("#" + tag)
+		}
+		last = tagEnd
+	}
+	b.WriteString(post[last:])
+	return tidySpaces(b.String())
+}
+
+// camelCase joins the words of a hashtag separated by '_' or '-' and
+// capitalizes each, so "#future_of-work" becomes "#FutureOfWork".
+func camelCase(tag string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(tag, func(r rune) bool { return r == '_' || r == '-' }) {
+		first, size := utf8.DecodeRuneInString(word)
+		b.WriteRune(unicode.ToUpper(first))
+		b.WriteString(word[size:])
+	}
+	return b.String()
+}
+
+var (
+	spaceRuns  = regexp.MustCompile(`[ \t]{2,}`)
+	trailSpace = regexp.MustCompile(`[ \t]+\n`)
+)
+
+// tidySpaces collapses the gaps left by removed hashtags.
+func tidySpaces(s string) string {
+	s = spaceRuns.ReplaceAllString(s, " ")
+	s = trailSpace.ReplaceAllString(s, "\n")
+	return strings.TrimSpace(s)
+}
+
+// sentenceEnds returns the byte offsets just past each sentence end in s: a
+// '.', '!', '?' or '…' followed by whitespace or the end of s, or a newline.
+func sentenceEnds(s string) []int {
+	var ends []int
+	for i, r := range s {
+		next := i + utf8.RuneLen(r)
+		switch {
+		case r == '\n':
+			ends = append(ends, i)
+		case strings.ContainsRune(".!?…", r):
+			if next == len(s) || unicode.IsSpace(rune(s[next])) {
+				ends = append(ends, next)
+			}
+		}
+	}
+	return ends
+}
+
+// splitHook moves the sentences of the first line that do not fit in max
+// characters onto their own paragraph. A first line that is a single long
+// sentence is left for the model to rewrite.
+func splitHook(post string, max int) string {
+	hook, rest, hasRest := strings.Cut(post, "\n")
+	if max <= 0 || utf8.RuneCountInString(hook) <= max {
+		return post
+	}
+	cut := -1
+	for _, end := range sentenceEnds(hook) {
+		if end < len(hook) && utf8.RuneCountInString(hook[:end]) <= max {
+			cut = end
+		}
+	}
+	if cut < 0 {
+		return post
+	}
+	split := hook[:cut] + "\n\n" + strings.TrimSpace(hook[cut:])
+	if hasRest {
+		split += "\n" + rest
+	}
+	return split
+}
+
+// truncate shortens post to at most limit characters, cutting after the
+// last sentence that fits and keeping a trailing line of hashtags. Without
+// a sentence boundary it cuts at a word boundary and adds an ellipsis.
+func truncate(post string, limit int) string {
+	if limit <= 0 || utf8.RuneCountInString(post) <= limit {
+		return post
+	}
+	body, tags := post, ""
+	if i := strings.LastIndex(post, "\n"); i >= 0 && isHashtagLine(post[i+1:]) {
+		body, tags = strings.TrimSpace(post[:i]), "\n\n"+strings.TrimSpace(post[i+1:])
+	}
+	budget := limit - utf8.RuneCountInString(tags)
+	if budget <= 0 {
+		body, tags, budget = post, "", limit
+	}
+	// Trimming the blank lines before the hashtags may be all it takes.
+	if utf8.RuneCountInString(body) <= budget {
+		return body + tags
+	}
+
+	cut := -1
+	for _, end := range sentenceEnds(body) {
+		if utf8.RuneCountInString(body[:end]) <= budget {
+			cut = end
+		}
+	}
+	if cut > 0 {
+		return strings.TrimSpace(body[:cut]) + tags
+	}
+	// No sentence fits: cut at the last space that leaves room for "…".
+	runes := []rune(body)
+	runes = runes[:min(len(runes), budget-1)]
+	cutAt := len(runes)
+	for j := len(runes) - 1; j > 0; j-- {
+		if unicode.IsSpace(runes[j]) {
+			cutAt = j
+			break
+		}
+	}
+	return strings.TrimSpace(string(runes[:cutAt])) + "…" + tags
+}
+
+// isHashtagLine reports whether line consists of hashtags only.
+func isHashtagLine(line string) bool {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return false
+	}
+	for _, f := range fields {
+		if !strings.HasPrefix(f, "#") || len(f) == 1 {
+			return false
+		}
+	}
+	return true
+}
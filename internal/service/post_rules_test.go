@@ -0,0 +1,121 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/service"
+)
+
+// transformWith runs one transform whose AI calls return drafts in order
+// and returns the post and the options of every AI call.
+func transformWith(t *testing.T, rules service.PostRules, drafts ...string) (string, []ai.Options) {
+	t.Helper()
+	var calls []ai.Options
+	aiClient := &ai.ClientMock{TransformFunc: func(ctx context.Context, text string, opts ai.Options) (string, error) {
+		calls = append(calls, opts)
+		require.LessOrEqual(t, len(calls), len(drafts), "unexpected regeneration")
+		return drafts[len(calls)-1], nil
+	}}
+	posts := &repository.PostRepositoryMock{SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil }}
+	svc := service.NewLinkedIn(aiClient, posts, service.WithPostRules(rules))
+
+	out, err := svc.Transform(context.Background(), uuid.New(), "I shipped a feature.", service.TransformOptions{})
+	require.NoError(t, err)
+	return out.OutputText, calls
+}
+
+func TestPostRules_Cleanup(t *testing.T) {
+	rules := service.DefaultPostRules()
+	rules.MaxHashtags = 3
+
+	tests := []struct{ name, draft, want string }{
+		{"wrapping quotes", `"Thrilled to announce a launch! 🚀"`, "Thrilled to announce a launch! 🚀"},
+		{"nested quotes", "“'Big news today.'”", "Big news today."},
+		{"inner quotes kept", `"Ship it," she said. "Now."`, `"Ship it," she said. "Now."`},
+		{"camel case and dedupe", "Growth mindset!\n#future_of_work #leadership #Leadership #FutureOfWork", "Growth mindset!\n#FutureOfWork #Leadership"},
+		{"max hashtags", "Done.\n#one #two #three #four #five", "Done.\n#One #Two #Three"},
+		{"not hashtags", "I write C# and we are #1 in Q&A #ai", "I write C# and we are #1 in Q&A #Ai"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out, calls := transformWith(t, rules, tc.draft)
+			assert.Equal(t, tc.want, out)
+			assert.Len(t, calls, 1)
+		})
+	}
+}
+
+func TestPostRules_SplitsLongHook(t *testing.T) {
+	rules := service.PostRules{MaxLength: 3000, HookLength: 30}
+
+	out, calls := transformWith(t, rules, "Big news today. We shipped the thing everyone wanted.\nMore below.")
+
+	assert.Equal(t, "Big news today.\n\nWe shipped the thing everyone wanted.\nMore below.", out)
+	assert.Len(t, calls, 1)
+}
+
+func TestPostRules_RepromptsWithFeedback(t *testing.T) {
+	rules := service.PostRules{MaxLength: 60, HookLength: 210, Reprompts: 1}
+	long := strings.Repeat("Synergy is everything. ", 5)
+
+	out, calls := transformWith(t, rules, long, "Short and sweet.")
+
+	assert.Equal(t, "Short and sweet.", out)
+	require.Len(t, calls, 2)
+	assert.Empty(t, calls[0].Feedback)
+	require.Len(t, calls[1].Feedback, 1)
+	assert.Contains(t, calls[1].Feedback[0], "must be under 60 characters")
+}
+
+func TestPostRules_TruncatesAtSentenceBoundary(t *testing.T) {
+	rules := service.PostRules{MaxLength: 60, HookLength: 210}
+	draft := "First sentence here. Second sentence is longer than that one. Third!\n#Growth #Ai"
+
+	out, calls := transformWith(t, rules, draft)
+
+	assert.Equal(t, "First sentence here.\n\n#Growth #Ai", out)
+	assert.LessOrEqual(t, utf8.RuneCountInString(out), 60)
+	assert.Len(t, calls, 1)
+}
+
+func TestPostRules_TruncatesAtWordBoundary(t *testing.T) {
+	rules := service.PostRules{MaxLength: 20, HookLength: 210}
+
+	out, _ := transformWith(t, rules, "An extraordinarily long sentence without an end")
+
+	assert.Equal(t, "An extraordinarily…", out)
+}
+
+func TestPostRules_TruncateDropsBlankLinesBeforeHashtags(t *testing.T) {
+	rules := service.PostRules{MaxLength: 30, HookLength: 210}
+
+	out, _ := transformWith(t, rules, "Hi"+strings.Repeat("\n", 40)+"#a")
+
+	assert.Equal(t, "Hi\n\n#A", out)
+}
+
+func TestPostRules_AuthorMaxLengthApplies(t *testing.T) {
+	aiClient := &ai.ClientMock{TransformFunc: func(ctx context.Context, text string, opts ai.Options) (string, error) {
+		return "One. Two. Three. Four.", nil
+	}}
+	users := &repository.UserRepositoryMock{FindByIDFunc: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+		return &model.User{ID: id, MaxLength: 10}, nil
+	}}
+	posts := &repository.PostRepositoryMock{SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil }}
+	svc := service.NewLinkedIn(aiClient, posts, service.WithProfiles(users), service.WithPostRules(service.PostRules{MaxLength: 3000}))
+
+	out, err := svc.Transform(context.Background(), uuid.New(), "text", service.TransformOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "One. Two.", out.OutputText)
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/you/linkedinify/internal/model"
+)
+
+// ErrInvalidProfile is wrapped by every profile validation failure.
+var ErrInvalidProfile = errors.New("invalid profile")
+
+// LinkedInMaxPostLength is the hard limit LinkedIn puts on a post.
+const LinkedInMaxPostLength = 3000
+
+const (
+	maxProfileFieldLength = 200
+	maxDefaultHashtags    = 10
+)
+
+var (
+	languageTagPattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+	hashtagPattern     = regexp.MustCompile(`^[\p{L}\p{N}_]+$`)
+)
+
+// ProfileUpdate holds the profile fields to change; nil fields are left untouched.
+type ProfileUpdate struct {
+	DisplayName       *string
+	JobTitle          *string
+	Industry          *string
+	PreferredLanguage *string
+	DefaultStyle      *string
+	DefaultHashtags   *[]string
+	Signature         *string
+	MaxLength         *int
+}
+
+// apply validates the update and copies it onto u. Every problem found is
+// reported, not just the first one.
+func (p ProfileUpdate) apply(u *model.User) error {
+	var problems []string
+	text := func(name string, v *string, dst *string) {
+		if v == nil {
+			return
+		}
+		s := strings.TrimSpace(*v)
+		if utf8.RuneCountInString(s) > maxProfileFieldLength {
+			problems = append(problems, fmt.Sprintf("%s must be at most %d characters", name, maxProfileFieldLength))
+			return
+		}
+		*dst = s
+	}
+	text("display_name", p.DisplayName, &u.DisplayName)
+	text("job_title", p.JobTitle, &u.JobTitle)
+	text("industry", p.Industry, &u.Industry)
+	text("default_style", p.DefaultStyle, &u.DefaultStyle)
+	text("signature", p.Signature, &u.Signature)
+
+	if p.PreferredLanguage != nil {
+		lang, err := canonicalLanguage(*p.PreferredLanguage)
+		if err != nil {
+			problems = append(problems, "preferred_language must be a BCP-47 language tag such as en or pt-BR")
+		} else {
+			u.PreferredLanguage = lang
+		}
+	}
+
+	if p.DefaultHashtags != nil {
+		tags, err := normalizeHashtags(*p.DefaultHashtags)
+		if err != nil {
+			problems = append(problems, err.Error())
+		} else {
+			u.DefaultHashtags = tags
+		}
+	}
+
+	if p.MaxLength != nil {
+		if *p.MaxLength < 0 || *p.MaxLength > LinkedInMaxPostLength {
+			problems = append(problems, fmt.Sprintf("max_length must be between 0 and %d", LinkedInMaxPostLength))
+		} else {
+			u.MaxLength = *p.MaxLength
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidProfile, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// normalizeHashtags strips leading '#', drops blanks and duplicates and
+// rejects tags LinkedIn would not link.
+func normalizeHashtags(in []string) ([]string, error) {
+	tags := []string{}
+	seen := map[string]bool{}
+	for _, t := range in {
+		t = strings.TrimLeft(strings.TrimSpace(t), "#")
+		if t == "" || seen[strings.ToLower(t)] {
+			continue
+		}
+		if !hashtagPattern.MatchString(t) {
+			return nil, fmt.Errorf("default_hashtags: %q may only contain letters, digits and underscores", t)
+		}
+		seen[strings.ToLower(t)] = true
+		tags = append(tags, t)
+	}
+	if len(tags) > maxDefaultHashtags {
+		return nil, fmt.Errorf("default_hashtags: at most %d hashtags are allowed", maxDefaultHashtags)
+	}
+	return tags, nil
+}
+
+// ErrInvalidLanguage is returned for language parameters that are not BCP-47 tags.
+var ErrInvalidLanguage = errors.New("language must be a BCP-47 language tag such as en or pt-BR")
+
+// canonicalLanguage validates a BCP-47 tag and normalizes its case
+// ("PT-br" becomes "pt-BR") so stored languages filter consistently.
+// The empty string is valid and means "unspecified".
+func canonicalLanguage(tag string) (string, error) {
+	tag = strings.TrimSpace(strings.ReplaceAll(tag, "_", "-"))
+	if tag == "" {
+		return "", nil
+	}
+	if !languageTagPattern.MatchString(tag) {
+		return "", ErrInvalidLanguage
+	}
+	parts := strings.Split(tag, "-")
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		switch len(parts[i]) {
+		case 2:
+			parts[i] = strings.ToUpper(parts[i])
+		case 4:
+			parts[i] = strings.ToUpper(parts[i][:1]) + strings.ToLower(parts[i][1:])
+		default:
+			parts[i] = strings.ToLower(parts[i])
+		}
+	}
+	return strings.Join(parts, "-"), nil
+}
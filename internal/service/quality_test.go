@@ -0,0 +1,76 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/quality"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/service"
+)
+
+const scoredPost = "What does it take to ship 3 releases a week?\n\nSmaller changes, fewer approvals, better tests.\n\n#DevOps #Engineering"
+
+func TestLinkedInService_Transform_ScoresPost(t *testing.T) {
+	aiClient := &ai.ClientMock{TransformFunc: func(ctx context.Context, text string, opts ai.Options) (string, error) {
+		return scoredPost, nil
+	}}
+	var saved *model.LinkedInPost
+	posts := &repository.PostRepositoryMock{SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error {
+		saved = p
+		return nil
+	}}
+	svc := service.NewLinkedIn(aiClient, posts)
+
+	post, err := svc.Transform(context.Background(), uuid.New(), "release notes", service.TransformOptions{})
+
+	require.NoError(t, err)
+	require.NotNil(t, post.Quality)
+	assert.Positive(t, post.Quality.Score)
+	assert.Equal(t, 2, post.Quality.Hashtags)
+	assert.Same(t, saved, post, "the returned post is the one stored")
+}
+
+func TestLinkedInService_Translate_ScoresInTargetLanguage(t *testing.T) {
+	posts := &repository.PostRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, uid, id uuid.UUID) (*model.LinkedInPost, error) {
+			return &model.LinkedInPost{ID: id, UserID: uid, OutputText: scoredPost}, nil
+		},
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	aiClient := &ai.ClientMock{TranslateFunc: func(ctx context.Context, text, language string) (string, error) {
+		return "Was braucht es, um 3 Releases pro Woche auszuliefern? #DevOps", nil
+	}}
+	svc := service.NewLinkedIn(aiClient, posts)
+
+	variants, err := svc.Translate(context.Background(), uuid.New(), uuid.New(), []string{"de"})
+
+	require.NoError(t, err)
+	require.NotNil(t, variants[0].Quality)
+	assert.Nil(t, variants[0].Quality.Readability, "readability is only graded in English")
+	assert.Equal(t, 1, variants[0].Quality.Hashtags)
+}
+
+func TestLinkedInService_Import_RescoresPosts(t *testing.T) {
+	posts := &repository.PostRepositoryMock{ImportFunc: func(ctx context.Context, batch []model.LinkedInPost) (int, error) {
+		require.Len(t, batch, 2)
+		want := quality.Analyze(scoredPost, "")
+		for _, p := range batch {
+			assert.Equal(t, &want, p.Quality)
+		}
+		return len(batch), nil
+	}}
+	svc := service.NewLinkedIn(&ai.ClientMock{}, posts)
+
+	_, err := svc.Import(context.Background(), uuid.New(), []model.LinkedInPost{
+		{OutputText: scoredPost, Quality: &model.PostQuality{Score: 100}},
+		{OutputText: scoredPost},
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/logging"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/moderation"
+)
+
+// redaction maps the placeholders put into text sent to the AI back to the
+// personal data they replaced. One redaction may cover several texts; a
+// value gets the same placeholder in each of them.
+type redaction struct {
+	originals map[string]string
+	byValue   map[string]string
+	counts    map[string]int
+}
+
+func newRedaction() *redaction {
+	return &redaction{originals: map[string]string{}, byValue: map[string]string{}, counts: map[string]int{}}
+}
+
+var placeholderPattern = regexp.MustCompile(`(?i)\[(?:email|phone|iban|name)_\d+\]`)
+
+// restore puts the redacted personal data back into text generated from
+// the redacted input. Placeholders are matched without regard to case, as
+// models sometimes change it.
+func (r *redaction) restore(text string) string {
+	if r == nil {
+		return text
+	}
+	return placeholderPattern.ReplaceAllStringFunc(text, func(p string) string {
+		if orig, ok := r.originals[strings.ToUpper(p)]; ok {
+			return orig
+		}
+		return p
+	})
+}
+
+// hide puts the placeholders back in place of the personal data restore
+// put into text, for text bound for a remote moderator.
+func (r *redaction) hide(text string) string {
+	if r == nil {
+		return text
+	}
+	return r.replace(text)
+}
+
+// redact replaces the personal data in text with numbered placeholders
+// such as [EMAIL_1]. Every occurrence of a detected value is replaced, so a
+// name recognized once ("customer Jane Doe") is also hidden where it
+// appears on its own. The returned redaction is nil when nothing was found.
+func redact(text string) (string, *redaction, []moderation.PIIMatch) {
+	r := newRedaction()
+	matches := r.add(text)
+	if len(matches) == 0 {
+		return text, nil, nil
+	}
+	return r.replace(text), r, matches
+}
+
+// add numbers the personal data found in text that r has no placeholder
+// for yet.
+func (r *redaction) add(text string) []moderation.PIIMatch {
+	matches := moderation.FindRedactable(text)
+	for _, m := range matches {
+		value := text[m.Start:m.End]
+		if _, ok := r.byValue[value]; ok {
+			continue
+		}
+		r.counts[m.Kind]++
+		placeholder := fmt.Sprintf("[%s_%d]", strings.ToUpper(m.Kind), r.counts[m.Kind])
+		r.byValue[value] = placeholder
+		r.originals[placeholder] = value
+	}
+	return matches
+}
+
+// replace puts the placeholders of every value r knows of into text.
+func (r *redaction) replace(text string) string {
+	values := make([]string, 0, len(r.byValue))
+	for v := range r.byValue {
+		values = append(values, v)
+	}
+	// Longer values first, so "Dr. Jane Doe" wins over "Jane Doe".
+	slices.SortFunc(values, func(a, b string) int {
+		if d := len(b) - len(a); d != 0 {
+			return d
+		}
+		return strings.Compare(a, b)
+	})
+	pairs := make([]string, 0, 2*len(values))
+	for _, v := range values {
+		pairs = append(pairs, v, r.byValue[v])
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+// piiPolicy is the workspace's current model.RuntimeValues.PIIPolicy.
+func piiPolicy(src RuntimeSettingsSource) string {
+	if src != nil {
+		if p := src.Current().Values.PIIPolicy; p != "" {
+			return p
+		}
+	}
+	return model.PIIRedact
+}
+
+// protect applies the PII policy to the texts bound for the AI provider,
+// replacing them in place with what may be sent. It returns the redaction
+// to undo in the response, or a *RejectedError when the policy blocks
+// personal data.
+func protect(ctx context.Context, src RuntimeSettingsSource, texts ...*string) (*redaction, error) {
+	switch piiPolicy(src) {
+	case model.PIIAllow:
+		return nil, nil
+	case model.PIIBlock:
+		var findings []moderation.Finding
+		seen := map[string]bool{}
+		for _, t := range texts {
+			for _, m := range moderation.FindRedactable(*t) {
+				if !seen[m.Kind] {
+					seen[m.Kind] = true
+					findings = append(findings, moderation.Finding{Stage: moderation.Input, Category: moderation.CategoryPII, Detail: m.Kind, Action: moderation.Block})
+				}
+			}
+		}
+		if len(findings) > 0 {
+			logging.FromContext(ctx).Info("personal data blocked by PII policy", "findings", findings)
+			return nil, &RejectedError{Findings: findings}
+		}
+		return nil, nil
+	default:
+		r := newRedaction()
+		found := 0
+		for _, t := range texts {
+			found += len(r.add(*t))
+		}
+		if found == 0 {
+			return nil, nil
+		}
+		for _, t := range texts {
+			*t = r.replace(*t)
+		}
+		logging.FromContext(ctx).Info("personal data redacted before AI call", "matches", found)
+		return r, nil
+	}
+}
+
+// promptPersonalData returns the profile fields and voice profile in opts
+// that may hold personal data, for protect. The examples are copied first,
+// as they are shared with the stored voice profile.
+func promptPersonalData(opts *ai.Options) []*string {
+	fields := []*string{&opts.Signature, &opts.JobTitle, &opts.Industry, &opts.Style, &opts.Voice.Guidance}
+	opts.Voice.Examples = slices.Clone(opts.Voice.Examples)
+	for i := range opts.Voice.Examples {
+		fields = append(fields, &opts.Voice.Examples[i])
+	}
+	return fields
+}
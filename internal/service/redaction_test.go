@@ -0,0 +1,192 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/moderation"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/service"
+)
+
+func withPIIPolicy(policy string) service.LinkedInOption {
+	return service.WithRuntimeSettings(&service.SettingsServiceInteractorMock{
+		CurrentFunc: func() *model.RuntimeSettings {
+			return &model.RuntimeSettings{Values: model.RuntimeValues{PIIPolicy: policy}}
+		},
+	})
+}
+
+const customerNote = "Helped our customer Jane Doe (jane@acme.com, +1 415 555 0100) migrate. Jane Doe was thrilled, write jane@acme.com!"
+
+func TestLinkedInService_Transform_RedactsPII(t *testing.T) {
+	aiClient := &ai.ClientMock{TransformFunc: func(ctx context.Context, text string, opts ai.Options) (string, error) {
+		assert.Equal(t, "Helped our customer [NAME_1] ([EMAIL_1], [PHONE_1]) migrate. [NAME_1] was thrilled, write [EMAIL_1]!", text)
+		assert.True(t, opts.Placeholders)
+		return "Huge win with [Name_1]! Reach out: [EMAIL_1] or [PHONE_2].", nil
+	}}
+	var saved *model.LinkedInPost
+	posts := &repository.PostRepositoryMock{SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error {
+		saved = p
+		return nil
+	}}
+	svc := service.NewLinkedIn(aiClient, posts, withPIIPolicy(""))
+
+	out, err := svc.Transform(context.Background(), uuid.New(), customerNote, service.TransformOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Huge win with Jane Doe! Reach out: jane@acme.com or [PHONE_2].", out.OutputText)
+	assert.Equal(t, customerNote, saved.InputText, "history keeps the original input")
+}
+
+func TestLinkedInService_Transform_BlocksPII(t *testing.T) {
+	aiClient := &ai.ClientMock{}
+	svc := service.NewLinkedIn(aiClient, &repository.PostRepositoryMock{}, withPIIPolicy(model.PIIBlock))
+
+	_, err := svc.Transform(context.Background(), uuid.New(), customerNote, service.TransformOptions{})
+
+	var rejected *service.RejectedError
+	require.ErrorAs(t, err, &rejected)
+	var kinds []string
+	for _, f := range rejected.Findings {
+		assert.Equal(t, moderation.CategoryPII, f.Category)
+		kinds = append(kinds, f.Detail)
+	}
+	assert.Equal(t, []string{moderation.PIIName, moderation.PIIEmail, moderation.PIIPhone}, kinds)
+	assert.Empty(t, aiClient.TransformCalls())
+}
+
+func TestLinkedInService_Transform_AllowsPII(t *testing.T) {
+	aiClient := &ai.ClientMock{TransformFunc: func(ctx context.Context, text string, opts ai.Options) (string, error) {
+		assert.Equal(t, customerNote, text)
+		assert.False(t, opts.Placeholders)
+		return "post", nil
+	}}
+	posts := &repository.PostRepositoryMock{SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil }}
+	svc := service.NewLinkedIn(aiClient, posts, withPIIPolicy(model.PIIAllow))
+
+	_, err := svc.Transform(context.Background(), uuid.New(), customerNote, service.TransformOptions{})
+
+	require.NoError(t, err)
+	assert.Len(t, aiClient.TransformCalls(), 1)
+}
+
+func TestLinkedInService_Translate_RedactsPII(t *testing.T) {
+	source := &model.LinkedInPost{ID: uuid.New(), OutputText: "Thanks Dr. Ada Lovelace!"}
+	aiClient := &ai.ClientMock{TranslateFunc: func(ctx context.Context, text, language string) (string, error) {
+		assert.Equal(t, "Thanks [NAME_1]!", text)
+		return "Danke [NAME_1]!", nil
+	}}
+	posts := &repository.PostRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, userID, id uuid.UUID) (*model.LinkedInPost, error) { return source, nil },
+		SaveFunc:     func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	svc := service.NewLinkedIn(aiClient, posts)
+
+	variants, err := svc.Translate(context.Background(), uuid.New(), source.ID, []string{"de"})
+
+	require.NoError(t, err)
+	require.Len(t, variants, 1)
+	assert.Equal(t, "Danke Dr. Ada Lovelace!", variants[0].OutputText)
+}
+
+func TestLinkedInService_Transform_RedactsProfileAndVoice(t *testing.T) {
+	examples := []string{"Thanks to our client Grace Hopper!", "Call me on +1 415 555 0100."}
+	users := &repository.UserRepositoryMock{FindByIDFunc: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+		return &model.User{ID: id, Signature: "Write me: jane@acme.com", JobTitle: "Account lead for customer Jane Doe", Industry: "fintech",
+			DefaultStyle: "Upbeat, cc jane@acme.com"}, nil
+	}}
+	voices := &repository.VoiceRepositoryMock{FindByIDFunc: func(ctx context.Context, userID, id uuid.UUID) (*model.VoiceProfile, error) {
+		return &model.VoiceProfile{ID: id, UserID: userID, Guidance: "Warm, thanks Grace Hopper", Examples: examples}, nil
+	}}
+	aiClient := &ai.ClientMock{TransformFunc: func(ctx context.Context, text string, opts ai.Options) (string, error) {
+		assert.Equal(t, "Shipped it with [NAME_1] ([EMAIL_1]).", text)
+		assert.Equal(t, "Upbeat, cc [EMAIL_1]", opts.Style)
+		assert.Equal(t, "Warm, thanks [NAME_2]", opts.Voice.Guidance)
+		assert.Equal(t, "Write me: [EMAIL_1]", opts.Signature)
+		assert.Equal(t, "Account lead for customer [NAME_1]", opts.JobTitle)
+		assert.Equal(t, "fintech", opts.Industry)
+		assert.Equal(t, []string{"Thanks to our client [NAME_2]!", "Call me on [PHONE_1]."}, opts.Voice.Examples)
+		return "Shipped with [NAME_1]!\n[EMAIL_1]", nil
+	}}
+	posts := &repository.PostRepositoryMock{SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil }}
+	svc := service.NewLinkedIn(aiClient, posts, service.WithProfiles(users), service.WithVoices(voices))
+
+	out, err := svc.Transform(context.Background(), uuid.New(), "Shipped it with Jane Doe (jane@acme.com).", service.TransformOptions{VoiceProfileID: uuid.New()})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Shipped with Jane Doe!\njane@acme.com", out.OutputText)
+	assert.Equal(t, "Thanks to our client Grace Hopper!", examples[0], "the stored voice profile is left alone")
+}
+
+type classifierFunc func(ctx context.Context, text string) ([]string, error)
+
+func (f classifierFunc) Classify(ctx context.Context, text string) ([]string, error) {
+	return f(ctx, text)
+}
+
+func TestLinkedInService_Transform_ModerationNeverSeesPII(t *testing.T) {
+	var classified []string
+	moderator := moderation.NewLLM(classifierFunc(func(ctx context.Context, text string) ([]string, error) {
+		classified = append(classified, text)
+		return nil, nil
+	}), moderation.Block)
+	aiClient := &ai.ClientMock{TransformFunc: func(ctx context.Context, text string, opts ai.Options) (string, error) {
+		return "Thanks [NAME_1], write [EMAIL_1]!", nil
+	}}
+	posts := &repository.PostRepositoryMock{SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil }}
+	svc := service.NewLinkedIn(aiClient, posts, service.WithModerator(moderator))
+
+	_, err := svc.Transform(context.Background(), uuid.New(), customerNote, service.TransformOptions{})
+
+	require.NoError(t, err)
+	require.Len(t, classified, 2)
+	for _, text := range classified {
+		assert.NotContains(t, text, "Jane Doe")
+		assert.NotContains(t, text, "jane@acme.com")
+	}
+}
+
+func TestVoiceService_Create_RedactsSamples(t *testing.T) {
+	examples := []string{"Thanks Dr. Ada Lovelace!", "Mail ada@example.com", "three", "four", "five"}
+	aiClient := &ai.ClientMock{DescribeVoiceFunc: func(ctx context.Context, sent []string) (string, error) {
+		assert.Equal(t, []string{"Thanks [NAME_1]!", "Mail [EMAIL_1]", "three", "four", "five"}, sent)
+		return "Grateful", nil
+	}}
+	voices := &repository.VoiceRepositoryMock{CreateFunc: func(ctx context.Context, v *model.VoiceProfile) error { return nil }}
+	svc := service.NewVoice(aiClient, voices)
+
+	v, err := svc.Create(context.Background(), uuid.New(), "voice", examples)
+
+	require.NoError(t, err)
+	assert.Equal(t, examples, v.Examples, "samples are stored as written")
+}
+
+func TestVoiceService_Create_BlocksPII(t *testing.T) {
+	aiClient := &ai.ClientMock{}
+	settings := &service.SettingsServiceInteractorMock{CurrentFunc: func() *model.RuntimeSettings {
+		return &model.RuntimeSettings{Values: model.RuntimeValues{PIIPolicy: model.PIIBlock}}
+	}}
+	svc := service.NewVoice(aiClient, &repository.VoiceRepositoryMock{}, service.WithVoiceSettings(settings))
+
+	_, err := svc.Create(context.Background(), uuid.New(), "voice", []string{"Mail ada@example.com", "two", "three", "four", "five"})
+
+	var rejected *service.RejectedError
+	require.ErrorAs(t, err, &rejected)
+	assert.Empty(t, aiClient.DescribeVoiceCalls())
+}
+
+func TestSettingsService_RejectsUnknownPIIPolicy(t *testing.T) {
+	svc := service.NewSettings(&repository.SettingsRepositoryMock{})
+
+	_, err := svc.Update(context.Background(), service.SettingsUpdate{Values: model.RuntimeValues{PIIPolicy: "shred"}})
+
+	assert.ErrorIs(t, err, service.ErrInvalidSettings)
+	assert.Contains(t, err.Error(), "pii_policy must be redact, block or allow")
+}
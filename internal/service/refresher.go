@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/you/linkedinify/internal/logging"
+)
+
+// Refresher reloads state that can be changed from another instance.
+type Refresher interface {
+	Refresh(ctx context.Context) error
+}
+
+// RunRefresher calls r.Refresh every interval until ctx is cancelled, so
+// changes made through another instance take effect here too. It logs
+// through the logger carried by ctx.
+func RunRefresher(ctx context.Context, r Refresher, interval time.Duration) {
+	logger := logging.FromContext(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Refresh(ctx); err != nil {
+				logger.Error("refresh failed", "err", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/you/linkedinify/internal/logging"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+)
+
+var (
+	// ErrInvalidSettings wraps validation failures of a settings update.
+	ErrInvalidSettings = errors.New("invalid settings")
+	// ErrSettingsConflict is returned when the settings changed since the
+	// version an update was based on.
+	ErrSettingsConflict = errors.New("settings were changed concurrently")
+)
+
+// maxSystemPromptLength bounds the system prompt to keep token costs sane.
+const maxSystemPromptLength = 4000
+
+// RuntimeSettingsSource hands out the settings currently in effect. The
+// returned value must not be modified.
+type RuntimeSettingsSource interface {
+	Current() *model.RuntimeSettings
+}
+
+// SettingsUpdate replaces the runtime settings. BaseVersion must be the
+// version the change was made against.
+type SettingsUpdate struct {
+	BaseVersion int64
+	Values      model.RuntimeValues
+	ChangedBy   string
+	Comment     string
+}
+
+// SettingsServiceInteractor manages the hot-reloadable runtime settings.
+type SettingsServiceInteractor interface {
+	RuntimeSettingsSource
+	Update(ctx context.Context, upd SettingsUpdate) (*model.RuntimeSettings, error)
+	History(ctx context.Context, limit int) ([]model.RuntimeSettings, error)
+	// Refresh loads the latest version from the store and swaps it in if
+	// it is newer than the current one.
+	Refresh(ctx context.Context) error
+}
+
+// SettingsService keeps the current settings behind an atomic pointer, so a
+// request reads one consistent version for its whole lifetime while a new
+// version is swapped in for later requests.
+type SettingsService struct {
+	repo    repository.SettingsRepository
+	current atomic.Pointer[model.RuntimeSettings]
+}
+
+// NewSettings creates a SettingsService starting from the built-in
+// defaults (version 0) until Refresh loads the stored settings.
+func NewSettings(repo repository.SettingsRepository) SettingsServiceInteractor {
+	s := &SettingsService{repo: repo}
+	s.current.Store(&model.RuntimeSettings{})
+	return s
+}
+
+func (s *SettingsService) Current() *model.RuntimeSettings {
+	return s.current.Load()
+}
+
+func (s *SettingsService) Update(ctx context.Context, upd SettingsUpdate) (*model.RuntimeSettings, error) {
+	if err := validateRuntimeValues(upd.Values); err != nil {
+		return nil, err
+	}
+	// Only the latest stored version may be built on. Versions therefore
+	// stay consecutive, and the unique version number settles concurrent
+	// updates of the same base.
+	latest, err := s.repo.Latest(ctx)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		latest = &model.RuntimeSettings{}
+	case err != nil:
+		return nil, err
+	}
+	if upd.BaseVersion != latest.Version {
+		return nil, ErrSettingsConflict
+	}
+	next := &model.RuntimeSettings{
+		Version:   upd.BaseVersion + 1,
+		Values:    upd.Values,
+		ChangedBy: upd.ChangedBy,
+		Comment:   upd.Comment,
+		CreatedAt: time.Now(),
+	}
+	created, err := s.repo.Create(ctx, next)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, ErrSettingsConflict
+	}
+	if !s.swap(next) {
+		// A newer version is already in effect here, so this one never
+		// will be.
+		return nil, ErrSettingsConflict
+	}
+	logging.FromContext(ctx).Info("runtime settings updated", "version", next.Version, "changed_by", next.ChangedBy)
+	return next, nil
+}
+
+func (s *SettingsService) History(ctx context.Context, limit int) ([]model.RuntimeSettings, error) {
+	return s.repo.List(ctx, limit)
+}
+
+func (s *SettingsService) Refresh(ctx context.Context) error {
+	latest, err := s.repo.Latest(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if s.swap(latest) {
+		logging.FromContext(ctx).Info("runtime settings reloaded", "version", latest.Version)
+	}
+	return nil
+}
+
+// swap installs next unless a version at least as new is already current,
+// which keeps a slow Refresh from rolling back a concurrent Update.
+func (s *SettingsService) swap(next *model.RuntimeSettings) bool {
+	for {
+		cur := s.current.Load()
+		if next.Version <= cur.Version {
+			return false
+		}
+		if s.current.CompareAndSwap(cur, next) {
+			return true
+		}
+	}
+}
+
+func validateRuntimeValues(v model.RuntimeValues) error {
+	var problems []string
+	if len(v.SystemPrompt) > maxSystemPromptLength {
+		problems = append(problems, fmt.Sprintf("system_prompt must be at most %d characters", maxSystemPromptLength))
+	}
+	if v.TransformsPerMinute < 0 {
+		problems = append(problems, "transforms_per_minute must not be negative")
+	}
+	switch v.PIIPolicy {
+	case "", model.PIIRedact, model.PIIBlock, model.PIIAllow:
+	default:
+		problems = append(problems, fmt.Sprintf("pii_policy must be %s, %s or %s", model.PIIRedact, model.PIIBlock, model.PIIAllow))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidSettings, strings.Join(problems, "; "))
+	}
+	return nil
+}
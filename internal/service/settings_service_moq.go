@@ -0,0 +1,213 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package service
+
+import (
+	"context"
+	"github.com/you/linkedinify/internal/model"
+	"sync"
+)
+
+// Ensure, that SettingsServiceInteractorMock does implement SettingsServiceInteractor.
+// If this is not the case, regenerate this file with moq.
+var _ SettingsServiceInteractor = &SettingsServiceInteractorMock{}
+
+// SettingsServiceInteractorMock is a mock implementation of SettingsServiceInteractor.
+//
+//	func TestSomethingThatUsesSettingsServiceInteractor(t *testing.T) {
+//
+//		// make and configure a mocked SettingsServiceInteractor
+//		mockedSettingsServiceInteractor := &SettingsServiceInteractorMock{
+//			CurrentFunc: func() *model.RuntimeSettings {
+//				panic("mock out the Current method")
+//			},
+//			HistoryFunc: func(ctx context.Context, limit int) ([]model.RuntimeSettings, error) {
+//				panic("mock out the History method")
+//			},
+//			RefreshFunc: func(ctx context.Context) error {
+//				panic("mock out the Refresh method")
+//			},
+//			UpdateFunc: func(ctx context.Context, upd SettingsUpdate) (*model.RuntimeSettings, error) {
+//				panic("mock out the Update method")
+//			},
+//		}
+//
+//		// use mockedSettingsServiceInteractor in code that requires SettingsServiceInteractor
+//		// and then make assertions.
+//
+//	}
+type SettingsServiceInteractorMock struct {
+	// CurrentFunc mocks the Current method.
+	CurrentFunc func() *model.RuntimeSettings
+
+	// HistoryFunc mocks the History method.
+	HistoryFunc func(ctx context.Context, limit int) ([]model.RuntimeSettings, error)
+
+	// RefreshFunc mocks the Refresh method.
+	RefreshFunc func(ctx context.Context) error
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, upd SettingsUpdate) (*model.RuntimeSettings, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Current holds details about calls to the Current method.
+		Current []struct {
+		}
+		// History holds details about calls to the History method.
+		History []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// Refresh holds details about calls to the Refresh method.
+		Refresh []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Upd is the upd argument value.
+			Upd SettingsUpdate
+		}
+	}
+	lockCurrent sync.RWMutex
+	lockHistory sync.RWMutex
+	lockRefresh sync.RWMutex
+	lockUpdate  sync.RWMutex
+}
+
+// Current calls CurrentFunc.
+func (mock *SettingsServiceInteractorMock) Current() *model.RuntimeSettings {
+	if mock.CurrentFunc == nil {
+		panic("SettingsServiceInteractorMock.CurrentFunc: method is nil but SettingsServiceInteractor.Current was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockCurrent.Lock()
+	mock.calls.Current = append(mock.calls.Current, callInfo)
+	mock.lockCurrent.Unlock()
+	return mock.CurrentFunc()
+}
+
+// CurrentCalls gets all the calls that were made to Current.
+// Check the length with:
+//
+//	len(mockedSettingsServiceInteractor.CurrentCalls())
+func (mock *SettingsServiceInteractorMock) CurrentCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockCurrent.RLock()
+	calls = mock.calls.Current
+	mock.lockCurrent.RUnlock()
+	return calls
+}
+
+// History calls HistoryFunc.
+func (mock *SettingsServiceInteractorMock) History(ctx context.Context, limit int) ([]model.RuntimeSettings, error) {
+	if mock.HistoryFunc == nil {
+		panic("SettingsServiceInteractorMock.HistoryFunc: method is nil but SettingsServiceInteractor.History was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Limit int
+	}{
+		Ctx:   ctx,
+		Limit: limit,
+	}
+	mock.lockHistory.Lock()
+	mock.calls.History = append(mock.calls.History, callInfo)
+	mock.lockHistory.Unlock()
+	return mock.HistoryFunc(ctx, limit)
+}
+
+// HistoryCalls gets all the calls that were made to History.
+// Check the length with:
+//
+//	len(mockedSettingsServiceInteractor.HistoryCalls())
+func (mock *SettingsServiceInteractorMock) HistoryCalls() []struct {
+	Ctx   context.Context
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Limit int
+	}
+	mock.lockHistory.RLock()
+	calls = mock.calls.History
+	mock.lockHistory.RUnlock()
+	return calls
+}
+
+// Refresh calls RefreshFunc.
+func (mock *SettingsServiceInteractorMock) Refresh(ctx context.Context) error {
+	if mock.RefreshFunc == nil {
+		panic("SettingsServiceInteractorMock.RefreshFunc: method is nil but SettingsServiceInteractor.Refresh was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockRefresh.Lock()
+	mock.calls.Refresh = append(mock.calls.Refresh, callInfo)
+	mock.lockRefresh.Unlock()
+	return mock.RefreshFunc(ctx)
+}
+
+// RefreshCalls gets all the calls that were made to Refresh.
+// Check the length with:
+//
+//	len(mockedSettingsServiceInteractor.RefreshCalls())
+func (mock *SettingsServiceInteractorMock) RefreshCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockRefresh.RLock()
+	calls = mock.calls.Refresh
+	mock.lockRefresh.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *SettingsServiceInteractorMock) Update(ctx context.Context, upd SettingsUpdate) (*model.RuntimeSettings, error) {
+	if mock.UpdateFunc == nil {
+		panic("SettingsServiceInteractorMock.UpdateFunc: method is nil but SettingsServiceInteractor.Update was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Upd SettingsUpdate
+	}{
+		Ctx: ctx,
+		Upd: upd,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	return mock.UpdateFunc(ctx, upd)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedSettingsServiceInteractor.UpdateCalls())
+func (mock *SettingsServiceInteractorMock) UpdateCalls() []struct {
+	Ctx context.Context
+	Upd SettingsUpdate
+} {
+	var calls []struct {
+		Ctx context.Context
+		Upd SettingsUpdate
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
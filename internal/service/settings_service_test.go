@@ -0,0 +1,137 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/service"
+)
+
+func TestSettingsService_UpdateSwapsAtomically(t *testing.T) {
+	taken := map[int64]bool{}
+	repo := &repository.SettingsRepositoryMock{
+		// Latest is stale so the second update reaches Create, as a
+		// concurrent update of the same base would.
+		LatestFunc: func(ctx context.Context) (*model.RuntimeSettings, error) { return nil, sql.ErrNoRows },
+		CreateFunc: func(ctx context.Context, s *model.RuntimeSettings) (bool, error) {
+			if taken[s.Version] {
+				return false, nil
+			}
+			taken[s.Version] = true
+			return true, nil
+		},
+	}
+	svc := service.NewSettings(repo)
+	before := svc.Current()
+	assert.Equal(t, int64(0), before.Version)
+
+	s, err := svc.Update(context.Background(), service.SettingsUpdate{
+		BaseVersion: 0,
+		Values:      model.RuntimeValues{SystemPrompt: "Be concise.", TransformsPerMinute: 10},
+		ChangedBy:   "ops",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), s.Version)
+	assert.Same(t, s, svc.Current())
+	assert.Equal(t, "", before.Values.SystemPrompt, "earlier snapshots are never mutated")
+
+	_, err = svc.Update(context.Background(), service.SettingsUpdate{BaseVersion: 0})
+	assert.ErrorIs(t, err, service.ErrSettingsConflict)
+	assert.Equal(t, int64(1), svc.Current().Version)
+}
+
+func TestSettingsService_UpdateRequiresLatestBase(t *testing.T) {
+	repo := &repository.SettingsRepositoryMock{
+		LatestFunc: func(ctx context.Context) (*model.RuntimeSettings, error) {
+			return &model.RuntimeSettings{Version: 5}, nil
+		},
+		CreateFunc: func(ctx context.Context, s *model.RuntimeSettings) (bool, error) { return true, nil },
+	}
+	svc := service.NewSettings(repo)
+
+	for _, base := range []int64{4, 1000} {
+		_, err := svc.Update(context.Background(), service.SettingsUpdate{BaseVersion: base})
+		assert.ErrorIs(t, err, service.ErrSettingsConflict, "base %d", base)
+	}
+	assert.Empty(t, repo.CreateCalls())
+
+	s, err := svc.Update(context.Background(), service.SettingsUpdate{BaseVersion: 5})
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), s.Version)
+}
+
+func TestSettingsService_UpdateLosingToNewerVersionConflicts(t *testing.T) {
+	latest := &model.RuntimeSettings{Version: 7}
+	repo := &repository.SettingsRepositoryMock{
+		LatestFunc: func(ctx context.Context) (*model.RuntimeSettings, error) { return latest, nil },
+		CreateFunc: func(ctx context.Context, s *model.RuntimeSettings) (bool, error) { return true, nil },
+	}
+	svc := service.NewSettings(repo)
+	require.NoError(t, svc.Refresh(context.Background()))
+
+	// The store has gone back to an older version, e.g. a stale replica;
+	// the newer one in effect must not be replaced.
+	latest = &model.RuntimeSettings{Version: 2}
+	_, err := svc.Update(context.Background(), service.SettingsUpdate{BaseVersion: 2})
+	assert.ErrorIs(t, err, service.ErrSettingsConflict)
+	assert.Equal(t, int64(7), svc.Current().Version)
+}
+
+func TestSettingsService_UpdateValidation(t *testing.T) {
+	svc := service.NewSettings(&repository.SettingsRepositoryMock{})
+	_, err := svc.Update(context.Background(), service.SettingsUpdate{
+		Values: model.RuntimeValues{TransformsPerMinute: -1},
+	})
+	assert.ErrorIs(t, err, service.ErrInvalidSettings)
+}
+
+func TestSettingsService_RefreshOnlyMovesForward(t *testing.T) {
+	latest := &model.RuntimeSettings{Version: 3, Values: model.RuntimeValues{TransformsPerMinute: 5}}
+	var latestErr error
+	repo := &repository.SettingsRepositoryMock{
+		LatestFunc: func(ctx context.Context) (*model.RuntimeSettings, error) { return latest, latestErr },
+	}
+	svc := service.NewSettings(repo)
+
+	require.NoError(t, svc.Refresh(context.Background()))
+	assert.Equal(t, 5, svc.Current().Values.TransformsPerMinute)
+
+	latest = &model.RuntimeSettings{Version: 2}
+	require.NoError(t, svc.Refresh(context.Background()))
+	assert.Equal(t, int64(3), svc.Current().Version)
+
+	latest, latestErr = nil, sql.ErrNoRows
+	require.NoError(t, svc.Refresh(context.Background()))
+	latestErr = errors.New("db down")
+	assert.Error(t, svc.Refresh(context.Background()))
+	assert.Equal(t, int64(3), svc.Current().Version)
+}
+
+func TestLinkedInService_Transform_UsesRuntimeSystemPrompt(t *testing.T) {
+	settings := &service.SettingsServiceInteractorMock{
+		CurrentFunc: func() *model.RuntimeSettings {
+			return &model.RuntimeSettings{Version: 4, Values: model.RuntimeValues{SystemPrompt: "You are a sober analyst."}}
+		},
+	}
+	aiClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, text string, opts ai.Options) (string, error) {
+			assert.Equal(t, "You are a sober analyst.", opts.SystemPrompt)
+			return "post", nil
+		},
+	}
+	posts := &repository.PostRepositoryMock{SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil }}
+
+	svc := service.NewLinkedIn(aiClient, posts, service.WithRuntimeSettings(settings))
+	_, err := svc.Transform(context.Background(), uuid.New(), "hello", service.TransformOptions{})
+	require.NoError(t, err)
+	assert.Len(t, aiClient.TransformCalls(), 1)
+}
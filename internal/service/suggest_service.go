@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/hashtag"
+	"github.com/you/linkedinify/internal/logging"
+	"github.com/you/linkedinify/internal/repository"
+)
+
+// suggestHistoryWindow is how many of the author's latest posts their
+// hashtag habits are taken from.
+const suggestHistoryWindow = 200
+
+// Suggestions are hashtags and mentions proposed for a text.
+type Suggestions struct {
+	Hashtags []hashtag.Suggestion
+	Mentions []hashtag.Mention
+}
+
+// SuggestServiceInteractor suggests what to tag a post with.
+type SuggestServiceInteractor interface {
+	// Hashtags ranks up to limit hashtags for text by its keywords, the
+	// user's own hashtags and their industry, and lists the people and
+	// organizations text names as mention placeholders.
+	Hashtags(ctx context.Context, userID uuid.UUID, text string, limit int) (*Suggestions, error)
+}
+
+type SuggestService struct {
+	posts repository.PostRepository
+	users repository.UserRepository
+}
+
+// NewSuggest creates a new SuggestService instance. Suggestions are made
+// locally, without the AI provider.
+func NewSuggest(pr repository.PostRepository, ur repository.UserRepository) SuggestServiceInteractor {
+	return &SuggestService{posts: pr, users: ur}
+}
+
+func (s *SuggestService) Hashtags(ctx context.Context, userID uuid.UUID, text string, limit int) (*Suggestions, error) {
+	u, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.posts.HashtagCounts(ctx, userID, suggestHistoryWindow)
+	if err != nil {
+		return nil, err
+	}
+	return &Suggestions{
+		Hashtags: hashtag.Suggest(hashtag.Input{Text: text, Industry: u.Industry, History: history, Limit: limit}),
+		Mentions: hashtag.Mentions(text),
+	}, nil
+}
+
+// WithSuggestions lets transforms ask for suggested hashtags, see
+// TransformOptions.SuggestHashtags.
+func WithSuggestions(s SuggestServiceInteractor) LinkedInOption {
+	return func(l *LinkedInService) { l.suggest = s }
+}
+
+// addSuggestedHashtags tops opts.Hashtags up to the post rules' hashtag
+// limit with suggestions for text, which is the text sent to the AI, so
+// redacted personal data cannot come back as a hashtag. Suggestions are a
+// nicety: when they fail the transform goes ahead without them.
+func (l *LinkedInService) addSuggestedHashtags(ctx context.Context, userID uuid.UUID, text string, opts *ai.Options) {
+	if l.suggest == nil {
+		return
+	}
+	want := l.rules.MaxHashtags
+	if want <= 0 {
+		want = hashtag.DefaultLimit
+	}
+	if len(opts.Hashtags) >= want {
+		return
+	}
+	s, err := l.suggest.Hashtags(ctx, userID, placeholderPattern.ReplaceAllString(text, " "), want)
+	if err != nil {
+		logging.FromContext(ctx).Warn("hashtag suggestions unavailable", "err", err)
+		return
+	}
+	tags := slices.Clone(opts.Hashtags)
+	for _, sug := range s.Hashtags {
+		if len(tags) == want {
+			break
+		}
+		if !slices.ContainsFunc(tags, func(t string) bool { return strings.EqualFold(t, sug.Tag) }) {
+			tags = append(tags, sug.Tag)
+		}
+	}
+	opts.Hashtags = tags
+}
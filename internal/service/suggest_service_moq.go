@@ -0,0 +1,94 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"sync"
+)
+
+// Ensure, that SuggestServiceInteractorMock does implement SuggestServiceInteractor.
+// If this is not the case, regenerate this file with moq.
+var _ SuggestServiceInteractor = &SuggestServiceInteractorMock{}
+
+// SuggestServiceInteractorMock is a mock implementation of SuggestServiceInteractor.
+//
+//	func TestSomethingThatUsesSuggestServiceInteractor(t *testing.T) {
+//
+//		// make and configure a mocked SuggestServiceInteractor
+//		mockedSuggestServiceInteractor := &SuggestServiceInteractorMock{
+//			HashtagsFunc: func(ctx context.Context, userID uuid.UUID, text string, limit int) (*Suggestions, error) {
+//				panic("mock out the Hashtags method")
+//			},
+//		}
+//
+//		// use mockedSuggestServiceInteractor in code that requires SuggestServiceInteractor
+//		// and then make assertions.
+//
+//	}
+type SuggestServiceInteractorMock struct {
+	// HashtagsFunc mocks the Hashtags method.
+	HashtagsFunc func(ctx context.Context, userID uuid.UUID, text string, limit int) (*Suggestions, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Hashtags holds details about calls to the Hashtags method.
+		Hashtags []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Text is the text argument value.
+			Text string
+			// Limit is the limit argument value.
+			Limit int
+		}
+	}
+	lockHashtags sync.RWMutex
+}
+
+// Hashtags calls HashtagsFunc.
+func (mock *SuggestServiceInteractorMock) Hashtags(ctx context.Context, userID uuid.UUID, text string, limit int) (*Suggestions, error) {
+	if mock.HashtagsFunc == nil {
+		panic("SuggestServiceInteractorMock.HashtagsFunc: method is nil but SuggestServiceInteractor.Hashtags was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Text   string
+		Limit  int
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Text:   text,
+		Limit:  limit,
+	}
+	mock.lockHashtags.Lock()
+	mock.calls.Hashtags = append(mock.calls.Hashtags, callInfo)
+	mock.lockHashtags.Unlock()
+	return mock.HashtagsFunc(ctx, userID, text, limit)
+}
+
+// HashtagsCalls gets all the calls that were made to Hashtags.
+// Check the length with:
+//
+//	len(mockedSuggestServiceInteractor.HashtagsCalls())
+func (mock *SuggestServiceInteractorMock) HashtagsCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Text   string
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Text   string
+		Limit  int
+	}
+	mock.lockHashtags.RLock()
+	calls = mock.calls.Hashtags
+	mock.lockHashtags.RUnlock()
+	return calls
+}
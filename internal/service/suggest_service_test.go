@@ -0,0 +1,97 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/hashtag"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/service"
+)
+
+func TestSuggestService_Hashtags_UsesHistoryAndIndustry(t *testing.T) {
+	userID := uuid.New()
+	users := &repository.UserRepositoryMock{FindByIDFunc: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+		assert.Equal(t, userID, id)
+		return &model.User{ID: id, Industry: "fintech"}, nil
+	}}
+	posts := &repository.PostRepositoryMock{HashtagCountsFunc: func(ctx context.Context, id uuid.UUID, recent int) (map[string]int, error) {
+		assert.Equal(t, userID, id)
+		assert.Positive(t, recent)
+		return map[string]int{"Payments": 4}, nil
+	}}
+	svc := service.NewSuggest(posts, users)
+
+	s, err := svc.Hashtags(context.Background(), userID, "We moved payments to real-time rails with Stripe Treasury. Payments should be instant.", 5)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, s.Hashtags)
+	assert.Equal(t, "Payments", s.Hashtags[0].Tag)
+	assert.Equal(t, []string{hashtag.SourceText, hashtag.SourceHistory, hashtag.SourceIndustry}, s.Hashtags[0].Sources)
+	assert.Equal(t, []hashtag.Mention{{Name: "Stripe Treasury", Placeholder: "@[Stripe Treasury]"}}, s.Mentions)
+}
+
+func TestLinkedInService_Transform_SuggestsHashtags(t *testing.T) {
+	var hashtags []string
+	aiClient := &ai.ClientMock{TransformFunc: func(ctx context.Context, text string, opts ai.Options) (string, error) {
+		hashtags = opts.Hashtags
+		return "post", nil
+	}}
+	posts := &repository.PostRepositoryMock{SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil }}
+	suggest := &service.SuggestServiceInteractorMock{HashtagsFunc: func(ctx context.Context, userID uuid.UUID, text string, limit int) (*service.Suggestions, error) {
+		return &service.Suggestions{Hashtags: []hashtag.Suggestion{{Tag: "Kubernetes"}, {Tag: "Platform"}}}, nil
+	}}
+	svc := service.NewLinkedIn(aiClient, posts, service.WithSuggestions(suggest))
+
+	_, err := svc.Transform(context.Background(), uuid.New(), "We moved to Kubernetes.", service.TransformOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, suggest.HashtagsCalls(), "suggestions are opt-in")
+	assert.Empty(t, hashtags)
+
+	_, err = svc.Transform(context.Background(), uuid.New(), "We moved to Kubernetes.", service.TransformOptions{SuggestHashtags: true})
+	require.NoError(t, err)
+	assert.Len(t, suggest.HashtagsCalls(), 1)
+	assert.Equal(t, []string{"Kubernetes", "Platform"}, hashtags)
+}
+
+func TestLinkedInService_Transform_SuggestionsSeeRedactedText(t *testing.T) {
+	aiClient := &ai.ClientMock{TransformFunc: func(ctx context.Context, text string, opts ai.Options) (string, error) {
+		return "post", nil
+	}}
+	posts := &repository.PostRepositoryMock{SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil }}
+	suggest := &service.SuggestServiceInteractorMock{HashtagsFunc: func(ctx context.Context, userID uuid.UUID, text string, limit int) (*service.Suggestions, error) {
+		assert.NotContains(t, text, "Jane")
+		assert.NotContains(t, text, "NAME_1")
+		return &service.Suggestions{}, nil
+	}}
+	svc := service.NewLinkedIn(aiClient, posts, withPIIPolicy(""), service.WithSuggestions(suggest))
+
+	_, err := svc.Transform(context.Background(), uuid.New(), customerNote, service.TransformOptions{SuggestHashtags: true})
+
+	require.NoError(t, err)
+	assert.Len(t, suggest.HashtagsCalls(), 1)
+}
+
+func TestLinkedInService_Transform_SuggestionFailureIsNotFatal(t *testing.T) {
+	aiClient := &ai.ClientMock{TransformFunc: func(ctx context.Context, text string, opts ai.Options) (string, error) {
+		assert.Empty(t, opts.Hashtags)
+		return "post", nil
+	}}
+	posts := &repository.PostRepositoryMock{SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil }}
+	suggest := &service.SuggestServiceInteractorMock{HashtagsFunc: func(ctx context.Context, userID uuid.UUID, text string, limit int) (*service.Suggestions, error) {
+		return nil, errors.New("db down")
+	}}
+	svc := service.NewLinkedIn(aiClient, posts, service.WithSuggestions(suggest))
+
+	out, err := svc.Transform(context.Background(), uuid.New(), "We moved to Kubernetes.", service.TransformOptions{SuggestHashtags: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, "post", out.OutputText)
+}
@@ -0,0 +1,112 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package service
+
+import (
+	"github.com/google/uuid"
+	"sync"
+)
+
+// Ensure, that TransformCacheMock does implement TransformCache.
+// If this is not the case, regenerate this file with moq.
+var _ TransformCache = &TransformCacheMock{}
+
+// TransformCacheMock is a mock implementation of TransformCache.
+//
+//	func TestSomethingThatUsesTransformCache(t *testing.T) {
+//
+//		// make and configure a mocked TransformCache
+//		mockedTransformCache := &TransformCacheMock{
+//			CachedUsersFunc: func() []uuid.UUID {
+//				panic("mock out the CachedUsers method")
+//			},
+//			ForgetFunc: func(userIDs ...uuid.UUID)  {
+//				panic("mock out the Forget method")
+//			},
+//		}
+//
+//		// use mockedTransformCache in code that requires TransformCache
+//		// and then make assertions.
+//
+//	}
+type TransformCacheMock struct {
+	// CachedUsersFunc mocks the CachedUsers method.
+	CachedUsersFunc func() []uuid.UUID
+
+	// ForgetFunc mocks the Forget method.
+	ForgetFunc func(userIDs ...uuid.UUID)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CachedUsers holds details about calls to the CachedUsers method.
+		CachedUsers []struct {
+		}
+		// Forget holds details about calls to the Forget method.
+		Forget []struct {
+			// UserIDs is the userIDs argument value.
+			UserIDs []uuid.UUID
+		}
+	}
+	lockCachedUsers sync.RWMutex
+	lockForget      sync.RWMutex
+}
+
+// CachedUsers calls CachedUsersFunc.
+func (mock *TransformCacheMock) CachedUsers() []uuid.UUID {
+	if mock.CachedUsersFunc == nil {
+		panic("TransformCacheMock.CachedUsersFunc: method is nil but TransformCache.CachedUsers was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockCachedUsers.Lock()
+	mock.calls.CachedUsers = append(mock.calls.CachedUsers, callInfo)
+	mock.lockCachedUsers.Unlock()
+	return mock.CachedUsersFunc()
+}
+
+// CachedUsersCalls gets all the calls that were made to CachedUsers.
+// Check the length with:
+//
+//	len(mockedTransformCache.CachedUsersCalls())
+func (mock *TransformCacheMock) CachedUsersCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockCachedUsers.RLock()
+	calls = mock.calls.CachedUsers
+	mock.lockCachedUsers.RUnlock()
+	return calls
+}
+
+// Forget calls ForgetFunc.
+func (mock *TransformCacheMock) Forget(userIDs ...uuid.UUID) {
+	if mock.ForgetFunc == nil {
+		panic("TransformCacheMock.ForgetFunc: method is nil but TransformCache.Forget was just called")
+	}
+	callInfo := struct {
+		UserIDs []uuid.UUID
+	}{
+		UserIDs: userIDs,
+	}
+	mock.lockForget.Lock()
+	mock.calls.Forget = append(mock.calls.Forget, callInfo)
+	mock.lockForget.Unlock()
+	mock.ForgetFunc(userIDs...)
+}
+
+// ForgetCalls gets all the calls that were made to Forget.
+// Check the length with:
+//
+//	len(mockedTransformCache.ForgetCalls())
+func (mock *TransformCacheMock) ForgetCalls() []struct {
+	UserIDs []uuid.UUID
+} {
+	var calls []struct {
+		UserIDs []uuid.UUID
+	}
+	mock.lockForget.RLock()
+	calls = mock.calls.Forget
+	mock.lockForget.RUnlock()
+	return calls
+}
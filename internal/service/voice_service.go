@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+)
+
+var (
+	// ErrVoiceProfileNotFound is returned for unknown IDs and for profiles owned by someone else.
+	ErrVoiceProfileNotFound = errors.New("voice profile not found")
+	// ErrInvalidVoiceProfile is wrapped by every voice profile validation failure.
+	ErrInvalidVoiceProfile = errors.New("invalid voice profile")
+)
+
+const (
+	minVoiceExamples = 5
+	maxVoiceExamples = 10
+)
+
+// VoiceServiceInteractor defines the operations on voice profiles.
+type VoiceServiceInteractor interface {
+	Create(ctx context.Context, userID uuid.UUID, name string, examples []string) (*model.VoiceProfile, error)
+	Get(ctx context.Context, userID, id uuid.UUID) (*model.VoiceProfile, error)
+	List(ctx context.Context, userID uuid.UUID) ([]model.VoiceProfile, error)
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+}
+
+type VoiceService struct {
+	ai       ai.Client
+	voices   repository.VoiceRepository
+	settings RuntimeSettingsSource
+}
+
+// VoiceOption configures a VoiceService.
+type VoiceOption func(*VoiceService)
+
+// WithVoiceSettings applies the PII policy of the runtime settings to the
+// sample posts sent for description. Without it they are redacted.
+func WithVoiceSettings(src RuntimeSettingsSource) VoiceOption {
+	return func(v *VoiceService) { v.settings = src }
+}
+
+// NewVoice creates a new VoiceService instance.
+func NewVoice(ai ai.Client, vr repository.VoiceRepository, opts ...VoiceOption) VoiceServiceInteractor {
+	v := &VoiceService{ai: ai, voices: vr}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// Create stores a voice profile for the sample posts after having the AI
+// client summarize them into style guidance.
+func (v *VoiceService) Create(ctx context.Context, userID uuid.UUID, name string, examples []string) (*model.VoiceProfile, error) {
+	name = strings.TrimSpace(name)
+	cleaned := make([]string, 0, len(examples))
+	for _, ex := range examples {
+		if ex = strings.TrimSpace(ex); ex != "" {
+			cleaned = append(cleaned, ex)
+		}
+	}
+
+	switch {
+	case name == "":
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidVoiceProfile)
+	case len(cleaned) < minVoiceExamples || len(cleaned) > maxVoiceExamples:
+		return nil, fmt.Errorf("%w: provide between %d and %d example posts, got %d", ErrInvalidVoiceProfile, minVoiceExamples, maxVoiceExamples, len(cleaned))
+	}
+	for i, ex := range cleaned {
+		if utf8.RuneCountInString(ex) > LinkedInMaxPostLength {
+			return nil, fmt.Errorf("%w: example %d is longer than %d characters", ErrInvalidVoiceProfile, i+1, LinkedInMaxPostLength)
+		}
+	}
+
+	// The samples are stored as written; they are redacted again whenever
+	// they are sent as examples. The guidance is not restored, so it never
+	// holds personal data.
+	samples := slices.Clone(cleaned)
+	sampleRefs := make([]*string, len(samples))
+	for i := range samples {
+		sampleRefs[i] = &samples[i]
+	}
+	if _, err := protect(ctx, v.settings, sampleRefs...); err != nil {
+		return nil, err
+	}
+	guidance, err := v.ai.DescribeVoice(ctx, samples)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &model.VoiceProfile{
+		ID:       uuid.New(),
+		UserID:   userID,
+		Name:     name,
+		Examples: cleaned,
+		Guidance: strings.TrimSpace(guidance),
+	}
+	if err := v.voices.Create(ctx, profile); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+func (v *VoiceService) Get(ctx context.Context, userID, id uuid.UUID) (*model.VoiceProfile, error) {
+	return findVoice(ctx, v.voices, userID, id)
+}
+
+func (v *VoiceService) List(ctx context.Context, userID uuid.UUID) ([]model.VoiceProfile, error) {
+	return v.voices.ListByUser(ctx, userID)
+}
+
+func (v *VoiceService) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	found, err := v.voices.Delete(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrVoiceProfileNotFound
+	}
+	return nil
+}
+
+func findVoice(ctx context.Context, voices repository.VoiceRepository, userID, id uuid.UUID) (*model.VoiceProfile, error) {
+	vp, err := voices.FindByID(ctx, userID, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrVoiceProfileNotFound
+	}
+	return vp, err
+}
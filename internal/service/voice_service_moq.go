@@ -0,0 +1,257 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/you/linkedinify/internal/model"
+	"sync"
+)
+
+// Ensure, that VoiceServiceInteractorMock does implement VoiceServiceInteractor.
+// If this is not the case, regenerate this file with moq.
+var _ VoiceServiceInteractor = &VoiceServiceInteractorMock{}
+
+// VoiceServiceInteractorMock is a mock implementation of VoiceServiceInteractor.
+//
+//	func TestSomethingThatUsesVoiceServiceInteractor(t *testing.T) {
+//
+//		// make and configure a mocked VoiceServiceInteractor
+//		mockedVoiceServiceInteractor := &VoiceServiceInteractorMock{
+//			CreateFunc: func(ctx context.Context, userID uuid.UUID, name string, examples []string) (*model.VoiceProfile, error) {
+//				panic("mock out the Create method")
+//			},
+//			DeleteFunc: func(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+//				panic("mock out the Delete method")
+//			},
+//			GetFunc: func(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*model.VoiceProfile, error) {
+//				panic("mock out the Get method")
+//			},
+//			ListFunc: func(ctx context.Context, userID uuid.UUID) ([]model.VoiceProfile, error) {
+//				panic("mock out the List method")
+//			},
+//		}
+//
+//		// use mockedVoiceServiceInteractor in code that requires VoiceServiceInteractor
+//		// and then make assertions.
+//
+//	}
+type VoiceServiceInteractorMock struct {
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, userID uuid.UUID, name string, examples []string) (*model.VoiceProfile, error)
+
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
+
+	// GetFunc mocks the Get method.
+	GetFunc func(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*model.VoiceProfile, error)
+
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context, userID uuid.UUID) ([]model.VoiceProfile, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Name is the name argument value.
+			Name string
+			// Examples is the examples argument value.
+			Examples []string
+		}
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+	}
+	lockCreate sync.RWMutex
+	lockDelete sync.RWMutex
+	lockGet    sync.RWMutex
+	lockList   sync.RWMutex
+}
+
+// Create calls CreateFunc.
+func (mock *VoiceServiceInteractorMock) Create(ctx context.Context, userID uuid.UUID, name string, examples []string) (*model.VoiceProfile, error) {
+	if mock.CreateFunc == nil {
+		panic("VoiceServiceInteractorMock.CreateFunc: method is nil but VoiceServiceInteractor.Create was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		Name     string
+		Examples []string
+	}{
+		Ctx:      ctx,
+		UserID:   userID,
+		Name:     name,
+		Examples: examples,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	return mock.CreateFunc(ctx, userID, name, examples)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedVoiceServiceInteractor.CreateCalls())
+func (mock *VoiceServiceInteractorMock) CreateCalls() []struct {
+	Ctx      context.Context
+	UserID   uuid.UUID
+	Name     string
+	Examples []string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		Name     string
+		Examples []string
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// Delete calls DeleteFunc.
+func (mock *VoiceServiceInteractorMock) Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	if mock.DeleteFunc == nil {
+		panic("VoiceServiceInteractorMock.DeleteFunc: method is nil but VoiceServiceInteractor.Delete was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		ID:     id,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(ctx, userID, id)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedVoiceServiceInteractor.DeleteCalls())
+func (mock *VoiceServiceInteractorMock) DeleteCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	ID     uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// Get calls GetFunc.
+func (mock *VoiceServiceInteractorMock) Get(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*model.VoiceProfile, error) {
+	if mock.GetFunc == nil {
+		panic("VoiceServiceInteractorMock.GetFunc: method is nil but VoiceServiceInteractor.Get was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		ID:     id,
+	}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	return mock.GetFunc(ctx, userID, id)
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedVoiceServiceInteractor.GetCalls())
+func (mock *VoiceServiceInteractorMock) GetCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	ID     uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// List calls ListFunc.
+func (mock *VoiceServiceInteractorMock) List(ctx context.Context, userID uuid.UUID) ([]model.VoiceProfile, error) {
+	if mock.ListFunc == nil {
+		panic("VoiceServiceInteractorMock.ListFunc: method is nil but VoiceServiceInteractor.List was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(ctx, userID)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedVoiceServiceInteractor.ListCalls())
+func (mock *VoiceServiceInteractorMock) ListCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
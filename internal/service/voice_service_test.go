@@ -0,0 +1,91 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/service"
+)
+
+var sampleVoiceExamples = []string{"post one", "post two", " ", "post three", "post four", "post five"}
+
+func TestVoiceService_Create_SummarizesExamples(t *testing.T) {
+	userID := uuid.New()
+	mockAIClient := &ai.ClientMock{
+		DescribeVoiceFunc: func(ctx context.Context, examples []string) (string, error) {
+			assert.Len(t, examples, 5, "blank examples are dropped")
+			return "  - Short sentences\n- No emojis  ", nil
+		},
+	}
+	mockVoiceRepo := &repository.VoiceRepositoryMock{
+		CreateFunc: func(ctx context.Context, v *model.VoiceProfile) error { return nil },
+	}
+	voiceSvc := service.NewVoice(mockAIClient, mockVoiceRepo)
+
+	v, err := voiceSvc.Create(context.Background(), userID, " Founder voice ", sampleVoiceExamples)
+	require.NoError(t, err)
+	assert.Equal(t, "Founder voice", v.Name)
+	assert.Equal(t, userID, v.UserID)
+	assert.Equal(t, "- Short sentences\n- No emojis", v.Guidance)
+	assert.Len(t, mockVoiceRepo.CreateCalls(), 1)
+}
+
+func TestVoiceService_Create_TooFewExamples(t *testing.T) {
+	mockAIClient := &ai.ClientMock{}
+	voiceSvc := service.NewVoice(mockAIClient, &repository.VoiceRepositoryMock{})
+
+	_, err := voiceSvc.Create(context.Background(), uuid.New(), "voice", []string{"only one"})
+	assert.ErrorIs(t, err, service.ErrInvalidVoiceProfile)
+	assert.Len(t, mockAIClient.DescribeVoiceCalls(), 0)
+}
+
+func TestVoiceService_Get_NotFound(t *testing.T) {
+	mockVoiceRepo := &repository.VoiceRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, userID, id uuid.UUID) (*model.VoiceProfile, error) {
+			return nil, sql.ErrNoRows
+		},
+	}
+	voiceSvc := service.NewVoice(&ai.ClientMock{}, mockVoiceRepo)
+
+	_, err := voiceSvc.Get(context.Background(), uuid.New(), uuid.New())
+	assert.ErrorIs(t, err, service.ErrVoiceProfileNotFound)
+}
+
+func TestLinkedInService_Transform_WithVoiceProfile(t *testing.T) {
+	userID := uuid.New()
+	voiceID := uuid.New()
+	mockVoiceRepo := &repository.VoiceRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, uid, id uuid.UUID) (*model.VoiceProfile, error) {
+			assert.Equal(t, userID, uid)
+			assert.Equal(t, voiceID, id)
+			return &model.VoiceProfile{ID: id, UserID: uid, Guidance: "terse", Examples: []string{"ex1", "ex2"}}, nil
+		},
+	}
+	mockAIClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, text string, opts ai.Options) (string, error) {
+			return "voiced post", nil
+		},
+	}
+	mockPostRepo := &repository.PostRepositoryMock{
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo, service.WithVoices(mockVoiceRepo))
+
+	_, err := liSvc.Transform(context.Background(), userID, "text", service.TransformOptions{VoiceProfileID: voiceID})
+	require.NoError(t, err)
+	require.Len(t, mockAIClient.TransformCalls(), 1)
+	assert.Equal(t, ai.Voice{Guidance: "terse", Examples: []string{"ex1", "ex2"}}, mockAIClient.TransformCalls()[0].Opts.Voice)
+
+	// The same text without the voice must not be served from the voiced cache entry.
+	_, err = liSvc.Transform(context.Background(), userID, "text", service.TransformOptions{})
+	require.NoError(t, err)
+	assert.Len(t, mockAIClient.TransformCalls(), 2)
+}
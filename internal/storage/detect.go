@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+)
+
+// sniffLen is how much of a blob DetectContentType looks at.
+const sniffLen = 512
+
+// vague are types sniffed from content that say too little; the key's
+// extension is trusted over them.
+var vague = map[string]bool{
+	"application/octet-stream":  true,
+	"text/plain; charset=utf-8": true,
+	"text/xml; charset=utf-8":   true,
+}
+
+// The types of files the API exports, which not every system's MIME table
+// knows.
+func init() {
+	for ext, t := range map[string]string{
+		".csv":   "text/csv; charset=utf-8",
+		".jsonl": "application/x-ndjson",
+		".md":    "text/markdown; charset=utf-8",
+		".zip":   "application/zip",
+	} {
+		mime.AddExtensionType(ext, t)
+	}
+}
+
+// DetectContentType names the type of a blob from the first bytes of its
+// content, head, the type it was declared as, which may be empty, and its
+// key. Content wins, so an HTML page declared or named as a PNG is not
+// served as an image; only where content says little, as for text, CSV or
+// JSON, does the declared type decide, and failing that the extension.
+func DetectContentType(key string, head []byte, declared string) string {
+	if len(head) > sniffLen {
+		head = head[:sniffLen]
+	}
+	sniffed := http.DetectContentType(head)
+	if !vague[sniffed] {
+		return sniffed
+	}
+	if isSVG(head) {
+		return "image/svg+xml"
+	}
+	if declared != "" {
+		return declared
+	}
+	if t := mime.TypeByExtension(path.Ext(key)); t != "" {
+		return t
+	}
+	return sniffed
+}
+
+// isSVG reports whether head starts an SVG document, optionally after an
+// XML declaration or doctype.
+func isSVG(head []byte) bool {
+	rest := bytes.TrimSpace(head)
+	for bytes.HasPrefix(rest, []byte("<?")) || bytes.HasPrefix(rest, []byte("<!")) {
+		end := bytes.IndexByte(rest, '>')
+		if end < 0 {
+			return false
+		}
+		rest = bytes.TrimSpace(rest[end+1:])
+	}
+	return bytes.HasPrefix(rest, []byte("<svg"))
+}
+
+// sniff returns the content type of r's blob and a reader of all of it.
+func sniff(key string, r io.Reader, declared string) (string, io.Reader, error) {
+	br := bufio.NewReaderSize(r, sniffLen)
+	head, err := br.Peek(sniffLen)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return "", nil, err
+	}
+	return DetectContentType(key, head, declared), br, nil
+}
@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Local stores blobs as files under a directory. It keeps no metadata, so
+// the content type of a blob is detected from its content and key each
+// time it is opened; the type given to Put is not kept.
+type Local struct {
+	dir string
+}
+
+// NewLocal stores blobs under dir, creating it if needed.
+func NewLocal(dir string) (*Local, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	return &Local{dir: dir}, nil
+}
+
+func (l *Local) path(key string) (string, error) {
+	if err := CheckKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(key)), nil
+}
+
+// Put writes the blob to a temporary file first, so readers never see a
+// partly written blob.
+func (l *Local) Put(ctx context.Context, key string, r io.Reader, contentType string) (*Blob, error) {
+	p, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return nil, err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".put-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name()) // fails harmlessly once renamed
+
+	contentType, r, err = sniff(key, r, "")
+	if err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	n, err := io.Copy(tmp, r)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp.Name(), p); err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(p)
+	if err != nil {
+		return nil, err
+	}
+	return &Blob{Key: key, ContentType: contentType, Size: n, ModTime: info.ModTime()}, nil
+}
+
+func (l *Local) Open(ctx context.Context, key string) (io.ReadCloser, *Blob, error) {
+	p, err := l.path(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := os.Open(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	contentType, r, err := sniff(key, f, "")
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	rc := struct {
+		io.Reader
+		io.Closer
+	}{r, f}
+	return rc, &Blob{Key: key, ContentType: contentType, Size: info.Size(), ModTime: info.ModTime()}, nil
+}
+
+func (l *Local) Delete(ctx context.Context, key string) error {
+	p, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// List walks the directory of prefix's last complete segment, skipping
+// blobs being written.
+func (l *Local) List(ctx context.Context, prefix string) ([]Blob, error) {
+	root := filepath.Join(l.dir, filepath.FromSlash(path.Dir("/"+prefix)))
+	var blobs []Blob
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".put-") {
+			return nil
+		}
+		rel, err := filepath.Rel(l.dir, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		blobs = append(blobs, Blob{Key: key, Size: info.Size(), ModTime: info.ModTime()})
+		return nil
+	})
+	return blobs, err
+}
@@ -0,0 +1,49 @@
+package storage_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/storage"
+)
+
+func TestLocal_PutOpenDelete(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	blob, err := store.Put(ctx, "u1/images/a.svg", strings.NewReader("<svg/>"), "image/svg+xml")
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), blob.Size)
+
+	rc, blob, err := store.Open(ctx, "u1/images/a.svg")
+	require.NoError(t, err)
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+	assert.Equal(t, "<svg/>", string(data))
+	assert.Equal(t, "image/svg+xml", blob.ContentType)
+
+	_, err = store.Put(ctx, "u1/images/a.svg", strings.NewReader("<svg></svg>"), "")
+	require.NoError(t, err, "putting again replaces")
+
+	require.NoError(t, store.Delete(ctx, "u1/images/a.svg"))
+	require.NoError(t, store.Delete(ctx, "u1/images/a.svg"), "deleting twice is fine")
+	_, _, err = store.Open(ctx, "u1/images/a.svg")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestLocal_RejectsKeysOutsideTheStore(t *testing.T) {
+	store, err := storage.NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "/etc/passwd", "../x", "a/../../x", "a//b", "a/./b", ".."} {
+		_, err := store.Put(context.Background(), key, strings.NewReader("x"), "")
+		assert.ErrorIs(t, err, storage.ErrInvalidKey, key)
+	}
+}
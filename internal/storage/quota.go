@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// Quota limits the total size of each owner's blobs in a store, see Owner.
+// Usage is counted when a blob is put, so concurrent puts by one owner can
+// overshoot the limit by what they write together.
+type Quota struct {
+	BlobStore
+	limit int64
+}
+
+// NewQuota allows each owner limit bytes in store; 0 means no limit.
+func NewQuota(store BlobStore, limit int64) *Quota {
+	return &Quota{BlobStore: store, limit: limit}
+}
+
+// Limit is the bytes allowed per owner, 0 for no limit.
+func (q *Quota) Limit() int64 { return q.limit }
+
+// Usage is the total size of owner's blobs.
+func (q *Quota) Usage(ctx context.Context, owner string) (int64, error) {
+	return Usage(ctx, q.BlobStore, owner+"/")
+}
+
+// Put stores the blob if it fits in its owner's quota, counting the blob
+// it replaces as freed. A blob that does not fit fails with
+// ErrQuotaExceeded once its content passes the limit, and is not stored.
+func (q *Quota) Put(ctx context.Context, key string, r io.Reader, contentType string) (*Blob, error) {
+	if q.limit <= 0 {
+		return q.BlobStore.Put(ctx, key, r, contentType)
+	}
+	if err := CheckKey(key); err != nil {
+		return nil, err
+	}
+	blobs, err := q.BlobStore.List(ctx, Owner(key)+"/")
+	if err != nil {
+		return nil, err
+	}
+	free := q.limit
+	for _, b := range blobs {
+		if b.Key != key {
+			free -= b.Size
+		}
+	}
+	return q.BlobStore.Put(ctx, key, &quotaReader{r: r, left: free}, contentType)
+}
+
+// quotaReader fails with ErrQuotaExceeded once more than left bytes are
+// read.
+type quotaReader struct {
+	r    io.Reader
+	left int64
+}
+
+func (q *quotaReader) Read(p []byte) (int, error) {
+	n, err := q.r.Read(p)
+	q.left -= int64(n)
+	if q.left < 0 {
+		return n, fmt.Errorf("%w: the blob does not fit", ErrQuotaExceeded)
+	}
+	return n, err
+}
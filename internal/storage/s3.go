@@ -0,0 +1,211 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// S3Config locates a bucket of an S3-compatible service such as AWS S3,
+// MinIO or Cloudflare R2.
+type S3Config struct {
+	// Endpoint is the service's base URL, like "https://s3.eu-west-1.amazonaws.com"
+	// or "http://minio:9000". The bucket is addressed in the path.
+	Endpoint        string
+	Bucket          string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// Client defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// S3 stores blobs as objects in a bucket, signing requests with AWS
+// Signature Version 4. Objects keep the content type detected on Put.
+type S3 struct {
+	cfg      S3Config
+	endpoint *url.URL
+	client   *http.Client
+}
+
+// NewS3 creates an S3 store. It does not contact the service.
+func NewS3(cfg S3Config) (*S3, error) {
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("s3: endpoint must be an http or https URL, got %q", cfg.Endpoint)
+	}
+	if cfg.Bucket == "" {
+		return nil, errors.New("s3: bucket is required")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	client := cfg.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	u.RawPath = ""
+	return &S3{cfg: cfg, endpoint: u, client: client}, nil
+}
+
+// Put uploads the blob in one request. S3 needs its length and hash up
+// front, so it is spooled to a temporary file first.
+func (s *S3) Put(ctx context.Context, key string, r io.Reader, contentType string) (*Blob, error) {
+	if err := CheckKey(key); err != nil {
+		return nil, err
+	}
+	contentType, r, err := sniff(key, r, contentType)
+	if err != nil {
+		return nil, err
+	}
+	tmp, err := os.CreateTemp("", "linkedinify-s3-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tmp, h), r)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(ctx, http.MethodPut, s.path(key), nil, tmp, n, hex.EncodeToString(h.Sum(nil)), contentType)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, s3Error(resp, key)
+	}
+	return &Blob{Key: key, ContentType: contentType, Size: n, ModTime: time.Now()}, nil
+}
+
+func (s *S3) Open(ctx context.Context, key string) (io.ReadCloser, *Blob, error) {
+	if err := CheckKey(key); err != nil {
+		return nil, nil, err
+	}
+	resp, err := s.do(ctx, http.MethodGet, s.path(key), nil, nil, 0, emptyHash, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, nil, s3Error(resp, key)
+	}
+	blob := &Blob{Key: key, ContentType: resp.Header.Get("Content-Type"), Size: resp.ContentLength}
+	blob.ModTime, _ = http.ParseTime(resp.Header.Get("Last-Modified"))
+	return resp.Body, blob, nil
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	if err := CheckKey(key); err != nil {
+		return err
+	}
+	resp, err := s.do(ctx, http.MethodDelete, s.path(key), nil, nil, 0, emptyHash, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	}
+	return s3Error(resp, key)
+}
+
+// List pages through ListObjectsV2.
+func (s *S3) List(ctx context.Context, prefix string) ([]Blob, error) {
+	var blobs []Blob
+	params := map[string]string{"list-type": "2", "prefix": prefix}
+	for {
+		resp, err := s.do(ctx, http.MethodGet, s.path(""), params, nil, 0, emptyHash, "")
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			err := s3Error(resp, prefix)
+			resp.Body.Close()
+			return nil, err
+		}
+		var page struct {
+			Contents []struct {
+				Key          string
+				Size         int64
+				LastModified time.Time
+			}
+			IsTruncated           bool
+			NextContinuationToken string
+		}
+		err = xml.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("s3: listing %q: %w", prefix, err)
+		}
+		for _, c := range page.Contents {
+			blobs = append(blobs, Blob{Key: c.Key, Size: c.Size, ModTime: c.LastModified})
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return blobs, nil
+		}
+		params["continuation-token"] = page.NextContinuationToken
+	}
+}
+
+// path is the bucket, or key's object in it, below the endpoint's path.
+func (s *S3) path(key string) string {
+	p := s.endpoint.Path + "/" + s.cfg.Bucket
+	if key != "" {
+		p += "/" + key
+	}
+	return p
+}
+
+func (s *S3) do(ctx context.Context, method, path string, params map[string]string, body io.Reader, size int64, payloadHash, contentType string) (*http.Response, error) {
+	u := *s.endpoint
+	u.Path, u.RawPath = path, uriEncode(path, true)
+	u.RawQuery = canonicalQuery(params)
+	if size == 0 {
+		body = nil // an empty non-nil body would be sent chunked, which S3 refuses
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	signV4(req, payloadHash, s.cfg.AccessKeyID, s.cfg.SecretAccessKey, s.cfg.Region, time.Now())
+	return s.client.Do(req)
+}
+
+// s3Error turns an error response into ErrNotFound for missing objects, or
+// an error with the service's code and message.
+func s3Error(resp *http.Response, key string) error {
+	var e struct {
+		Code    string
+		Message string
+	}
+	xml.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&e)
+	if resp.StatusCode == http.StatusNotFound && (e.Code == "NoSuchKey" || e.Code == "") {
+		return ErrNotFound
+	}
+	if e.Code == "" {
+		e.Code = resp.Status
+	}
+	return fmt.Errorf("s3: %q: %s: %s", key, e.Code, e.Message)
+}
@@ -0,0 +1,185 @@
+package storage_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/storage"
+)
+
+// fakeS3 is a stand-in for an S3-compatible service such as MinIO: one
+// bucket, path-style addressing, and ListObjectsV2 with pages of two keys.
+// It checks that requests are signed by the expected key and that uploads
+// match their declared hash.
+type fakeS3 struct {
+	t       *testing.T
+	bucket  string
+	mu      sync.Mutex
+	objects map[string]fakeObject
+}
+
+type fakeObject struct {
+	data        []byte
+	contentType string
+	modified    time.Time
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	f := &fakeS3{t: t, bucket: "blobs", objects: map[string]fakeObject{}}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func (f *fakeS3) fail(w http.ResponseWriter, status int, code string) {
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>%s</Code><Message>%s</Message></Error>`, code, code)
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=minio/") ||
+		!strings.Contains(r.Header.Get("Authorization"), "/eu-central-1/s3/aws4_request") {
+		f.fail(w, http.StatusForbidden, "AccessDenied")
+		return
+	}
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != f.bucket {
+		f.fail(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		sum := sha256.Sum256(data)
+		if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(sum[:]) || r.ContentLength != int64(len(data)) {
+			f.fail(w, http.StatusBadRequest, "XAmzContentSHA256Mismatch")
+			return
+		}
+		f.objects[key] = fakeObject{data: data, contentType: r.Header.Get("Content-Type"), modified: time.Now().UTC().Truncate(time.Second)}
+	case r.Method == http.MethodGet && key == "":
+		f.list(w, r)
+	case r.Method == http.MethodGet:
+		obj, ok := f.objects[key]
+		if !ok {
+			f.fail(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("Content-Type", obj.contentType)
+		w.Header().Set("Last-Modified", obj.modified.Format(http.TimeFormat))
+		w.Write(obj.data)
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		f.fail(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+func (f *fakeS3) list(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	require.Equal(f.t, "2", q.Get("list-type"))
+	var keys []string
+	for k := range f.objects {
+		if strings.HasPrefix(k, q.Get("prefix")) && k > q.Get("continuation-token") {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	type content struct {
+		Key          string
+		Size         int
+		LastModified string
+	}
+	res := struct {
+		XMLName               xml.Name `xml:"ListBucketResult"`
+		Contents              []content
+		IsTruncated           bool
+		NextContinuationToken string `xml:",omitempty"`
+	}{}
+	if len(keys) > 2 {
+		keys = keys[:2]
+		res.IsTruncated, res.NextContinuationToken = true, keys[1]
+	}
+	for _, k := range keys {
+		res.Contents = append(res.Contents, content{Key: k, Size: len(f.objects[k].data), LastModified: f.objects[k].modified.Format("2006-01-02T15:04:05.000Z")})
+	}
+	xml.NewEncoder(w).Encode(res)
+}
+
+func newS3(t *testing.T, endpoint, bucket string) *storage.S3 {
+	t.Helper()
+	s, err := storage.NewS3(storage.S3Config{Endpoint: endpoint, Bucket: bucket, Region: "eu-central-1", AccessKeyID: "minio", SecretAccessKey: "minio123"})
+	require.NoError(t, err)
+	return s
+}
+
+func TestS3_PutOpenListDelete(t *testing.T) {
+	ctx := context.Background()
+	fake, srv := newFakeS3(t)
+	store := newS3(t, srv.URL, "blobs")
+
+	blob, err := store.Put(ctx, "u1/images/a b.svg", strings.NewReader(`<svg xmlns="http://www.w3.org/2000/svg"/>`), "")
+	require.NoError(t, err)
+	assert.Equal(t, "image/svg+xml", blob.ContentType, "detected")
+	assert.Equal(t, "image/svg+xml", fake.objects["u1/images/a b.svg"].contentType)
+	_, err = store.Put(ctx, "u1/exports/posts.csv", strings.NewReader("id,text\n"), "text/csv")
+	require.NoError(t, err)
+	_, err = store.Put(ctx, "u1/exports/empty.txt", strings.NewReader(""), "")
+	require.NoError(t, err, "empty blobs are sent with a length")
+	_, err = store.Put(ctx, "u2/images/b.png", strings.NewReader("png"), "image/png")
+	require.NoError(t, err)
+
+	rc, blob, err := store.Open(ctx, "u1/images/a b.svg")
+	require.NoError(t, err)
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	assert.Equal(t, `<svg xmlns="http://www.w3.org/2000/svg"/>`, string(data))
+	assert.Equal(t, int64(len(data)), blob.Size)
+	assert.False(t, blob.ModTime.IsZero())
+
+	blobs, err := store.List(ctx, "u1/")
+	require.NoError(t, err)
+	var keys []string
+	for _, b := range blobs {
+		keys = append(keys, b.Key)
+	}
+	assert.Equal(t, []string{"u1/exports/empty.txt", "u1/exports/posts.csv", "u1/images/a b.svg"}, keys, "all pages")
+
+	require.NoError(t, store.Delete(ctx, "u1/images/a b.svg"))
+	require.NoError(t, store.Delete(ctx, "u1/images/a b.svg"))
+	_, _, err = store.Open(ctx, "u1/images/a b.svg")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestS3_Errors(t *testing.T) {
+	ctx := context.Background()
+	_, srv := newFakeS3(t)
+
+	_, err := newS3(t, srv.URL, "missing").Put(ctx, "u1/a.txt", strings.NewReader("x"), "")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, storage.ErrNotFound)
+	assert.Contains(t, err.Error(), "NoSuchBucket")
+
+	_, err = newS3(t, srv.URL, "blobs").Put(ctx, "../a.txt", strings.NewReader("x"), "")
+	assert.ErrorIs(t, err, storage.ErrInvalidKey)
+
+	_, err = storage.NewS3(storage.S3Config{Endpoint: "minio:9000", Bucket: "blobs"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSignature is returned for download links that were not signed
+// with the secret, were altered or have expired.
+var ErrInvalidSignature = errors.New("invalid or expired signature")
+
+// URLSigner makes download links for blobs that work without logging in
+// until they expire. Links are the base path followed by the key, with the
+// expiry and an HMAC-SHA256 of both in the query.
+type URLSigner struct {
+	secret []byte
+	base   string
+	ttl    time.Duration
+}
+
+// NewURLSigner signs links under base, such as "/api/v1/blobs", that are
+// valid for ttl.
+func NewURLSigner(secret []byte, base string, ttl time.Duration) *URLSigner {
+	return &URLSigner{secret: secret, base: strings.TrimSuffix(base, "/"), ttl: ttl}
+}
+
+// URL returns a download link for key and when it expires.
+func (s *URLSigner) URL(key string, now time.Time) (string, time.Time) {
+	expires := now.Add(s.ttl).Truncate(time.Second)
+	exp := strconv.FormatInt(expires.Unix(), 10)
+	q := url.Values{"expires": {exp}, "signature": {hex.EncodeToString(s.mac(key, exp))}}
+	return s.base + "/" + (&url.URL{Path: key}).EscapedPath() + "?" + q.Encode(), expires
+}
+
+// Verify checks the expires and signature query parameters of a link to
+// key.
+func (s *URLSigner) Verify(key string, query url.Values, now time.Time) error {
+	exp := query.Get("expires")
+	unix, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: bad expiry", ErrInvalidSignature)
+	}
+	got, err := hex.DecodeString(query.Get("signature"))
+	if err != nil || !hmac.Equal(got, s.mac(key, exp)) {
+		return ErrInvalidSignature
+	}
+	if now.After(time.Unix(unix, 0)) {
+		return fmt.Errorf("%w: expired", ErrInvalidSignature)
+	}
+	return nil
+}
+
+func (s *URLSigner) mac(key, exp string) []byte {
+	m := hmac.New(sha256.New, s.secret)
+	m.Write([]byte(key + "\n" + exp))
+	return m.Sum(nil)
+}
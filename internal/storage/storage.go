@@ -0,0 +1,95 @@
+// Package storage keeps files such as images and exports in a blob store,
+// on local disk or in an S3-compatible bucket. Blobs are addressed by
+// slash-separated keys like "<user id>/images/<image id>.svg", whose first
+// segment is the owner that quotas are counted against.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned for keys that hold no blob.
+	ErrNotFound = errors.New("blob not found")
+	// ErrInvalidKey is returned for keys that are empty, absolute or
+	// leave their prefix with "..".
+	ErrInvalidKey = errors.New("invalid blob key")
+	// ErrQuotaExceeded is returned by Quota when a blob does not fit in its
+	// owner's quota.
+	ErrQuotaExceeded = errors.New("storage quota exceeded")
+)
+
+// Blob describes a stored blob.
+type Blob struct {
+	Key         string
+	ContentType string
+	Size        int64
+	ModTime     time.Time
+}
+
+// BlobStore stores blobs by key. Putting a key that exists replaces its
+// blob.
+type BlobStore interface {
+	// Put stores the blob. Its content type is detected, with contentType,
+	// which may be empty, as the declared type; see DetectContentType.
+	Put(ctx context.Context, key string, r io.Reader, contentType string) (*Blob, error)
+	// Open returns the blob's content, which the caller must close.
+	Open(ctx context.Context, key string) (io.ReadCloser, *Blob, error)
+	// Delete removes a blob. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+	// List returns the blobs whose keys start with prefix, in key order.
+	// Their ContentType may be empty.
+	List(ctx context.Context, prefix string) ([]Blob, error)
+}
+
+// CheckKey returns ErrInvalidKey, wrapped, unless key is a clean relative
+// path.
+func CheckKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || key == ".." || strings.HasPrefix(key, "../") {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return nil
+}
+
+// Owner is the first segment of key, which quotas are counted against.
+func Owner(key string) string {
+	owner, _, _ := strings.Cut(key, "/")
+	return owner
+}
+
+// Usage is the total size of the blobs whose keys start with prefix.
+func Usage(ctx context.Context, store BlobStore, prefix string) (int64, error) {
+	blobs, err := store.List(ctx, prefix)
+	if err != nil {
+		return 0, err
+	}
+	var n int64
+	for _, b := range blobs {
+		n += b.Size
+	}
+	return n, nil
+}
+
+// DeletePrefix removes every blob whose key starts with prefix, such as all
+// blobs of a user. It returns the number removed.
+func DeletePrefix(ctx context.Context, store BlobStore, prefix string) (int, error) {
+	if prefix == "" {
+		return 0, fmt.Errorf("%w: refusing to delete every blob", ErrInvalidKey)
+	}
+	blobs, err := store.List(ctx, prefix)
+	if err != nil {
+		return 0, err
+	}
+	for i, b := range blobs {
+		if err := store.Delete(ctx, b.Key); err != nil {
+			return i, err
+		}
+	}
+	return len(blobs), nil
+}
@@ -0,0 +1,138 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/storage"
+)
+
+func TestDetectContentType(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	for _, tc := range []struct {
+		key      string
+		head     []byte
+		declared string
+		want     string
+	}{
+		{"a.bin", png, "", "image/png"},
+		{"a.txt", png, "", "image/png"},
+		{"a.png", []byte("<!DOCTYPE html><html><script>"), "", "text/html; charset=utf-8"},
+		{"a", []byte(`<?xml version="1.0"?>` + "\n" + `<svg xmlns="http://www.w3.org/2000/svg">`), "", "image/svg+xml"},
+		{"a.png", []byte(`<svg xmlns="http://www.w3.org/2000/svg">`), "", "image/svg+xml"},
+		{"posts.csv", []byte("id,text\n1,hi\n"), "", "text/csv; charset=utf-8"},
+		{"posts.jsonl", []byte(`{"id":1}`), "", "application/x-ndjson"},
+		{"blob", nil, "", "text/plain; charset=utf-8"},
+		{"a.png", []byte("<!DOCTYPE html>"), "image/png", "text/html; charset=utf-8"},
+		{"export", []byte("id,text\n"), "text/csv; charset=utf-8", "text/csv; charset=utf-8"},
+	} {
+		assert.Equal(t, tc.want, storage.DetectContentType(tc.key, tc.head, tc.declared), tc.key)
+	}
+}
+
+func TestLocal_List(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.NewLocal(t.TempDir())
+	require.NoError(t, err)
+	for _, key := range []string{"u1/images/a.png", "u1/exports/x.csv", "u10/images/b.png", "u2/c.txt"} {
+		_, err := store.Put(ctx, key, strings.NewReader("12345"), "")
+		require.NoError(t, err)
+	}
+
+	blobs, err := store.List(ctx, "u1/")
+	require.NoError(t, err)
+	var keys []string
+	for _, b := range blobs {
+		keys = append(keys, b.Key)
+		assert.Equal(t, int64(5), b.Size)
+	}
+	assert.Equal(t, []string{"u1/exports/x.csv", "u1/images/a.png"}, keys)
+
+	blobs, err = store.List(ctx, "u1")
+	require.NoError(t, err)
+	assert.Len(t, blobs, 3, "a prefix need not end at a slash")
+	blobs, err = store.List(ctx, "nobody/")
+	require.NoError(t, err)
+	assert.Empty(t, blobs)
+
+	n, err := storage.DeletePrefix(ctx, store, "u1/")
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	used, err := storage.Usage(ctx, store, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), used)
+	_, err = storage.DeletePrefix(ctx, store, "")
+	assert.ErrorIs(t, err, storage.ErrInvalidKey)
+}
+
+func TestQuota(t *testing.T) {
+	ctx := context.Background()
+	local, err := storage.NewLocal(t.TempDir())
+	require.NoError(t, err)
+	store := storage.NewQuota(local, 10)
+
+	_, err = store.Put(ctx, "u1/a.txt", strings.NewReader("123456"), "")
+	require.NoError(t, err)
+	_, err = store.Put(ctx, "u1/b.txt", strings.NewReader("12345"), "")
+	assert.ErrorIs(t, err, storage.ErrQuotaExceeded)
+	_, _, err = store.Open(ctx, "u1/b.txt")
+	assert.ErrorIs(t, err, storage.ErrNotFound, "nothing is left of a blob over quota")
+
+	_, err = store.Put(ctx, "u1/a.txt", strings.NewReader("1234567890"), "")
+	require.NoError(t, err, "a replaced blob's space is freed")
+	_, err = store.Put(ctx, "u2/a.txt", strings.NewReader("1234567890"), "")
+	require.NoError(t, err, "quotas are per owner")
+
+	used, err := store.Usage(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), used)
+
+	_, err = storage.NewQuota(local, 0).Put(ctx, "u1/c.txt", bytes.NewReader(make([]byte, 100)), "")
+	assert.NoError(t, err, "0 means no limit")
+}
+
+func TestURLSigner(t *testing.T) {
+	signer := storage.NewURLSigner([]byte("secret"), "/api/v1/blobs/", 15*time.Minute)
+	now := time.Unix(1700000000, 0)
+
+	link, expires := signer.URL("u1/images/a b.png", now)
+	assert.Equal(t, now.Add(15*time.Minute), expires)
+	require.True(t, strings.HasPrefix(link, "/api/v1/blobs/u1/images/a%20b.png?"), link)
+	u, err := url.Parse(link)
+	require.NoError(t, err)
+
+	assert.NoError(t, signer.Verify("u1/images/a b.png", u.Query(), now.Add(time.Minute)))
+	assert.ErrorIs(t, signer.Verify("u1/images/a b.png", u.Query(), now.Add(16*time.Minute)), storage.ErrInvalidSignature)
+	assert.ErrorIs(t, signer.Verify("u2/images/a b.png", u.Query(), now), storage.ErrInvalidSignature)
+
+	q := u.Query()
+	q.Set("expires", "9999999999")
+	assert.ErrorIs(t, signer.Verify("u1/images/a b.png", q, now), storage.ErrInvalidSignature)
+	assert.ErrorIs(t, storage.NewURLSigner([]byte("other"), "/", time.Minute).Verify("u1/images/a b.png", u.Query(), now), storage.ErrInvalidSignature)
+}
+
+func TestLocal_DetectsContentType(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	blob, err := store.Put(ctx, "u1/evil.png", strings.NewReader("<!DOCTYPE html><script>alert(1)</script>"), "image/png")
+	require.NoError(t, err)
+	assert.Equal(t, "text/html; charset=utf-8", blob.ContentType, "content wins over the key and the caller")
+
+	rc, blob, err := store.Open(ctx, "u1/evil.png")
+	require.NoError(t, err)
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	rc.Close()
+	assert.Equal(t, "text/html; charset=utf-8", blob.ContentType)
+	assert.Equal(t, "<!DOCTYPE html><script>alert(1)</script>", string(data), "sniffing does not eat the content")
+}
@@ -0,0 +1,16 @@
+-- migrations/002_account_deletion.sql
+alter table users
+  add column deletion_requested_at timestamptz,
+  add column delete_after timestamptz;
+
+create index users_delete_after_idx on users (delete_after) where delete_after is not null;
+
+create table audit_events (
+  id uuid primary key default uuid_generate_v4(),
+  user_id uuid not null references users(id) on delete cascade,
+  action text not null,
+  detail text not null default '',
+  created_at timestamptz default now()
+);
+
+create index audit_events_user_id_idx on audit_events (user_id, created_at);
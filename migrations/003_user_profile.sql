@@ -0,0 +1,10 @@
+-- migrations/003_user_profile.sql
+alter table users
+  add column display_name text not null default '',
+  add column job_title text not null default '',
+  add column industry text not null default '',
+  add column preferred_language text not null default '',
+  add column default_style text not null default '',
+  add column default_hashtags text[] not null default '{}',
+  add column signature text not null default '',
+  add column max_length integer not null default 0;
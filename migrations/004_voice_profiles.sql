@@ -0,0 +1,11 @@
+-- migrations/004_voice_profiles.sql
+create table voice_profiles (
+  id uuid primary key default uuid_generate_v4(),
+  user_id uuid not null references users(id) on delete cascade,
+  name text not null,
+  examples text[] not null,
+  guidance text not null,
+  created_at timestamptz default now()
+);
+
+create index voice_profiles_user_id_idx on voice_profiles (user_id);
@@ -0,0 +1,7 @@
+-- migrations/005_post_language.sql
+alter table linkedin_posts
+  add column language text not null default '',
+  add column source_post_id uuid references linkedin_posts(id) on delete cascade;
+
+create index linkedin_posts_user_language_idx on linkedin_posts (user_id, language);
+create index linkedin_posts_source_post_id_idx on linkedin_posts (source_post_id);
@@ -0,0 +1,10 @@
+-- migrations/006_schema_migrations.sql
+-- Records which migrations have been applied so readiness checks can detect a
+-- database that is behind the code. Every later migration must insert its
+-- own version and bump db.SchemaVersion.
+create table schema_migrations (
+  version int primary key,
+  applied_at timestamptz not null default now()
+);
+
+insert into schema_migrations (version) values (1), (2), (3), (4), (5), (6);
@@ -0,0 +1,12 @@
+-- migrations/007_runtime_settings.sql
+-- Every change to the runtime settings inserts a new version; the highest
+-- version is the one in effect and older rows are the change history.
+create table runtime_settings (
+  version bigint primary key,
+  settings jsonb not null,
+  changed_by text not null default '',
+  comment text not null default '',
+  created_at timestamptz not null default now()
+);
+
+insert into schema_migrations (version) values (7);
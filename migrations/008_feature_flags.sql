@@ -0,0 +1,12 @@
+-- migrations/008_feature_flags.sql
+create table feature_flags (
+  key text primary key,
+  description text not null default '',
+  enabled boolean not null default false,
+  variants text[] not null,
+  default_variant text not null,
+  rules jsonb not null default '[]',
+  updated_at timestamptz not null default now()
+);
+
+insert into schema_migrations (version) values (8);
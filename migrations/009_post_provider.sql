@@ -0,0 +1,6 @@
+-- migrations/009_post_provider.sql
+alter table linkedin_posts
+  add column provider text not null default '',
+  add column model text not null default '';
+
+insert into schema_migrations (version) values (9);
@@ -0,0 +1,5 @@
+-- migrations/010_post_moderation.sql
+alter table linkedin_posts
+  add column moderation_flags jsonb not null default '[]';
+
+insert into schema_migrations (version) values (10);
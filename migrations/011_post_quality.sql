@@ -0,0 +1,8 @@
+-- migrations/011_post_quality.sql
+alter table linkedin_posts
+  add column quality jsonb;
+
+create index linkedin_posts_user_quality_idx
+  on linkedin_posts (user_id, ((quality->>'score')::int) desc nulls last);
+
+insert into schema_migrations (version) values (11);
@@ -0,0 +1,15 @@
+-- migrations/012_post_images.sql
+create table post_images (
+  id uuid primary key default uuid_generate_v4(),
+  post_id uuid not null references linkedin_posts(id) on delete cascade,
+  user_id uuid not null references users(id) on delete cascade,
+  blob_key text not null,
+  content_type text not null,
+  size bigint not null,
+  source text not null,
+  created_at timestamptz not null default now()
+);
+
+create index post_images_post_id_idx on post_images (post_id, created_at);
+
+insert into schema_migrations (version) values (12);